@@ -10,13 +10,14 @@ import (
 	"github.com/AndreyAD1/helsinki-guide/cmd/global_flags"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/configuration"
-	"github.com/caarlos0/env/v9"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
 	"github.com/spf13/cobra"
 )
 
 var (
-	botToken string
-	BotCmd   = &cobra.Command{
+	botToken   string
+	configPath string
+	BotCmd     = &cobra.Command{
 		Use:   "bot",
 		Short: "Run a Telegram bot",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -36,16 +37,18 @@ func init() {
 		"",
 		"a token of Telegram bot",
 	)
+	BotCmd.Flags().StringVarP(
+		&configPath,
+		"config",
+		"c",
+		"",
+		"a YAML configuration file, environment variables override its values",
+	)
 }
 
 func run() error {
 	ctx := context.Background()
-	handlerOptions := slog.HandlerOptions{
-		AddSource: true,
-		Level:     logLevel,
-	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &handlerOptions))
-	slog.SetDefault(logger)
+	setLogger(true)
 
 	if global_flags.Debug {
 		os.Setenv("DEBUG", "true")
@@ -54,8 +57,7 @@ func run() error {
 		os.Setenv("BOT_TOKEN", botToken)
 	}
 
-	config := configuration.StartupConfig{}
-	err := env.Parse(&config)
+	config, err := configuration.LoadStartupConfig(configPath)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
@@ -64,8 +66,9 @@ func run() error {
 		)
 		return fmt.Errorf("a configuration error: %w", err)
 	}
-	if config.Debug {
-		logLevel.Set(slog.LevelDebug)
+	logLevel.Set(config.GetLogLevel())
+	if config.LogPrivateData {
+		setLogger(false)
 	}
 	defer func() {
 		p := recover()
@@ -88,10 +91,22 @@ func run() error {
 		}
 		run()
 	}()
-	server, err := bot.NewServer(ctx, config)
+	server, err := bot.NewServer(ctx, config, configPath, logLevel)
 	if err != nil {
 		return fmt.Errorf("can not create a new server: %w", err)
 	}
 	defer server.Shutdown(10 * time.Second)
 	return server.RunBot(ctx)
 }
+
+func setLogger(redact bool) {
+	handlerOptions := slog.HandlerOptions{
+		AddSource: true,
+		Level:     logLevel,
+	}
+	if redact {
+		handlerOptions.ReplaceAttr = logger.Redact
+	}
+	handler := slog.NewJSONHandler(os.Stdout, &handlerOptions)
+	slog.SetDefault(slog.New(logger.NewContextHandler(handler)))
+}
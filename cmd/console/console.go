@@ -0,0 +1,82 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/AndreyAD1/helsinki-guide/cmd/global_flags"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/configuration"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configPath string
+	userID     int64
+	ConsoleCmd = &cobra.Command{
+		Use:   "console",
+		Short: "Talk to the bot in a terminal",
+		Long: `The console reads messages from stdin and prints answers of the bot.
+A line is a command like '/addresses', a text like 'Lauttasaari',
+a location like '@60.16,24.88', '!click 2' to click the second button
+of the last message with buttons or '> text' to reply to the bot.
+The console uses the configured database without Telegram.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run()
+		},
+	}
+	// consoleDefaults fill the settings the console does not use. A salt
+	// must differ from a token, console popularity events are test data.
+	consoleDefaults = map[string]string{
+		"BOT_TOKEN":        "console",
+		"POPULARITY_SALT":  "console salt",
+		"METRICS_USER":     "console",
+		"METRICS_PASSWORD": "console",
+		"METRICS_PORT":     "9090",
+	}
+)
+
+func init() {
+	ConsoleCmd.Flags().StringVarP(
+		&configPath,
+		"config",
+		"c",
+		"",
+		"a YAML configuration file, environment variables override its values",
+	)
+	ConsoleCmd.Flags().Int64VarP(
+		&userID,
+		"user",
+		"u",
+		1,
+		"a Telegram ID of a console user, add it to admin_ids to use admin commands",
+	)
+}
+
+func run() error {
+	ctx := context.Background()
+	if global_flags.Debug {
+		os.Setenv("DEBUG", "true")
+	}
+	for name, value := range consoleDefaults {
+		if _, ok := os.LookupEnv(name); !ok {
+			os.Setenv(name, value)
+		}
+	}
+	config, err := configuration.LoadStartupConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("a configuration error: %w", err)
+	}
+	// logs go to stderr to keep stdout for the answers of the bot
+	handler := slog.NewTextHandler(
+		os.Stderr,
+		&slog.HandlerOptions{Level: config.GetLogLevel()},
+	)
+	slog.SetDefault(slog.New(logger.NewContextHandler(handler)))
+	user := frontend.User{ID: userID, FirstName: "Console", LanguageCode: "en"}
+	return bot.RunConsole(ctx, config, user, os.Stdin, os.Stdout)
+}
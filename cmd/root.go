@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/AndreyAD1/helsinki-guide/cmd/bot"
+	"github.com/AndreyAD1/helsinki-guide/cmd/console"
 	"github.com/AndreyAD1/helsinki-guide/cmd/global_flags"
 	"github.com/AndreyAD1/helsinki-guide/cmd/populate_db"
 	"github.com/AndreyAD1/helsinki-guide/cmd/translate"
@@ -27,5 +28,6 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&global_flags.Debug, "debug", "d", false, "Run in a debug mode")
 	RootCmd.AddCommand(translate.TranslateCmd)
 	RootCmd.AddCommand(bot.BotCmd)
+	RootCmd.AddCommand(console.ConsoleCmd)
 	RootCmd.AddCommand(populate_db.PopulateCmd)
 }
@@ -8,6 +8,7 @@ tag=$3
 metrics_user=$4
 metrics_password=$5
 metrics_port=$6
+popularity_salt=$7
 
 container_name=helsinki-guide
 image_name=andreyad/helsinki-guide:$tag
@@ -27,8 +28,14 @@ docker run \
 --env METRICS_USER=$metrics_user \
 --env METRICS_PASSWORD=$metrics_password \
 --env METRICS_PORT=$metrics_port \
+--env POPULARITY_SALT=$popularity_salt \
 --network host \
 --log-opt tag=hguide \
 --name $container_name \
 --detach \
 $image_name
+
+# wait until the bot connects to the DB and Telegram
+curl --fail --silent --show-error \
+--retry 10 --retry-delay 3 --retry-all-errors \
+http://localhost:$metrics_port/readyz
@@ -0,0 +1,81 @@
+package integrationtests
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/configuration"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend/telegram/telegramtest"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func getFreePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// startBot runs the bot against a fake Bot API until a test ends.
+func startBot(t *testing.T, api *telegramtest.Server) {
+	config := configuration.StartupConfig{
+		BotAPIToken:         telegramtest.Token,
+		BotAPIEndpoint:      api.Endpoint(),
+		DatabaseURL:         databaseUrl,
+		TGUpdateTimeout:     1,
+		UpdateReadersNumber: 2,
+		MetricsUser:         "user",
+		MetricsPassword:     "password",
+		MetricsPort:         getFreePort(t),
+		PopularitySalt:      "test salt",
+		HandlerTimeout:      10,
+		RateLimit:           30,
+		PopularityRetention: 90,
+		BuildingCacheTTL:    300,
+		UserCacheTTL:        3600,
+		LogLevel:            "debug",
+		SearchRadius:        100,
+		PageSize:            10,
+		APIRateLimit:        60,
+	}
+	require.NoError(t, config.Validate())
+	ctx, cancel := context.WithCancel(context.Background())
+	server, err := bot.NewServer(ctx, config, "", new(slog.LevelVar))
+	require.NoError(t, err)
+	stopped := make(chan error)
+	go func() { stopped <- server.RunBot(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-stopped)
+		server.Shutdown(10 * time.Second)
+	})
+}
+
+func testBot(t *testing.T) {
+	api := telegramtest.NewServer(t)
+	startBot(t, api)
+	commandCalls := api.WaitForCalls(t, "setMyCommands", 2)
+	require.Contains(t, commandCalls[0].Params.Get("commands"), `"command":"settings"`)
+
+	user := tgbotapi.User{ID: 42, FirstName: "Test", LanguageCode: "en"}
+	api.SendMessage(user, "/settings")
+	sendCalls := api.WaitForCalls(t, "sendMessage", 1)
+	require.Equal(t, "42", sendCalls[0].Params.Get("chat_id"))
+	settingsMessage := api.GetMessage(t, sendCalls[0].MessageID)
+	require.NotNil(t, settingsMessage.ReplyMarkup)
+	finnishButton := settingsMessage.ReplyMarkup.InlineKeyboard[0][0]
+	require.Equal(t, "Finnish", finnishButton.Text)
+
+	api.Click(t, user, settingsMessage.MessageID, *finnishButton.CallbackData)
+	api.WaitForCalls(t, "answerCallbackQuery", 1)
+	editCalls := api.WaitForCalls(t, "editMessageText", 1)
+	require.Equal(t, settingsMessage.MessageID, editCalls[0].MessageID)
+	edited := api.GetMessage(t, settingsMessage.MessageID)
+	require.Equal(t, "I will return the building information in Finnish.", edited.Text)
+	require.Nil(t, edited.ReplyMarkup)
+}
@@ -0,0 +1,69 @@
+package integrationtests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func testGetBuildingsByBBox(t *testing.T) {
+	ctx := context.Background()
+	storageN := r.NewNeighbourhoodRepo(dbpool)
+	savedNeighbour, err := storageN.Add(ctx, r.Neighbourhood{Name: "test neighbourhood"})
+	require.NoError(t, err)
+	storage := r.NewBuildingRepo(dbpool)
+	// corners which are far from the equator are closer to the center
+	// of a box in the northern hemisphere and farther in the southern one
+	boxes := [][4]float64{
+		{60.1, 24.9, 60.2, 25.0},
+		{-60.2, 24.9, -60.1, 25.0},
+	}
+	for i, box := range boxes {
+		minLat, minLon, maxLat, maxLon := box[0], box[1], box[2], box[3]
+		corners := [][2]float64{
+			{minLat, minLon},
+			{minLat, maxLon},
+			{maxLat, minLon},
+			{maxLat, maxLon},
+		}
+		expectedIDs := []int64{}
+		for j, corner := range corners {
+			building := r.Building{
+				Address: r.Address{
+					StreetAddress:   fmt.Sprintf("corner street %v-%v", i, j),
+					NeighbourhoodID: &savedNeighbour.ID,
+				},
+				NameEn:          utils.GetPointer("corner"),
+				Latitude_WGS84:  utils.GetPointer(corner[0]),
+				Longitude_WGS84: utils.GetPointer(corner[1]),
+			}
+			saved, err := storage.Add(ctx, building)
+			require.NoError(t, err)
+			expectedIDs = append(expectedIDs, saved.ID)
+		}
+		outside := r.Building{
+			Address: r.Address{
+				StreetAddress:   fmt.Sprintf("outside street %v", i),
+				NeighbourhoodID: &savedNeighbour.ID,
+			},
+			NameEn:          utils.GetPointer("outside"),
+			Latitude_WGS84:  utils.GetPointer(maxLat + 0.001),
+			Longitude_WGS84: utils.GetPointer(maxLon),
+		}
+		_, err := storage.Add(ctx, outside)
+		require.NoError(t, err)
+
+		spec := r.NewBuildingSpecificationByBBox(minLat, minLon, maxLat, maxLon, 10)
+		got, err := storage.Query(ctx, spec)
+		require.NoError(t, err)
+		gotIDs := []int64{}
+		for _, building := range got {
+			gotIDs = append(gotIDs, building.ID)
+		}
+		require.Equal(t, expectedIDs, gotIDs, "box %v", box)
+	}
+}
@@ -0,0 +1,98 @@
+package integrationtests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+const benchmarkBuildingNumber = 50
+
+// queryCounter counts round trips to a database.
+type queryCounter struct {
+	queries atomic.Int64
+}
+
+func (c *queryCounter) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	_ pgx.TraceQueryStartData,
+) context.Context {
+	c.queries.Add(1)
+	return ctx
+}
+
+func (c *queryCounter) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// BenchmarkBuildingStorage_Query reports a number of queries per page,
+// which should not depend on a page size.
+func BenchmarkBuildingStorage_Query(b *testing.B) {
+	ctx := context.Background()
+	migrator, err := migrate.New("file:"+MIGRATION_PATH, databaseUrl)
+	require.NoError(b, err)
+	err = migrator.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(b, err)
+	}
+	b.Cleanup(func() { migrator.Down() })
+	previousLevel := logLevel.Level()
+	logLevel.Set(slog.LevelInfo)
+	b.Cleanup(func() { logLevel.Set(previousLevel) })
+
+	counter := &queryCounter{}
+	config, err := pgxpool.ParseConfig(databaseUrl)
+	require.NoError(b, err)
+	config.ConnConfig.Tracer = counter
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(b, err)
+	b.Cleanup(pool.Close)
+
+	neighbourhood, err := r.NewNeighbourhoodRepo(pool).Add(
+		ctx,
+		r.Neighbourhood{Name: "benchmark neighbourhood"},
+	)
+	require.NoError(b, err)
+	author, err := r.NewActorRepo(pool).Add(ctx, r.Actor{Name: "benchmark author"})
+	require.NoError(b, err)
+	storage := r.NewBuildingRepo(pool)
+	for i := 0; i < benchmarkBuildingNumber; i++ {
+		building := r.Building{
+			Address: r.Address{
+				StreetAddress:   fmt.Sprintf("benchmark street %v", i),
+				NeighbourhoodID: &neighbourhood.ID,
+			},
+			AuthorIDs:   []int64{author.ID},
+			InitialUses: []r.UseType{{NameFi: "use fi", NameEn: "use en", NameRu: "use ru"}},
+			CurrentUses: []r.UseType{{NameFi: "use fi", NameEn: "use en", NameRu: "use ru"}},
+		}
+		_, err := storage.Add(ctx, building)
+		require.NoError(b, err)
+	}
+
+	for _, pageSize := range []int{1, 10, benchmarkBuildingNumber} {
+		b.Run(fmt.Sprintf("page size %v", pageSize), func(b *testing.B) {
+			spec := r.NewBuildingSpecificationAll(pageSize, 0)
+			counter.queries.Store(0)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buildings, err := storage.Query(ctx, spec)
+				require.NoError(b, err)
+				require.Len(b, buildings, pageSize)
+			}
+			b.StopTimer()
+			queriesPerPage := float64(counter.queries.Load()) / float64(b.N)
+			b.ReportMetric(queriesPerPage, "queries/op")
+			// a building query, an author query and two use queries
+			require.Equal(b, 4.0, queriesPerPage)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func testGetBuildingsByNeighbourhoodAndAuthor(t *testing.T) {
+	ctx := context.Background()
+	storageN := r.NewNeighbourhoodRepo(dbpool)
+	neighbourhood1, err := storageN.Add(ctx, r.Neighbourhood{Name: "neighbourhood 1"})
+	require.NoError(t, err)
+	neighbourhood2, err := storageN.Add(ctx, r.Neighbourhood{Name: "neighbourhood 2"})
+	require.NoError(t, err)
+	actorStorage := r.NewActorRepo(dbpool)
+	author, err := actorStorage.Add(ctx, r.Actor{Name: "test author"})
+	require.NoError(t, err)
+
+	storage := r.NewBuildingRepo(dbpool)
+	building1, err := storage.Add(ctx, r.Building{
+		Address: r.Address{
+			StreetAddress:   "b street",
+			NeighbourhoodID: &neighbourhood1.ID,
+		},
+		AuthorIDs: []int64{author.ID},
+	})
+	require.NoError(t, err)
+	building2, err := storage.Add(ctx, r.Building{
+		Address: r.Address{
+			StreetAddress:   "a street",
+			NeighbourhoodID: &neighbourhood1.ID,
+		},
+	})
+	require.NoError(t, err)
+	building3, err := storage.Add(ctx, r.Building{
+		Address: r.Address{
+			StreetAddress:   "c street",
+			NeighbourhoodID: &neighbourhood2.ID,
+		},
+		AuthorIDs: []int64{author.ID},
+	})
+	require.NoError(t, err)
+
+	spec := r.NewBuildingSpecificationByNeighbourhood(neighbourhood1.ID, 10, 0)
+	buildings, err := storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buildings))
+	require.Equal(t, building2.ID, buildings[0].ID)
+	require.Equal(t, building1.ID, buildings[1].ID)
+
+	spec = r.NewBuildingSpecificationByAuthor(author.ID, 10, 0)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buildings))
+	require.Equal(t, building1.ID, buildings[0].ID)
+	require.Equal(t, building3.ID, buildings[1].ID)
+
+	spec = r.NewBuildingSpecificationByAuthor(author.ID, 10, 1)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buildings))
+
+	idSpec := r.NewBuildingSpecificationByIDs([]int64{building3.ID, building1.ID})
+	buildings, err = storage.Query(ctx, idSpec)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buildings))
+	require.Equal(t, building1.ID, buildings[0].ID)
+	require.Equal(t, building3.ID, buildings[1].ID)
+}
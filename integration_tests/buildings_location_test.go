@@ -224,6 +224,7 @@ func testGetNearestBuildings(t *testing.T) {
 				"Address.ID",
 				"Address.CreatedAt",
 				"CreatedAt",
+				"DistanceMeters",
 			)
 			require.Equal(
 				t,
@@ -235,6 +236,25 @@ func testGetNearestBuildings(t *testing.T) {
 				),
 				"",
 			)
+			previousDistance := 0.0
+			for _, building := range got {
+				require.NotNil(t, building.DistanceMeters)
+				require.GreaterOrEqual(t, *building.DistanceMeters, previousDistance)
+				require.LessOrEqual(t, *building.DistanceMeters, float64(tt.distance))
+				previousDistance = *building.DistanceMeters
+			}
+			countSpecification := r.NewBuildingCountSpecificationNearest(
+				tt.distance,
+				tt.latitude,
+				tt.longitude,
+			)
+			count, err := storage.Count(context.Background(), countSpecification)
+			require.NoError(t, err)
+			if tt.offset == 0 && len(tt.expectedBuildings) < tt.limit {
+				require.Equal(t, len(tt.expectedBuildings), count)
+			} else {
+				require.GreaterOrEqual(t, count, tt.offset+len(tt.expectedBuildings))
+			}
 		})
 	}
 }
@@ -0,0 +1,37 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+)
+
+func testChatRepository(t *testing.T) {
+	storage := r.NewChatRepo(dbpool)
+	chat := r.Chat{TelegramID: -100123, PreferredLanguage: "fi"}
+	saved, err := storage.AddOrUpdate(context.Background(), chat)
+	require.NoError(t, err)
+	require.NotEqualValues(t, 0, saved.ID)
+
+	spec := r.NewChatSpecificationByID(-100123)
+	stored, err := storage.Query(context.Background(), spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(stored))
+	require.Equal(
+		t,
+		"",
+		cmp.Diff(*saved, stored[0], cmpopts.IgnoreUnexported(r.Timestamps{})),
+	)
+
+	saved.PreferredLanguage = "ru"
+	_, err = storage.AddOrUpdate(context.Background(), *saved)
+	require.NoError(t, err)
+	stored, err = storage.Query(context.Background(), spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(stored))
+	require.Equal(t, "ru", stored[0].PreferredLanguage)
+}
@@ -0,0 +1,112 @@
+package integrationtests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+)
+
+func testCorrectionRepository(t *testing.T) {
+	ctx := context.Background()
+	storageN := r.NewNeighbourhoodRepo(dbpool)
+	neighbourbourhood := r.Neighbourhood{Name: "test neighbourhood"}
+	savedNeighbour, err := storageN.Add(ctx, neighbourbourhood)
+	require.NoError(t, err)
+	buildingStorage := r.NewBuildingRepo(dbpool)
+	nameEn := "test_building"
+	building := r.Building{
+		NameEn: &nameEn,
+		Address: r.Address{
+			StreetAddress:   "test street",
+			NeighbourhoodID: &savedNeighbour.ID,
+		},
+	}
+	savedBuilding, err := buildingStorage.Add(ctx, building)
+	require.NoError(t, err)
+
+	storage := r.NewCorrectionRepo(dbpool)
+	correction := r.Correction{
+		BuildingID: savedBuilding.ID,
+		Field:      "history",
+		Language:   "en",
+		Value:      "new history",
+		ReporterID: 123,
+		ChatID:     456,
+	}
+	saved, err := storage.Add(ctx, correction)
+	require.NoError(t, err)
+	require.NotEqualValues(t, 0, saved.ID)
+	require.Equal(t, r.CorrectionPending, saved.Status)
+
+	correction.BuildingID = savedBuilding.ID + 1
+	_, err = storage.Add(ctx, correction)
+	require.ErrorIs(t, err, r.ErrNoDependency)
+
+	pendingSpec := r.NewCorrectionSpecificationByStatus(r.CorrectionPending, 10, 0)
+	pending, err := storage.Query(ctx, pendingSpec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pending))
+	require.Equal(
+		t,
+		"",
+		cmp.Diff(*saved, pending[0], cmpopts.IgnoreUnexported(r.Timestamps{})),
+	)
+
+	saved.Status = r.CorrectionAccepted
+	updated, err := storage.Update(ctx, *saved)
+	require.NoError(t, err)
+	require.NotNil(t, updated.UpdatedAt)
+
+	pending, err = storage.Query(ctx, pendingSpec)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(pending))
+	stored, err := storage.Query(ctx, r.NewCorrectionSpecificationByID(saved.ID))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(stored))
+	require.Equal(t, r.CorrectionAccepted, stored[0].Status)
+	// a moderated correction can not be moderated again
+	saved.Status = r.CorrectionRejected
+	_, err = storage.Update(ctx, *saved)
+	require.ErrorIs(t, err, r.ErrNotExist)
+
+	// a failed transaction reverts a status and a building
+	correction.BuildingID = savedBuilding.ID
+	another, err := storage.Add(ctx, correction)
+	require.NoError(t, err)
+	another.Status = r.CorrectionAccepted
+	buildingErr := errors.New("test error")
+	err = r.NewTransactionRepo(dbpool).Run(ctx, func(ctx context.Context) error {
+		if _, err := storage.Update(ctx, *another); err != nil {
+			return err
+		}
+		changed := *savedBuilding
+		changed.HistoryEn = &another.Value
+		if _, err := buildingStorage.Update(ctx, changed); err != nil {
+			return err
+		}
+		// a transaction reads its own uncommitted changes
+		spec := r.NewBuildingSpecificationByIDForUpdate(savedBuilding.ID)
+		locked, err := buildingStorage.Query(ctx, spec)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, &another.Value, locked[0].HistoryEn)
+		return buildingErr
+	})
+	require.ErrorIs(t, err, buildingErr)
+	stored, err = storage.Query(ctx, r.NewCorrectionSpecificationByID(another.ID))
+	require.NoError(t, err)
+	require.Equal(t, r.CorrectionPending, stored[0].Status)
+	buildings, err := buildingStorage.Query(ctx, r.NewBuildingSpecificationByID(savedBuilding.ID))
+	require.NoError(t, err)
+	require.Nil(t, buildings[0].HistoryEn)
+
+	require.NoError(t, storage.Remove(ctx, *saved))
+	_, err = storage.Update(ctx, *saved)
+	require.ErrorIs(t, err, r.ErrNotExist)
+}
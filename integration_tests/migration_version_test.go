@@ -0,0 +1,19 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/migrations"
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func testMigrationRepository(t *testing.T) {
+	expected, err := migrations.LatestVersion()
+	require.NoError(t, err)
+	version, dirty, err := r.NewMigrationRepo(dbpool).GetVersion(context.Background())
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.Equal(t, expected, version)
+}
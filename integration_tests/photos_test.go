@@ -0,0 +1,78 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func testPhotoRepository(t *testing.T) {
+	ctx := context.Background()
+	storageN := r.NewNeighbourhoodRepo(dbpool)
+	neighbourbourhood := r.Neighbourhood{Name: "test neighbourhood"}
+	savedNeighbour, err := storageN.Add(ctx, neighbourbourhood)
+	require.NoError(t, err)
+	buildingStorage := r.NewBuildingRepo(dbpool)
+	nameEn := "test_building"
+	building := r.Building{
+		NameEn: &nameEn,
+		Address: r.Address{
+			StreetAddress:   "test street",
+			NeighbourhoodID: &savedNeighbour.ID,
+		},
+	}
+	savedBuilding, err := buildingStorage.Add(ctx, building)
+	require.NoError(t, err)
+
+	storage := r.NewPhotoRepo(dbpool)
+	photo := r.BuildingPhoto{
+		BuildingID:   savedBuilding.ID,
+		FileID:       "file_id",
+		FileUniqueID: "file_unique_id",
+		Attribution:  "test author",
+		UploaderID:   123,
+		ChatID:       456,
+	}
+	saved, err := storage.Add(ctx, photo)
+	require.NoError(t, err)
+	require.NotEqualValues(t, 0, saved.ID)
+	require.Equal(t, r.PhotoPending, saved.Status)
+
+	_, err = storage.Add(ctx, photo)
+	require.ErrorIs(t, err, r.ErrDuplicate)
+	photo.BuildingID = savedBuilding.ID + 1
+	_, err = storage.Add(ctx, photo)
+	require.ErrorIs(t, err, r.ErrNoDependency)
+
+	acceptedSpec := r.NewPhotoSpecificationByBuilding(
+		savedBuilding.ID,
+		r.PhotoAccepted,
+		10,
+	)
+	accepted, err := storage.Query(ctx, acceptedSpec)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(accepted))
+
+	saved.Status = r.PhotoAccepted
+	updated, err := storage.Update(ctx, *saved)
+	require.NoError(t, err)
+	require.NotNil(t, updated.UpdatedAt)
+
+	accepted, err = storage.Query(ctx, acceptedSpec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(accepted))
+	require.Equal(t, "file_id", accepted[0].FileID)
+	pendingSpec := r.NewPhotoSpecificationByStatus(r.PhotoPending, 10, 0)
+	pending, err := storage.Query(ctx, pendingSpec)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(pending))
+	saved.Status = r.PhotoRejected
+	_, err = storage.Update(ctx, *saved)
+	require.ErrorIs(t, err, r.ErrNotExist)
+
+	require.NoError(t, storage.Remove(ctx, *saved))
+	_, err = storage.Update(ctx, *saved)
+	require.ErrorIs(t, err, r.ErrNotExist)
+}
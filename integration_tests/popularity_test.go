@@ -0,0 +1,104 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func testPopularityRepository(t *testing.T) {
+	ctx := context.Background()
+	neighbourhood, err := r.NewNeighbourhoodRepo(dbpool).
+		Add(ctx, r.Neighbourhood{Name: "Lauttasaari"})
+	require.NoError(t, err)
+	storage := r.NewBuildingRepo(dbpool)
+	building1, err := storage.Add(ctx, r.Building{
+		NameEn: utils.GetPointer("building 1"),
+		Address: r.Address{
+			StreetAddress:   "test street 1",
+			NeighbourhoodID: &neighbourhood.ID,
+		},
+	})
+	require.NoError(t, err)
+	building2, err := storage.Add(ctx, r.Building{
+		NameEn:  utils.GetPointer("building 2"),
+		Address: r.Address{StreetAddress: "test street 1"},
+	})
+	require.NoError(t, err)
+	building3, err := storage.Add(ctx, r.Building{
+		Address: r.Address{StreetAddress: "test street 2"},
+	})
+	require.NoError(t, err)
+
+	popularityStorage := r.NewPopularityRepo(dbpool)
+	for _, buildingID := range []int64{building2.ID, building2.ID, building1.ID} {
+		event, err := popularityStorage.Add(ctx, r.PopularityEvent{
+			EventType:  r.ViewEvent,
+			UserHash:   "hash",
+			BuildingID: &buildingID,
+		})
+		require.NoError(t, err)
+		require.NotZero(t, event.ID)
+	}
+	searchType, query := "address", "test"
+	_, err = popularityStorage.Add(ctx, r.PopularityEvent{
+		EventType:   r.SearchEvent,
+		UserHash:    "hash",
+		SearchType:  &searchType,
+		SearchQuery: &query,
+	})
+	require.NoError(t, err)
+	absentID := building3.ID + 100
+	_, err = popularityStorage.Add(ctx, r.PopularityEvent{
+		EventType:  r.ViewEvent,
+		UserHash:   "hash",
+		BuildingID: &absentID,
+	})
+	require.ErrorIs(t, err, r.ErrNoDependency)
+
+	since := time.Now().Add(-time.Hour)
+	buildings, err := storage.Query(ctx, r.NewBuildingSpecificationPopular(since, "", 10))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buildings))
+	require.Equal(t, building2.ID, buildings[0].ID)
+	require.Equal(t, building1.ID, buildings[1].ID)
+
+	spec := r.NewBuildingSpecificationPopular(since, "lauttasaari", 10)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buildings))
+	require.Equal(t, building1.ID, buildings[0].ID)
+
+	spec = r.NewBuildingSpecificationPopular(time.Now().Add(time.Hour), "", 10)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(buildings))
+
+	// the more viewed building goes first among buildings with the same address
+	spec = r.NewBuildingSpecificationByAlikeAddress("test street", 10, 0)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(buildings))
+	require.Equal(t, building2.ID, buildings[0].ID)
+	require.Equal(t, building1.ID, buildings[1].ID)
+	require.Equal(t, building3.ID, buildings[2].ID)
+
+	removed, err := popularityStorage.RemoveBefore(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), removed)
+	removed, err = popularityStorage.RemoveBefore(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(4), removed)
+
+	// removed events do not count
+	spec = r.NewBuildingSpecificationByAlikeAddress("test street 1", 10, 0)
+	buildings, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buildings))
+	require.Equal(t, building1.ID, buildings[0].ID)
+	require.Equal(t, building2.ID, buildings[1].ID)
+}
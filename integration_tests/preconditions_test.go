@@ -169,8 +169,17 @@ var integrationTests = []integrationTest{
 	{"addBuildingAddressError", testAddNewBuildingAddressError},
 	{"addBuildingAuthorError", testAddNewBuildingAuthorError},
 	{"getNearestBuildings", testGetNearestBuildings},
+	{"getBuildingsByBBox", testGetBuildingsByBBox},
 	{"updateAbsentBuilding", testUpdateAbsentBuilding},
 	{"manageRemovedBuilding", testManageRemovedBuilding},
 	{"runPopulator", testRunPopulator},
 	{"addUser", testUserRepository},
+	{"corrections", testCorrectionRepository},
+	{"photos", testPhotoRepository},
+	{"chats", testChatRepository},
+	{"updates", testUpdateRepository},
+	{"migrationVersion", testMigrationRepository},
+	{"buildingsByNeighbourhoodAndAuthor", testGetBuildingsByNeighbourhoodAndAuthor},
+	{"popularity", testPopularityRepository},
+	{"bot", testBot},
 }
@@ -0,0 +1,48 @@
+package integrationtests
+
+import (
+	"context"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/require"
+)
+
+func testUpdateRepository(t *testing.T) {
+	ctx := context.Background()
+	storage := r.NewUpdateRepo(dbpool)
+	offset, err := storage.GetOffset(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, offset)
+
+	for _, updateID := range []int64{10, 11, 12} {
+		saved, err := storage.Add(ctx, r.ProcessedUpdate{UpdateID: updateID})
+		require.NoError(t, err)
+		require.Equal(t, updateID, saved.UpdateID)
+	}
+	_, err = storage.Add(ctx, r.ProcessedUpdate{UpdateID: 11})
+	require.ErrorIs(t, err, r.ErrDuplicate)
+	exists, err := storage.Exists(ctx, 11)
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = storage.Exists(ctx, 13)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, storage.SetOffset(ctx, 12))
+	offset, err = storage.GetOffset(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, offset)
+
+	// updates before the offset are removed from the ledger
+	_, err = storage.Add(ctx, r.ProcessedUpdate{UpdateID: 11})
+	require.NoError(t, err)
+	_, err = storage.Add(ctx, r.ProcessedUpdate{UpdateID: 12})
+	require.ErrorIs(t, err, r.ErrDuplicate)
+
+	// an offset never decreases
+	require.NoError(t, storage.SetOffset(ctx, 5))
+	offset, err = storage.GetOffset(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, offset)
+}
@@ -49,4 +49,12 @@ func testUserRepository(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(stored2))
 	require.Equal(t, *updated, stored2[0])
+
+	_, err = storage.AddOrUpdate(context.Background(), r.User{TelegramID: 124, PreferredLanguage: "en"})
+	require.NoError(t, err)
+	_, err = storage.AddOrUpdate(context.Background(), r.User{TelegramID: 125, PreferredLanguage: "ru"})
+	require.NoError(t, err)
+	counts, err := storage.CountPerLanguage(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"en": 2, "ru": 1}, counts)
 }
@@ -0,0 +1,216 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/middlewares"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+	// rateLimitPeriod is a period of rate limits of API keys
+	rateLimitPeriod = time.Minute
+)
+
+//go:embed openapi.yaml
+var openAPIDocument []byte
+
+// apiError is an error which a client can fix, it is sent to the client.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e apiError) Error() string {
+	return e.message
+}
+
+var errNotFound = apiError{http.StatusNotFound, "not found"}
+
+func badRequest(format string, args ...any) error {
+	return apiError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handlerFunc returns a response body of a request in a language.
+type handlerFunc func(r *http.Request, language services.Language) (any, error)
+
+type handler struct {
+	buildingService services.Buildings
+	catalogService  services.Catalog
+	userService     services.Users
+	// getLanguage selects a language of a response
+	getLanguage func(r *http.Request) (services.Language, error)
+}
+
+// NewHandler returns a read-only JSON API of the dataset. Every endpoint,
+// except the OpenAPI document, requires a key from a rate limit per key.
+// Limits must be positive, the configuration validation ensures it.
+func NewHandler(
+	buildingService services.Buildings,
+	catalogService services.Catalog,
+	limitPerKey map[string]int,
+	m *metrics.Metrics,
+) http.Handler {
+	h := handler{buildingService, catalogService, nil, getLanguage}
+	limiter := middlewares.NewRateLimiter[string](rateLimitPeriod, time.Now)
+	mux := http.NewServeMux()
+	h.addDataRoutes(mux, "/v1", m, func(next http.Handler) http.Handler {
+		return authorize(limitPerKey, limiter, next)
+	})
+	mux.Handle("/v1/openapi.yaml", count("/v1/openapi.yaml", m, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/yaml")
+			w.Write(openAPIDocument)
+		},
+	)))
+	mux.Handle("/", count("/", m, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, r, errNotFound)
+		},
+	)))
+	return mux
+}
+
+// addDataRoutes registers endpoints of the dataset under a path prefix.
+func (h handler) addDataRoutes(
+	mux *http.ServeMux,
+	prefix string,
+	m *metrics.Metrics,
+	authenticate func(http.Handler) http.Handler,
+) {
+	route := func(path string, f handlerFunc) {
+		pattern := prefix + path
+		mux.Handle(pattern, count(pattern, m, authenticate(h.serve(pattern, http.MethodGet, f))))
+	}
+	route("/buildings", h.getBuildings)
+	route("/buildings/", h.getBuilding)
+	route("/buildings/nearest", h.getNearestBuildings)
+	route("/buildings/geojson", h.getGeoJSON)
+	route("/neighbourhoods", h.getNeighbourhoods)
+	route("/architects", h.getArchitects)
+}
+
+// serve writes a response of a handler as JSON. A GET handler
+// also serves HEAD requests.
+func (h handler) serve(name, method string, f handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isHead := method == http.MethodGet && r.Method == http.MethodHead
+		if r.Method != method && !isHead {
+			allowed := method
+			if method == http.MethodGet {
+				allowed = "GET, HEAD"
+			}
+			w.Header().Set("Allow", allowed)
+			writeError(w, r, apiError{http.StatusMethodNotAllowed, "method not allowed"})
+			return
+		}
+		ctx, span := tracing.Start(r.Context(), "API "+name)
+		r = r.WithContext(ctx)
+		w.Header().Set("Vary", "Accept-Language")
+		language, err := h.getLanguage(r)
+		var body any
+		if err == nil {
+			w.Header().Set("Content-Language", string(language))
+			body, err = f(r, language)
+		}
+		var clientErr apiError
+		if errors.As(err, &clientErr) {
+			tracing.End(span, nil)
+		} else {
+			tracing.End(span, err)
+		}
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, r, http.StatusOK, body)
+	})
+}
+
+// authorize rejects requests without a known API key
+// and requests over a limit of a key.
+func authorize(
+	limitPerKey map[string]int,
+	limiter *middlewares.RateLimiter[string],
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(apiKeyHeader)
+		limit, ok := limitPerKey[key]
+		if key == "" || !ok {
+			writeError(w, r, apiError{
+				http.StatusUnauthorized,
+				"a valid " + apiKeyHeader + " header is required",
+			})
+			return
+		}
+		if !limiter.Allow(key, limit) {
+			// a bucket gets a new token in this time
+			retryAfter := math.Ceil(rateLimitPeriod.Seconds() / float64(limit))
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+			writeError(w, r, apiError{http.StatusTooManyRequests, "too many requests"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// count counts requests per path pattern and status code.
+func count(name string, m *metrics.Metrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{w, http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		m.APIRequests.With(prometheus.Labels{
+			"endpoint": name,
+			"code":     strconv.Itoa(recorder.status),
+		}).Inc()
+	})
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var clientErr apiError
+	if !errors.As(err, &clientErr) {
+		slog.ErrorContext(
+			r.Context(),
+			fmt.Sprintf("can not serve an API request %v", r.URL.Path),
+			slog.Any(logger.ErrorKey, err),
+		)
+		clientErr = apiError{http.StatusInternalServerError, "internal error"}
+	}
+	writeJSON(w, r, clientErr.status, errorResponse{clientErr.message})
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.WarnContext(r.Context(), "can not write an API response", slog.Any(logger.ErrorKey, err))
+	}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "test key"
+
+type testAPI struct {
+	handler   http.Handler
+	buildings *services.Buildings_mock
+	catalog   *services.Catalog_mock
+	metrics   *metrics.Metrics
+}
+
+func newTestAPI(t *testing.T, limit int) testAPI {
+	buildings := services.NewBuildings_mock(t)
+	catalog := services.NewCatalog_mock(t)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	handler := NewHandler(buildings, catalog, map[string]int{testKey: limit}, m)
+	return testAPI{handler, buildings, catalog, m}
+}
+
+func (a testAPI) get(t *testing.T, target string, header http.Header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set(apiKeyHeader, testKey)
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	recorder := httptest.NewRecorder()
+	a.handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func decodeBody[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	var body T
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+	return body
+}
+
+func TestHandler_authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         http.Header
+		expectedStatus int
+	}{
+		{"a known key", nil, http.StatusOK},
+		{"an unknown key", http.Header{"X-Api-Key": {"unknown"}}, http.StatusUnauthorized},
+		{"no key", http.Header{"X-Api-Key": {""}}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			if tt.expectedStatus == http.StatusOK {
+				api.catalog.EXPECT().
+					GetNeighbourhoods(mock.Anything, defaultLimit+1, 0).
+					Return(nil, nil)
+			}
+			recorder := api.get(t, "/v1/neighbourhoods", tt.header)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestHandler_rateLimit(t *testing.T) {
+	api := newTestAPI(t, 2)
+	api.catalog.EXPECT().GetArchitects(mock.Anything, defaultLimit+1, 0).Return(nil, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		require.Equal(t, http.StatusOK, api.get(t, "/v1/architects", nil).Code)
+	}
+	recorder := api.get(t, "/v1/architects", nil)
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+	require.Equal(t, errorResponse{"too many requests"}, decodeBody[errorResponse](t, recorder))
+	rejected := testutil.ToFloat64(api.metrics.APIRequests.WithLabelValues("/v1/architects", "429"))
+	require.Equal(t, 1.0, rejected)
+}
+
+func TestHandler_errors(t *testing.T) {
+	api := newTestAPI(t, 10)
+	api.catalog.EXPECT().
+		GetNeighbourhoods(mock.Anything, defaultLimit+1, 0).
+		Return(nil, errors.New("test error"))
+	recorder := api.get(t, "/v1/neighbourhoods", nil)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Equal(t, errorResponse{"internal error"}, decodeBody[errorResponse](t, recorder))
+
+	recorder = api.get(t, "/v1/unknown", nil)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/architects", nil)
+	request.Header.Set(apiKeyHeader, testKey)
+	recorder = httptest.NewRecorder()
+	api.handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	require.Equal(t, "GET, HEAD", recorder.Header().Get("Allow"))
+}
+
+func TestHandler_openAPIDocument(t *testing.T) {
+	api := newTestAPI(t, 10)
+	request := httptest.NewRequest(http.MethodGet, "/v1/openapi.yaml", nil)
+	recorder := httptest.NewRecorder()
+	api.handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, openAPIDocument, recorder.Body.Bytes())
+}
+
+func TestHandler_getArchitects(t *testing.T) {
+	titleFi, titleEn := "arkkitehti", "architect"
+	api := newTestAPI(t, 10)
+	architects := []services.ArchitectDTO{
+		{ID: 1, Name: "Alvar Aalto", TitleFi: &titleFi, TitleEn: &titleEn},
+		{ID: 2, Name: "Eliel Saarinen"},
+	}
+	api.catalog.EXPECT().GetArchitects(mock.Anything, 2, 0).Return(architects, nil)
+	recorder := api.get(t, "/v1/architects?limit=1&lang=fi", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	expected := page[architect]{
+		Items:      []architect{{1, "Alvar Aalto", &titleFi}},
+		NextCursor: encodeCursor(1),
+	}
+	require.Equal(t, expected, decodeBody[page[architect]](t, recorder))
+}
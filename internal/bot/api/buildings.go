@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+type buildingPreview struct {
+	ID             int64    `json:"id"`
+	Address        string   `json:"address"`
+	Name           *string  `json:"name"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	DistanceMeters *float64 `json:"distance_meters,omitempty"`
+}
+
+type building struct {
+	ID              int64    `json:"id"`
+	Address         string   `json:"address"`
+	Name            *string  `json:"name"`
+	Description     *string  `json:"description"`
+	CompletionYear  *int     `json:"completion_year"`
+	Authors         []string `json:"authors"`
+	Facades         *string  `json:"facades"`
+	Details         *string  `json:"details"`
+	NotableFeatures *string  `json:"notable_features"`
+	Surroundings    *string  `json:"surroundings"`
+	History         *string  `json:"history"`
+	Latitude        *float64 `json:"latitude"`
+	Longitude       *float64 `json:"longitude"`
+}
+
+func newBuildingPreview(language services.Language) func(services.BuildingDTO) buildingPreview {
+	return func(b services.BuildingDTO) buildingPreview {
+		return buildingPreview{
+			b.ID,
+			b.Address,
+			localize(language, b.NameFi, b.NameEn, b.NameRu),
+			b.Latitude,
+			b.Longitude,
+			b.DistanceMeters,
+		}
+	}
+}
+
+func newBuilding(b services.BuildingDTO, language services.Language) building {
+	authors := []string{}
+	if b.Authors != nil {
+		authors = *b.Authors
+	}
+	return building{
+		b.ID,
+		b.Address,
+		localize(language, b.NameFi, b.NameEn, b.NameRu),
+		localize(language, b.DescriptionFi, b.DescriptionEn, b.DescriptionRu),
+		b.CompletionYear,
+		authors,
+		localize(language, b.FacadesFi, b.FacadesEn, b.FacadesRu),
+		localize(language, b.DetailsFi, b.DetailsEn, b.DetailsRu),
+		localize(language, b.NotableFeaturesFi, b.NotableFeaturesEn, b.NotableFeaturesRu),
+		localize(language, b.SurroundingsFi, b.SurroundingsEn, b.SurroundingsRu),
+		localize(language, b.HistoryFi, b.HistoryEn, b.HistoryRu),
+		b.Latitude,
+		b.Longitude,
+	}
+}
+
+func (h handler) getBuildings(r *http.Request, language services.Language) (any, error) {
+	query := r.URL.Query()
+	p, err := getPagination(query)
+	if err != nil {
+		return nil, err
+	}
+	buildings, err := h.buildingService.GetBuildings(
+		r.Context(),
+		query.Get("prefix"),
+		p.limit+1,
+		p.offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(buildings, p, newBuildingPreview(language)), nil
+}
+
+func (h handler) getBuilding(r *http.Request, language services.Language) (any, error) {
+	_, rawID, _ := strings.Cut(r.URL.Path, "/buildings/")
+	buildingID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		return nil, errNotFound
+	}
+	b, err := h.buildingService.GetBuildingByID(r.Context(), buildingID)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errNotFound
+	}
+	return newBuilding(*b, language), nil
+}
+
+func (h handler) getNearestBuildings(r *http.Request, language services.Language) (any, error) {
+	query := r.URL.Query()
+	p, err := getPagination(query)
+	if err != nil {
+		return nil, err
+	}
+	latitude, err := getFloat(query, "lat", -90, 90)
+	if err != nil {
+		return nil, err
+	}
+	longitude, err := getFloat(query, "lon", -180, 180)
+	if err != nil {
+		return nil, err
+	}
+	radius := defaultRadius
+	if value := query.Get("radius"); value != "" {
+		radius, err = strconv.Atoi(value)
+		if err != nil || radius < 1 || radius > maxRadius {
+			return nil, badRequest("radius must be between 1 and %v", maxRadius)
+		}
+	}
+	buildings, err := h.buildingService.GetNearestBuildings(
+		r.Context(),
+		radius,
+		latitude,
+		longitude,
+		p.limit+1,
+		p.offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(buildings, p, newBuildingPreview(language)), nil
+}
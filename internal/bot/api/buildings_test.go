@@ -0,0 +1,197 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_getBuildings(t *testing.T) {
+	nameEn, nameRu := "House", "Дом"
+	dtos := []services.BuildingDTO{
+		{ID: 1, Address: "Mannerheimintie 1", NameEn: &nameEn, NameRu: &nameRu},
+		{ID: 2, Address: "Mannerheimintie 2"},
+		{ID: 3, Address: "Mannerheimintie 3"},
+	}
+	tests := []struct {
+		name           string
+		target         string
+		header         http.Header
+		limit          int
+		offset         int
+		buildings      []services.BuildingDTO
+		expected       page[buildingPreview]
+		expectedLang   string
+		expectedStatus int
+	}{
+		{
+			"the first page",
+			"/v1/buildings?prefix=Manner&limit=2",
+			nil,
+			3,
+			0,
+			dtos,
+			page[buildingPreview]{
+				[]buildingPreview{
+					{ID: 1, Address: "Mannerheimintie 1", Name: &nameEn},
+					{ID: 2, Address: "Mannerheimintie 2"},
+				},
+				encodeCursor(2),
+			},
+			"en",
+			http.StatusOK,
+		},
+		{
+			"the last page",
+			"/v1/buildings?prefix=Manner&limit=2&cursor=" + encodeCursor(2),
+			nil,
+			3,
+			2,
+			dtos[2:],
+			page[buildingPreview]{
+				[]buildingPreview{{ID: 3, Address: "Mannerheimintie 3"}},
+				"",
+			},
+			"en",
+			http.StatusOK,
+		},
+		{
+			"an Accept-Language header",
+			"/v1/buildings?prefix=Manner&limit=1",
+			http.Header{"Accept-Language": {"de-DE, ru;q=0.8, en;q=0.5"}},
+			2,
+			0,
+			dtos[:1],
+			page[buildingPreview]{
+				[]buildingPreview{{ID: 1, Address: "Mannerheimintie 1", Name: &nameRu}},
+				"",
+			},
+			"ru",
+			http.StatusOK,
+		},
+		{
+			"a lang parameter overrides a header",
+			"/v1/buildings?prefix=Manner&limit=1&lang=EN",
+			http.Header{"Accept-Language": {"ru"}},
+			2,
+			0,
+			dtos[:1],
+			page[buildingPreview]{
+				[]buildingPreview{{ID: 1, Address: "Mannerheimintie 1", Name: &nameEn}},
+				"",
+			},
+			"en",
+			http.StatusOK,
+		},
+		{"an unknown language", "/v1/buildings?lang=de", nil, 0, 0, nil, page[buildingPreview]{}, "", http.StatusBadRequest},
+		{"a large limit", "/v1/buildings?limit=101", nil, 0, 0, nil, page[buildingPreview]{}, "", http.StatusBadRequest},
+		{"an invalid cursor", "/v1/buildings?cursor=abc", nil, 0, 0, nil, page[buildingPreview]{}, "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			if tt.expectedStatus == http.StatusOK {
+				api.buildings.EXPECT().
+					GetBuildings(mock.Anything, "Manner", tt.limit, tt.offset).
+					Return(tt.buildings, nil)
+			}
+			recorder := api.get(t, tt.target, tt.header)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			require.Equal(t, tt.expectedLang, recorder.Header().Get("Content-Language"))
+			require.Equal(t, tt.expected, decodeBody[page[buildingPreview]](t, recorder))
+		})
+	}
+}
+
+func TestHandler_getBuilding(t *testing.T) {
+	descriptionFi, year := "kuvaus", 1930
+	authors := []string{"Alvar Aalto"}
+	dto := services.BuildingDTO{
+		ID:             5,
+		Address:        "Mannerheimintie 5",
+		DescriptionFi:  &descriptionFi,
+		CompletionYear: &year,
+		Authors:        &authors,
+	}
+	tests := []struct {
+		name           string
+		target         string
+		building       *services.BuildingDTO
+		err            error
+		expectedStatus int
+	}{
+		{"a building", "/v1/buildings/5?lang=fi", &dto, nil, http.StatusOK},
+		{"an unknown building", "/v1/buildings/5", nil, nil, http.StatusNotFound},
+		{"a service error", "/v1/buildings/5", nil, errors.New("test error"), http.StatusInternalServerError},
+		{"an invalid ID", "/v1/buildings/abc", nil, nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			if tt.name != "an invalid ID" {
+				api.buildings.EXPECT().
+					GetBuildingByID(mock.Anything, int64(5)).
+					Return(tt.building, tt.err)
+			}
+			recorder := api.get(t, tt.target, nil)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			expected := building{
+				ID:             5,
+				Address:        "Mannerheimintie 5",
+				Description:    &descriptionFi,
+				CompletionYear: &year,
+				Authors:        authors,
+			}
+			require.Equal(t, expected, decodeBody[building](t, recorder))
+		})
+	}
+}
+
+func TestHandler_getNearestBuildings(t *testing.T) {
+	distance := 15.5
+	dtos := []services.BuildingDTO{{ID: 1, Address: "Mannerheimintie 1", DistanceMeters: &distance}}
+	tests := []struct {
+		name           string
+		target         string
+		radius         int
+		expectedStatus int
+	}{
+		{"a default radius", "/v1/buildings/nearest?lat=60.17&lon=24.94", defaultRadius, http.StatusOK},
+		{"a radius", "/v1/buildings/nearest?lat=60.17&lon=24.94&radius=500", 500, http.StatusOK},
+		{"a large radius", "/v1/buildings/nearest?lat=60.17&lon=24.94&radius=5001", 0, http.StatusBadRequest},
+		{"no latitude", "/v1/buildings/nearest?lon=24.94", 0, http.StatusBadRequest},
+		{"an invalid longitude", "/v1/buildings/nearest?lat=60.17&lon=200", 0, http.StatusBadRequest},
+		{"not a number", "/v1/buildings/nearest?lat=NaN&lon=24.94", 0, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			if tt.expectedStatus == http.StatusOK {
+				api.buildings.EXPECT().
+					GetNearestBuildings(mock.Anything, tt.radius, 60.17, 24.94, defaultLimit+1, 0).
+					Return(dtos, nil)
+			}
+			recorder := api.get(t, tt.target, nil)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			expected := page[buildingPreview]{
+				Items: []buildingPreview{
+					{ID: 1, Address: "Mannerheimintie 1", DistanceMeters: &distance},
+				},
+			}
+			require.Equal(t, expected, decodeBody[page[buildingPreview]](t, recorder))
+		})
+	}
+}
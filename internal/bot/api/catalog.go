@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+type neighbourhood struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Municipality *string `json:"municipality"`
+}
+
+type architect struct {
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Title *string `json:"title"`
+}
+
+func (h handler) getNeighbourhoods(r *http.Request, _ services.Language) (any, error) {
+	p, err := getPagination(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	neighbourhoods, err := h.catalogService.GetNeighbourhoods(r.Context(), p.limit+1, p.offset)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(neighbourhoods, p, func(n services.NeighbourhoodDTO) neighbourhood {
+		return neighbourhood{n.ID, n.Name, n.Municipality}
+	}), nil
+}
+
+func (h handler) getArchitects(r *http.Request, language services.Language) (any, error) {
+	p, err := getPagination(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	architects, err := h.catalogService.GetArchitects(r.Context(), p.limit+1, p.offset)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(architects, p, func(a services.ArchitectDTO) architect {
+		return architect{a.ID, a.Name, localize(language, a.TitleFi, a.TitleEn, a.TitleRu)}
+	}), nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/export"
+)
+
+const (
+	// maxFeatures caps buildings of one map response without clusters
+	maxFeatures = 1000
+	// clusterZoom is a zoom level of web maps where buildings are not clustered
+	clusterZoom = 16
+	maxZoom     = 22
+)
+
+// featureCollection tells a map client to zoom in if buildings are truncated.
+type featureCollection struct {
+	export.FeatureCollection
+	Truncated bool `json:"truncated"`
+}
+
+// getBBox parses a bbox parameter of GeoJSON: west,south,east,north.
+func getBBox(query url.Values) (services.Coordinates, services.Coordinates, error) {
+	var southWest, northEast services.Coordinates
+	invalidBBox := badRequest(
+		"bbox must be west,south,east,north in degrees, west must be less than east",
+	)
+	values := strings.Split(query.Get("bbox"), ",")
+	if len(values) != 4 {
+		return southWest, northEast, invalidBBox
+	}
+	var bbox [4]float64
+	for i, value := range values {
+		coordinate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(coordinate) {
+			return southWest, northEast, invalidBBox
+		}
+		bbox[i] = coordinate
+	}
+	southWest = services.Coordinates{Latitude: bbox[1], Longitude: bbox[0]}
+	northEast = services.Coordinates{Latitude: bbox[3], Longitude: bbox[2]}
+	isValid := southWest.Longitude >= -180 && southWest.Longitude < northEast.Longitude &&
+		northEast.Longitude <= 180 &&
+		southWest.Latitude >= -90 && southWest.Latitude < northEast.Latitude &&
+		northEast.Latitude <= 90
+	if !isValid {
+		return southWest, northEast, invalidBBox
+	}
+	return southWest, northEast, nil
+}
+
+func newPlace(b services.BuildingDTO, language services.Language) export.Place {
+	place := export.Place{
+		ID:      b.ID,
+		Name:    b.Address,
+		Address: b.Address,
+		Year:    b.CompletionYear,
+	}
+	// a map label needs a text even if a building has no name
+	if name := localize(language, b.NameFi, b.NameEn, b.NameRu); name != nil {
+		place.Name = *name
+	}
+	if b.Authors != nil {
+		place.Authors = *b.Authors
+	}
+	place.Latitude, place.Longitude = *b.Latitude, *b.Longitude
+	return place
+}
+
+// getGeoJSON returns buildings inside a box. Buildings are clustered
+// on maps of low zoom levels, clusters count all buildings of a box.
+func (h handler) getGeoJSON(r *http.Request, language services.Language) (any, error) {
+	query := r.URL.Query()
+	southWest, northEast, err := getBBox(query)
+	if err != nil {
+		return nil, err
+	}
+	zoom := maxZoom
+	if value := query.Get("zoom"); value != "" {
+		zoom, err = strconv.Atoi(value)
+		if err != nil || zoom < 0 || zoom > maxZoom {
+			return nil, badRequest("zoom must be between 0 and %v", maxZoom)
+		}
+	}
+	isClustered := zoom < clusterZoom
+	limit := maxFeatures + 1
+	if isClustered {
+		limit = 0
+	}
+	buildings, err := h.buildingService.GetBuildingsInBBox(
+		r.Context(),
+		southWest,
+		northEast,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	truncated := !isClustered && len(buildings) > maxFeatures
+	if truncated {
+		buildings = buildings[:maxFeatures]
+	}
+	places := make([]export.Place, 0, len(buildings))
+	for _, building := range buildings {
+		if building.Latitude != nil && building.Longitude != nil {
+			places = append(places, newPlace(building, language))
+		}
+	}
+	if !isClustered {
+		return featureCollection{export.NewFeatureCollection(places), truncated}, nil
+	}
+	clusters := export.ClusterPlaces(places, zoom)
+	return featureCollection{export.NewClusterCollection(clusters), false}, nil
+}
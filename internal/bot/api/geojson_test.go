@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_getGeoJSON(t *testing.T) {
+	nameFi := "Kauppahalli"
+	authors := []string{"Gustaf Nyström"}
+	buildings := []services.BuildingDTO{
+		{
+			ID:        1,
+			Address:   "Eteläranta 1",
+			NameFi:    &nameFi,
+			Authors:   &authors,
+			Latitude:  utils.GetPointer(60.16717),
+			Longitude: utils.GetPointer(24.95375),
+		},
+		{
+			ID:        2,
+			Address:   "Eteläranta 3",
+			Latitude:  utils.GetPointer(60.16737),
+			Longitude: utils.GetPointer(24.95395),
+		},
+	}
+	tests := []struct {
+		name     string
+		target   string
+		limit    int
+		expected string
+	}{
+		{
+			"buildings",
+			"/v1/buildings/geojson?bbox=24.9,60.1,25,60.2&lang=fi",
+			maxFeatures + 1,
+			`{"type":"FeatureCollection","truncated":false,"features":[
+				{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[24.95375,60.16717]},
+				"properties":{"name":"Kauppahalli","address":"Eteläranta 1","year":null,
+				"authors":["Gustaf Nyström"]}},
+				{"type":"Feature","id":2,"geometry":{"type":"Point","coordinates":[24.95395,60.16737]},
+				"properties":{"name":"Eteläranta 3","address":"Eteläranta 3","year":null,"authors":[]}}
+			]}`,
+		},
+		{
+			"clusters",
+			"/v1/buildings/geojson?bbox=24.9,60.1,25,60.2&zoom=12",
+			0,
+			`{"type":"FeatureCollection","truncated":false,"features":[
+				{"type":"Feature","geometry":{"type":"Point","coordinates":[24.95385,60.16727]},
+				"properties":{"cluster":true,"count":2}}
+			]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			api.buildings.EXPECT().
+				GetBuildingsInBBox(
+					mock.Anything,
+					services.Coordinates{Latitude: 60.1, Longitude: 24.9},
+					services.Coordinates{Latitude: 60.2, Longitude: 25},
+					tt.limit,
+				).
+				Return(buildings, nil)
+			recorder := api.get(t, tt.target, nil)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.JSONEq(t, tt.expected, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_getGeoJSON_truncated(t *testing.T) {
+	buildings := make([]services.BuildingDTO, maxFeatures+1)
+	for i := range buildings {
+		buildings[i] = services.BuildingDTO{
+			ID:        int64(i + 1),
+			Latitude:  utils.GetPointer(60.17),
+			Longitude: utils.GetPointer(24.95),
+		}
+	}
+	api := newTestAPI(t, 10)
+	api.buildings.EXPECT().
+		GetBuildingsInBBox(mock.Anything, mock.Anything, mock.Anything, maxFeatures+1).
+		Return(buildings, nil)
+	recorder := api.get(t, "/v1/buildings/geojson?bbox=24.9,60.1,25,60.2", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := decodeBody[struct {
+		Features  []any `json:"features"`
+		Truncated bool  `json:"truncated"`
+	}](t, recorder)
+	require.True(t, body.Truncated)
+	require.Len(t, body.Features, maxFeatures)
+}
+
+func TestHandler_getGeoJSON_clustersAll(t *testing.T) {
+	buildings := make([]services.BuildingDTO, maxFeatures+5)
+	for i := range buildings {
+		buildings[i] = services.BuildingDTO{
+			ID:        int64(i + 1),
+			Latitude:  utils.GetPointer(60.17),
+			Longitude: utils.GetPointer(24.95),
+		}
+	}
+	api := newTestAPI(t, 10)
+	api.buildings.EXPECT().
+		GetBuildingsInBBox(mock.Anything, mock.Anything, mock.Anything, 0).
+		Return(buildings, nil)
+	recorder := api.get(t, "/v1/buildings/geojson?bbox=24.9,60.1,25,60.2&zoom=10", nil)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body := decodeBody[struct {
+		Features []struct {
+			Properties struct {
+				Count int `json:"count"`
+			} `json:"properties"`
+		} `json:"features"`
+		Truncated bool `json:"truncated"`
+	}](t, recorder)
+	require.False(t, body.Truncated)
+	require.Len(t, body.Features, 1)
+	require.Equal(t, maxFeatures+5, body.Features[0].Properties.Count)
+}
+
+func TestHandler_getGeoJSON_invalidParameters(t *testing.T) {
+	targets := []string{
+		"/v1/buildings/geojson",
+		"/v1/buildings/geojson?bbox=24.9,60.1,25",
+		"/v1/buildings/geojson?bbox=25,60.1,24.9,60.2",
+		"/v1/buildings/geojson?bbox=24.9,60.1,25,91",
+		"/v1/buildings/geojson?bbox=a,60.1,25,60.2",
+		"/v1/buildings/geojson?bbox=24.9,NaN,25,60.2",
+		"/v1/buildings/geojson?bbox=24.9,60.1,25,60.2&zoom=23",
+	}
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			api := newTestAPI(t, 10)
+			recorder := api.get(t, target, nil)
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInitData = errors.New("invalid init data of a web app")
+	ErrExpiredInitData = errors.New("expired init data of a web app")
+)
+
+// webAppUser is a Telegram user who has opened the Mini App.
+type webAppUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
+}
+
+// initData is data which Telegram passes to the Mini App.
+type initData struct {
+	User     webAppUser
+	AuthDate time.Time
+	QueryID  string
+}
+
+// validateInitData checks a signature of init data as described in
+// https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+// and rejects data older than a maximum age.
+func validateInitData(
+	rawData,
+	botToken string,
+	maxAge time.Duration,
+	now time.Time,
+) (initData, error) {
+	var data initData
+	values, err := url.ParseQuery(rawData)
+	if err != nil {
+		return data, fmt.Errorf("%w: %v", ErrInvalidInitData, err)
+	}
+	hash, err := hex.DecodeString(values.Get("hash"))
+	if err != nil || len(hash) == 0 {
+		return data, fmt.Errorf("%w: no valid hash", ErrInvalidInitData)
+	}
+	var lines []string
+	for key := range values {
+		if key != "hash" {
+			lines = append(lines, key+"="+values.Get(key))
+		}
+	}
+	slices.Sort(lines)
+	secretKey := hmac.New(sha256.New, []byte("WebAppData"))
+	secretKey.Write([]byte(botToken))
+	signature := hmac.New(sha256.New, secretKey.Sum(nil))
+	signature.Write([]byte(strings.Join(lines, "\n")))
+	if !hmac.Equal(hash, signature.Sum(nil)) {
+		return data, fmt.Errorf("%w: a hash mismatch", ErrInvalidInitData)
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return data, fmt.Errorf("%w: no valid auth_date", ErrInvalidInitData)
+	}
+	data.AuthDate = time.Unix(authDate, 0)
+	if now.Sub(data.AuthDate) > maxAge {
+		return data, fmt.Errorf("%w: signed at %v", ErrExpiredInitData, data.AuthDate)
+	}
+	if err := json.Unmarshal([]byte(values.Get("user")), &data.User); err != nil {
+		return data, fmt.Errorf("%w: no valid user: %v", ErrInvalidInitData, err)
+	}
+	if data.User.ID == 0 {
+		return data, fmt.Errorf("%w: no user ID", ErrInvalidInitData)
+	}
+	data.QueryID = values.Get("query_id")
+	return data, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBotToken = "7342037359:AAHI25ES9xCOMPWRC9MDGNy7-nBdtgnD6a8"
+	// testInitData is signed with testBotToken at 2024-02-14 08:40:00 UTC
+	testInitData = "query_id=AAHdF6IQAAAAAN0XohDhrOrc&user=%7B%22id%22%3A279058397%2C" +
+		"%22first_name%22%3A%22Vladislav%22%2C%22last_name%22%3A%22Kibenko%22%2C" +
+		"%22username%22%3A%22vdkfrost%22%2C%22language_code%22%3A%22ru%22%2C" +
+		"%22is_premium%22%3Atrue%2C%22allows_write_to_pm%22%3Atrue%7D" +
+		"&auth_date=1707900000" +
+		"&hash=d5abcde881c1ad67ef3a752c29aa74b596fb2fbea16261e62339047db872b33a"
+	// testSignedInitData has an Ed25519 signature which is also checked by a hash
+	testSignedInitData = "user=%7B%22id%22%3A5%2C%22first_name%22%3A%22Test%22%2C" +
+		"%22language_code%22%3A%22fi%22%7D&auth_date=1707900000&signature=abc" +
+		"&hash=03c1c69cbd4b884e6b26958d44d7c004b77d4304a0fe562b419f1fdcef3dc22c"
+	// testAnonymousInitData is valid but has no user
+	testAnonymousInitData = "auth_date=1707900000&query_id=x" +
+		"&hash=de47e01ce00d7bebda908c61cad989e3b92b7341d4f3196f6fd58ff418bc8bc6"
+)
+
+var testAuthDate = time.Unix(1707900000, 0)
+
+func TestValidateInitData(t *testing.T) {
+	data, err := validateInitData(testInitData, testBotToken, time.Hour, testAuthDate.Add(time.Minute))
+	require.NoError(t, err)
+	expected := initData{
+		webAppUser{279058397, "Vladislav", "Kibenko", "vdkfrost", "ru"},
+		testAuthDate,
+		"AAHdF6IQAAAAAN0XohDhrOrc",
+	}
+	require.Equal(t, expected, data)
+
+	data, err = validateInitData(testSignedInitData, testBotToken, time.Hour, testAuthDate)
+	require.NoError(t, err)
+	require.Equal(t, webAppUser{ID: 5, FirstName: "Test", LanguageCode: "fi"}, data.User)
+}
+
+func TestValidateInitData_errors(t *testing.T) {
+	tamperedUser := strings.Replace(testInitData, "279058397", "279058398", 1)
+	values, err := url.ParseQuery(testInitData)
+	require.NoError(t, err)
+	values.Del("hash")
+	tests := []struct {
+		name        string
+		initData    string
+		botToken    string
+		now         time.Time
+		expectedErr error
+	}{
+		{"a tampered user", tamperedUser, testBotToken, testAuthDate, ErrInvalidInitData},
+		{"another bot", testInitData, "123:other", testAuthDate, ErrInvalidInitData},
+		{"no hash", values.Encode(), testBotToken, testAuthDate, ErrInvalidInitData},
+		{"an invalid hash", testInitData + "x", testBotToken, testAuthDate, ErrInvalidInitData},
+		{"an invalid query", "%%%", testBotToken, testAuthDate, ErrInvalidInitData},
+		{"no user", testAnonymousInitData, testBotToken, testAuthDate, ErrInvalidInitData},
+		{
+			"expired data",
+			testInitData,
+			testBotToken,
+			testAuthDate.Add(time.Hour + time.Second),
+			ErrExpiredInitData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateInitData(tt.initData, tt.botToken, time.Hour, tt.now)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/base64"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+const (
+	defaultLimit  = 20
+	maxLimit      = 100
+	defaultRadius = 100
+	maxRadius     = 5000
+)
+
+// getLanguage prefers the lang parameter to the Accept-Language header
+// and falls back to English.
+func getLanguage(r *http.Request) (services.Language, error) {
+	code := r.URL.Query().Get("lang")
+	if code == "" {
+		return parseAcceptLanguage(r.Header.Get("Accept-Language")), nil
+	}
+	language, ok := services.GetLanguagePerCode(strings.ToLower(code))
+	if !ok {
+		return "", badRequest("unsupported language %q, expected fi, en or ru", code)
+	}
+	return language, nil
+}
+
+// parseAcceptLanguage returns a supported language with the highest weight.
+func parseAcceptLanguage(header string) services.Language {
+	language, weight := services.English, 0.0
+	for _, item := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(item, ";")
+		primaryTag, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		candidate, ok := services.GetLanguagePerCode(primaryTag)
+		if !ok {
+			continue
+		}
+		candidateWeight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			candidateWeight = parsed
+		}
+		if candidateWeight > weight {
+			language, weight = candidate, candidateWeight
+		}
+	}
+	return language
+}
+
+// pagination is a page of a list. A cursor is an opaque offset of a page,
+// so clients do not depend on the way lists are paginated.
+type pagination struct {
+	limit  int
+	offset int
+}
+
+func getPagination(query url.Values) (pagination, error) {
+	p := pagination{defaultLimit, 0}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			return p, badRequest("limit must be between 1 and %v", maxLimit)
+		}
+		p.limit = limit
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, err := decodeCursor(cursor)
+		if err != nil {
+			return p, badRequest("invalid cursor")
+		}
+		p.offset = offset
+	}
+	return p, nil
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err == nil && offset < 0 {
+		err = strconv.ErrRange
+	}
+	return offset, err
+}
+
+type page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// newPage converts items of a query which requests one item more than
+// a limit to find out whether the next page exists.
+func newPage[T, D any](items []D, p pagination, convert func(D) T) page[T] {
+	result := page[T]{Items: make([]T, 0, len(items))}
+	if len(items) > p.limit {
+		items = items[:p.limit]
+		result.NextCursor = encodeCursor(p.offset + p.limit)
+	}
+	for _, item := range items {
+		result.Items = append(result.Items, convert(item))
+	}
+	return result
+}
+
+// getFloat parses a required parameter within a range. NaN is out of any
+// range, but comparisons with it are false.
+func getFloat(query url.Values, name string, minValue, maxValue float64) (float64, error) {
+	value, err := strconv.ParseFloat(query.Get(name), 64)
+	if err != nil || math.IsNaN(value) || value < minValue || value > maxValue {
+		return 0, badRequest("%v must be a number between %v and %v", name, minValue, maxValue)
+	}
+	return value, nil
+}
+
+func localize(language services.Language, fi, en, ru *string) *string {
+	switch language {
+	case services.Finnish:
+		return fi
+	case services.Russian:
+		return ru
+	default:
+		return en
+	}
+}
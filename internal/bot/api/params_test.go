@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected services.Language
+	}{
+		{"", services.English},
+		{"*", services.English},
+		{"fi", services.Finnish},
+		{"ru-RU,ru;q=0.9,en-US;q=0.8", services.Russian},
+		{"en;q=0.5, fi;q=0.7", services.Finnish},
+		{"de, sv;q=0.9", services.English},
+		{"fi;q=0, ru;q=invalid", services.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	offset, err := decodeCursor(encodeCursor(40))
+	require.NoError(t, err)
+	require.Equal(t, 40, offset)
+	for _, cursor := range []string{"abc", "***", encodeCursor(-1)} {
+		_, err := decodeCursor(cursor)
+		require.Error(t, err, cursor)
+	}
+}
+
+func TestGetFloat(t *testing.T) {
+	value, err := getFloat(url.Values{"lat": {"60.17"}}, "lat", -90, 90)
+	require.NoError(t, err)
+	require.Equal(t, 60.17, value)
+	for _, invalid := range []string{"", "a", "91", "NaN", "-Inf"} {
+		_, err := getFloat(url.Values{"lat": {invalid}}, "lat", -90, 90)
+		require.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+const (
+	// initDataMaxAge limits a lifetime of a Mini App session
+	initDataMaxAge = 24 * time.Hour
+	// initDataScheme is a scheme of an Authorization header with init data
+	initDataScheme = "tma "
+)
+
+//go:embed webapp
+var webAppFiles embed.FS
+
+type webAppUserKey struct{}
+
+type profile struct {
+	ID        int64             `json:"id"`
+	FirstName string            `json:"first_name"`
+	Language  services.Language `json:"language"`
+}
+
+type languageRequest struct {
+	Language string `json:"language"`
+}
+
+// NewWebAppHandler serves the Telegram Mini App under /webapp/: static files
+// of the app and the dataset API for a Telegram user who has opened the app.
+// A user has the same preferences in the app and in the bot.
+func NewWebAppHandler(
+	botToken string,
+	buildingService services.Buildings,
+	catalogService services.Catalog,
+	userService services.Users,
+	m *metrics.Metrics,
+) http.Handler {
+	h := handler{buildingService, catalogService, userService, nil}
+	h.getLanguage = h.getUserLanguage
+	authenticate := func(next http.Handler) http.Handler {
+		return authenticateWebAppUser(botToken, time.Now, next)
+	}
+	mux := http.NewServeMux()
+	h.addDataRoutes(mux, "/webapp/api", m, authenticate)
+	mux.Handle("/webapp/api/me", count("/webapp/api/me", m, authenticate(
+		h.serve("/webapp/api/me", http.MethodGet, h.getProfile),
+	)))
+	mux.Handle("/webapp/api/me/language", count("/webapp/api/me/language", m, authenticate(
+		h.serve("/webapp/api/me/language", http.MethodPut, h.setLanguage),
+	)))
+	staticFiles, err := fs.Sub(webAppFiles, "webapp")
+	if err != nil {
+		// the directory is embedded at compile time
+		panic(err)
+	}
+	mux.Handle("/webapp/", count("/webapp/", m, http.StripPrefix(
+		"/webapp/",
+		http.FileServer(http.FS(staticFiles)),
+	)))
+	return mux
+}
+
+// authenticateWebAppUser rejects requests without valid init data
+// in an Authorization header: tma <init data>.
+func authenticateWebAppUser(
+	botToken string,
+	now func() time.Time,
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawData, ok := strings.CutPrefix(r.Header.Get("Authorization"), initDataScheme)
+		if !ok {
+			writeError(w, r, apiError{http.StatusUnauthorized, "init data is required"})
+			return
+		}
+		data, err := validateInitData(rawData, botToken, initDataMaxAge, now())
+		if err != nil {
+			writeError(w, r, apiError{http.StatusUnauthorized, err.Error()})
+			return
+		}
+		ctx := context.WithValue(r.Context(), webAppUserKey{}, data.User)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// getWebAppUser returns a user of an authenticated request.
+func getWebAppUser(ctx context.Context) webAppUser {
+	user, _ := ctx.Value(webAppUserKey{}).(webAppUser)
+	return user
+}
+
+// getUserLanguage prefers the lang parameter, then a language which a user
+// has chosen in the bot, then a language of a Telegram client.
+func (h handler) getUserLanguage(r *http.Request) (services.Language, error) {
+	if r.URL.Query().Get("lang") != "" {
+		return getLanguage(r)
+	}
+	user := getWebAppUser(r.Context())
+	preferred, err := h.userService.GetPreferredLanguage(r.Context(), user.ID)
+	if err != nil {
+		return "", err
+	}
+	if preferred != nil {
+		return *preferred, nil
+	}
+	if language, ok := services.GetLanguagePerCode(user.LanguageCode); ok {
+		return language, nil
+	}
+	return services.English, nil
+}
+
+func (h handler) getProfile(r *http.Request, language services.Language) (any, error) {
+	user := getWebAppUser(r.Context())
+	return profile{user.ID, user.FirstName, language}, nil
+}
+
+// setLanguage saves a preferred language which the bot also uses.
+func (h handler) setLanguage(r *http.Request, _ services.Language) (any, error) {
+	var request languageRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, badRequest("expected a JSON object with a language")
+	}
+	language, ok := services.GetLanguagePerCode(request.Language)
+	if !ok {
+		return nil, badRequest("unsupported language %q, expected fi, en or ru", request.Language)
+	}
+	user := getWebAppUser(r.Context())
+	if err := h.userService.SetLanguage(r.Context(), user.ID, language); err != nil {
+		return nil, err
+	}
+	return profile{user.ID, user.FirstName, language}, nil
+}
@@ -0,0 +1,158 @@
+// The Mini App authenticates every request by init data of Telegram.
+const webApp = window.Telegram.WebApp;
+const elements = {
+  search: document.getElementById("search"),
+  prefix: document.getElementById("prefix"),
+  nearest: document.getElementById("nearest"),
+  language: document.getElementById("language"),
+  status: document.getElementById("status"),
+  buildings: document.getElementById("buildings"),
+  more: document.getElementById("more"),
+  building: document.getElementById("building"),
+};
+const fields = [
+  ["description", "Description"],
+  ["facades", "Facades"],
+  ["details", "Interesting details"],
+  ["notable_features", "Notable features"],
+  ["surroundings", "Surroundings"],
+  ["history", "History"],
+];
+let nextPage = null;
+
+async function request(path, options = {}) {
+  const response = await fetch(path, {
+    ...options,
+    headers: {
+      "Authorization": "tma " + webApp.initData,
+      "Content-Type": "application/json",
+    },
+  });
+  const body = await response.json();
+  if (!response.ok) {
+    throw new Error(body.error);
+  }
+  return body;
+}
+
+function showStatus(text) {
+  elements.status.textContent = text;
+}
+
+function addText(parent, tag, text, className) {
+  const element = document.createElement(tag);
+  element.textContent = text;
+  if (className) {
+    element.className = className;
+  }
+  parent.appendChild(element);
+  return element;
+}
+
+async function showPage(path) {
+  try {
+    const page = await request(path);
+    for (const building of page.items) {
+      const item = document.createElement("li");
+      addText(item, "div", building.name || building.address);
+      let hint = building.address;
+      if (building.distance_meters !== undefined) {
+        hint += " · " + Math.round(building.distance_meters) + " m";
+      }
+      addText(item, "div", hint, "hint");
+      item.addEventListener("click", () => showBuilding(building.id));
+      elements.buildings.appendChild(item);
+    }
+    nextPage = page.next_cursor ? path + "&cursor=" + page.next_cursor : null;
+    elements.more.hidden = nextPage === null;
+    showStatus(elements.buildings.children.length ? "" : "No buildings found");
+  } catch (error) {
+    showStatus(error.message);
+  }
+}
+
+function showList() {
+  elements.building.hidden = true;
+  elements.buildings.hidden = false;
+  elements.more.hidden = nextPage === null;
+  webApp.BackButton.hide();
+}
+
+function startList(path) {
+  elements.buildings.replaceChildren();
+  nextPage = null;
+  showList();
+  showPage(path);
+}
+
+async function showBuilding(id) {
+  try {
+    const building = await request("api/buildings/" + id);
+    elements.building.replaceChildren();
+    addText(elements.building, "h2", building.name || building.address);
+    addText(elements.building, "div", building.address, "hint");
+    if (building.completion_year) {
+      addText(elements.building, "div", String(building.completion_year), "hint");
+    }
+    if (building.authors.length) {
+      addText(elements.building, "div", building.authors.join(", "), "hint");
+    }
+    for (const [field, title] of fields) {
+      if (building[field]) {
+        addText(elements.building, "h3", title);
+        addText(elements.building, "p", building[field]);
+      }
+    }
+    elements.building.hidden = false;
+    elements.buildings.hidden = true;
+    elements.more.hidden = true;
+    webApp.BackButton.show();
+    window.scrollTo(0, 0);
+  } catch (error) {
+    showStatus(error.message);
+  }
+}
+
+elements.search.addEventListener("submit", (event) => {
+  event.preventDefault();
+  startList("api/buildings?prefix=" + encodeURIComponent(elements.prefix.value));
+});
+
+elements.nearest.addEventListener("click", () => {
+  navigator.geolocation.getCurrentPosition(
+    (position) => startList(
+      "api/buildings/nearest?radius=500&lat=" + position.coords.latitude +
+      "&lon=" + position.coords.longitude
+    ),
+    (error) => showStatus(error.message),
+  );
+});
+
+elements.more.addEventListener("click", () => showPage(nextPage));
+
+elements.language.addEventListener("change", async () => {
+  try {
+    await request("api/me/language", {
+      method: "PUT",
+      body: JSON.stringify({language: elements.language.value}),
+    });
+    startList("api/buildings?prefix=" + encodeURIComponent(elements.prefix.value));
+  } catch (error) {
+    showStatus(error.message);
+  }
+});
+
+webApp.BackButton.onClick(showList);
+
+async function start() {
+  webApp.ready();
+  try {
+    const profile = await request("api/me");
+    elements.language.value = profile.language;
+    startList("api/buildings?prefix=");
+  } catch (error) {
+    showStatus(error.message);
+  }
+}
+
+start();
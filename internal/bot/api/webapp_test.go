@@ -0,0 +1,208 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testWebApp struct {
+	handler   http.Handler
+	buildings *services.Buildings_mock
+	users     *services.Users_mock
+}
+
+func newTestWebApp(t *testing.T) testWebApp {
+	buildings := services.NewBuildings_mock(t)
+	users := services.NewUsers_mock(t)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	handler := NewWebAppHandler(testBotToken, buildings, services.NewCatalog_mock(t), users, m)
+	return testWebApp{handler, buildings, users}
+}
+
+// signInitData returns fresh init data of a user, test vectors of
+// TestValidateInitData check the signature algorithm.
+func signInitData(user string) string {
+	values := url.Values{
+		"user":      {user},
+		"auth_date": {strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+	lines := []string{}
+	for key := range values {
+		lines = append(lines, key+"="+values.Get(key))
+	}
+	slices.Sort(lines)
+	secretKey := hmac.New(sha256.New, []byte("WebAppData"))
+	secretKey.Write([]byte(testBotToken))
+	signature := hmac.New(sha256.New, secretKey.Sum(nil))
+	signature.Write([]byte(strings.Join(lines, "\n")))
+	values.Set("hash", hex.EncodeToString(signature.Sum(nil)))
+	return values.Encode()
+}
+
+func (a testWebApp) do(method, target, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set(
+		"Authorization",
+		"tma "+signInitData(`{"id":5,"first_name":"Test","language_code":"ru"}`),
+	)
+	recorder := httptest.NewRecorder()
+	a.handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAuthenticateWebAppUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		header         string
+		now            time.Time
+		expectedStatus int
+	}{
+		{"valid init data", "tma " + testInitData, testAuthDate, http.StatusOK},
+		{"no header", "", testAuthDate, http.StatusUnauthorized},
+		{"another scheme", "Bearer " + testInitData, testAuthDate, http.StatusUnauthorized},
+		{
+			"expired init data",
+			"tma " + testInitData,
+			testAuthDate.Add(initDataMaxAge + time.Second),
+			http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user webAppUser
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user = getWebAppUser(r.Context())
+			})
+			now := func() time.Time { return tt.now }
+			request := httptest.NewRequest(http.MethodGet, "/webapp/api/me", nil)
+			request.Header.Set("Authorization", tt.header)
+			recorder := httptest.NewRecorder()
+			authenticateWebAppUser(testBotToken, now, next).ServeHTTP(recorder, request)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				require.Equal(t, int64(279058397), user.ID)
+			}
+		})
+	}
+}
+
+func TestWebAppHandler_getProfile(t *testing.T) {
+	finnish := services.Finnish
+	tests := []struct {
+		name             string
+		target           string
+		preferred        *services.Language
+		expectedLanguage services.Language
+	}{
+		{"a preferred language", "/webapp/api/me", &finnish, services.Finnish},
+		{"a language of a client", "/webapp/api/me", nil, services.Russian},
+		{"a lang parameter", "/webapp/api/me?lang=en", nil, services.English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestWebApp(t)
+			if tt.name != "a lang parameter" {
+				app.users.EXPECT().
+					GetPreferredLanguage(mock.Anything, int64(5)).
+					Return(tt.preferred, nil)
+			}
+			recorder := app.do(http.MethodGet, tt.target, "")
+			require.Equal(t, http.StatusOK, recorder.Code)
+			expected := profile{5, "Test", tt.expectedLanguage}
+			require.Equal(t, expected, decodeBody[profile](t, recorder))
+		})
+	}
+}
+
+func TestWebAppHandler_setLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"a language", http.MethodPut, `{"language":"fi"}`, nil, http.StatusOK},
+		{
+			"a service error",
+			http.MethodPut,
+			`{"language":"fi"}`,
+			errors.New("test error"),
+			http.StatusInternalServerError,
+		},
+		{"an unknown language", http.MethodPut, `{"language":"de"}`, nil, http.StatusBadRequest},
+		{"an invalid body", http.MethodPut, `language=fi`, nil, http.StatusBadRequest},
+		{"another method", http.MethodPost, `{"language":"fi"}`, nil, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestWebApp(t)
+			russian := services.Russian
+			if tt.method == http.MethodPut {
+				app.users.EXPECT().
+					GetPreferredLanguage(mock.Anything, int64(5)).
+					Return(&russian, nil)
+			}
+			if tt.name == "a language" || tt.name == "a service error" {
+				app.users.EXPECT().
+					SetLanguage(mock.Anything, int64(5), services.Finnish).
+					Return(tt.serviceErr)
+			}
+			recorder := app.do(tt.method, "/webapp/api/me/language", tt.body)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				expected := profile{5, "Test", services.Finnish}
+				require.Equal(t, expected, decodeBody[profile](t, recorder))
+			}
+		})
+	}
+}
+
+func TestWebAppHandler_getBuilding(t *testing.T) {
+	nameFi, nameRu := "Talo", "Дом"
+	app := newTestWebApp(t)
+	app.users.EXPECT().GetPreferredLanguage(mock.Anything, int64(5)).Return(nil, nil)
+	app.buildings.EXPECT().
+		GetBuildingByID(mock.Anything, int64(7)).
+		Return(&services.BuildingDTO{ID: 7, NameFi: &nameFi, NameRu: &nameRu}, nil)
+	recorder := app.do(http.MethodGet, "/webapp/api/buildings/7", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, &nameRu, decodeBody[building](t, recorder).Name)
+}
+
+func TestWebAppHandler_staticFiles(t *testing.T) {
+	app := newTestWebApp(t)
+	tests := []struct {
+		target          string
+		expectedContent string
+	}{
+		{"/webapp/", "telegram-web-app.js"},
+		{"/webapp/app.js", "initData"},
+		{"/webapp/style.css", "body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			recorder := httptest.NewRecorder()
+			app.handler.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Contains(t, recorder.Body.String(), tt.expectedContent)
+		})
+	}
+}
@@ -14,65 +14,107 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/api"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/configuration"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend/telegram"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/handlers"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/health"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/migrations"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/middlewares"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// readinessTimeout is shorter than a usual timeout of a probe
+	readinessTimeout = 5 * time.Second
+	// popularityCleanupPeriod is an interval between removals of expired events
+	popularityCleanupPeriod = time.Hour
+	// languageObservationPeriod is an interval between counts of users per language
+	languageObservationPeriod = 5 * time.Minute
+	// listenRetryDelay is a pause before listening for building changes again
+	listenRetryDelay = 10 * time.Second
+	// updateRetryDelay is a pause after a failed request for updates
+	updateRetryDelay = 3 * time.Second
+	// apiReadHeaderTimeout protects the public API from slow clients
+	apiReadHeaderTimeout = 10 * time.Second
 )
 
 type Server struct {
-	bot                 handlers.InternalBot
+	bot                 telegram.InternalBot
 	handlers            handlers.HandlerContainer
 	shutdownFuncs       []func()
 	tgUpdateTimeout     int
 	updateReadersNumber int
 	httpServer          *http.Server
+	// apiServer is nil if the public API is disabled
+	apiServer           *http.Server
 	metrics             *metrics.Metrics
+	botUser             tgbotapi.User
+	updateService       services.Updates
+	popularityService   services.PopularityService
+	popularityRetention time.Duration
+	userService         services.UserService
+	buildingChanges     *repositories.BuildingChangeStorage
+	// flushBuildingCache is nil if the building cache is disabled
+	flushBuildingCache func()
+	settings           *handlers.Settings
+	// configPath is an optional configuration file to reload settings
+	configPath string
+	logLevel   *slog.LevelVar
 }
 
-func NewServer(ctx context.Context, config configuration.StartupConfig) (*Server, error) {
-	bot, err := tgbotapi.NewBotAPI(config.BotAPIToken)
+// NewServer creates a bot from a loaded config. A config path and a log level
+// allow to reload runtime settings on SIGHUP.
+func NewServer(
+	ctx context.Context,
+	config configuration.StartupConfig,
+	configPath string,
+	logLevel *slog.LevelVar,
+) (*Server, error) {
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint(config.BotAPIToken, config.BotAPIEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("can not connect to the Telegram API: %w", err)
 	}
 	bot.Debug = false
 
-	dbpool, err := pgxpool.New(ctx, config.DatabaseURL)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"unable to create a connection pool: DB URL '%s': %w",
-			config.DatabaseURL,
-			err,
-		)
+	shutdownFuncs := []func(){}
+	if config.OTLPEndpoint != "" {
+		provider, err := tracing.NewProvider(ctx, config.OTLPEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		otel.SetTracerProvider(provider)
+		shutdownFuncs = append(shutdownFuncs, func() {
+			// send the remaining spans
+			if err := provider.Shutdown(context.Background()); err != nil {
+				slog.Error("can not stop the tracer provider", slog.Any(logger.ErrorKey, err))
+			}
+		})
 	}
-	if err := dbpool.Ping(ctx); err != nil {
-		logMsg := fmt.Sprintf(
-			"unable to connect to the DB '%v'",
-			config.DatabaseURL,
-		)
-		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
-		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	dbpool, err := newDBPool(ctx, config.DatabaseURL)
+	if err != nil {
+		return nil, err
 	}
-	buildingRepo := repositories.NewBuildingRepo(dbpool)
-	actorRepo := repositories.NewActorRepo(dbpool)
-	userRepo := repositories.NewUserRepo(dbpool)
-	buildingService := services.NewBuildingService(buildingRepo, actorRepo)
-	userService := services.NewUserService(userRepo)
-
 	registry := prom.NewRegistry()
 	registry.MustRegister(
 		collectors.NewGoCollector(),
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 	)
 	registeredMetrics := metrics.NewMetrics(registry)
+	svc := newBotServices(config, dbpool, registeredMetrics)
+
 	prometheusHandler := promhttp.HandlerFor(
 		registry,
 		promhttp.HandlerOpts{ErrorLog: log.Default()},
@@ -83,33 +125,251 @@ func NewServer(ctx context.Context, config configuration.StartupConfig) (*Server
 		config.MetricsPassword,
 	)
 
+	expectedVersion, err := migrations.LatestVersion()
+	if err != nil {
+		return nil, err
+	}
+	migrationRepo := repositories.NewMigrationRepo(dbpool)
+	readinessHandler := health.GetReadinessHandler(
+		readinessTimeout,
+		health.Check{Name: "database", Function: dbpool.Ping},
+		health.Check{Name: "telegram", Function: func(context.Context) error {
+			_, err := bot.GetMe()
+			return err
+		}},
+		health.NewMigrationCheck(migrationRepo.GetVersion, expectedVersion),
+	)
+
 	srvMux := http.NewServeMux()
 	srvMux.Handle("/metrics", authMetricsHandler)
+	srvMux.Handle("/healthz", health.GetLivenessHandler())
+	srvMux.Handle("/readyz", readinessHandler)
 	httpServer := http.Server{
 		Addr:    ":" + strconv.Itoa(config.MetricsPort),
 		Handler: srvMux,
 	}
 
-	botWithMetrics := handlers.NewBotWithMetrics(bot, registeredMetrics)
+	var apiServer *http.Server
+	if config.APIPort != 0 {
+		apiMux := http.NewServeMux()
+		apiMux.Handle("/", api.NewHandler(
+			svc.buildings,
+			svc.catalog,
+			config.GetAPIKeys(),
+			registeredMetrics,
+		))
+		if config.WebAppURL != "" {
+			apiMux.Handle("/webapp/", api.NewWebAppHandler(
+				config.BotAPIToken,
+				svc.buildings,
+				svc.catalog,
+				svc.users,
+				registeredMetrics,
+			))
+			if err := setMenuButton(bot, config.WebAppURL); err != nil {
+				return nil, err
+			}
+		}
+		apiServer = &http.Server{
+			Addr:              ":" + strconv.Itoa(config.APIPort),
+			Handler:           apiMux,
+			ReadHeaderTimeout: apiReadHeaderTimeout,
+		}
+	}
+
+	botWithMetrics := telegram.NewBotWithMetrics(bot, registeredMetrics)
+	settings := handlers.NewSettings(getRuntimeSettings(config))
 
-	handlerContainer := handlers.NewCommandContainer(
-		botWithMetrics,
-		buildingService,
-		userService,
+	handlerContainer := newHandlerContainer(
+		telegram.NewFrontend(botWithMetrics),
+		svc,
 		registeredMetrics,
+		settings,
+		bot.Self.UserName,
+		config,
 	)
 	server := Server{
 		botWithMetrics,
 		handlerContainer,
-		[]func(){dbpool.Close},
+		append([]func(){dbpool.Close}, shutdownFuncs...),
 		config.TGUpdateTimeout,
 		config.UpdateReadersNumber,
 		&httpServer,
+		apiServer,
 		registeredMetrics,
+		bot.Self,
+		svc.updates,
+		svc.popularity,
+		time.Duration(config.PopularityRetention) * 24 * time.Hour,
+		svc.users,
+		repositories.NewBuildingChangeRepo(dbpool),
+		svc.flushBuildingCache,
+		settings,
+		configPath,
+		logLevel,
 	}
 	return &server, nil
 }
 
+func newDBPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB URL '%s': %w", databaseURL, err)
+	}
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
+	dbpool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create a connection pool: DB URL '%s': %w",
+			databaseURL,
+			err,
+		)
+	}
+	if err := dbpool.Ping(ctx); err != nil {
+		dbpool.Close()
+		logMsg := fmt.Sprintf("unable to connect to the DB '%v'", databaseURL)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	return dbpool, nil
+}
+
+// botServices are shared by the Telegram bot and the console.
+type botServices struct {
+	buildings   services.BuildingService
+	users       services.UserService
+	corrections services.CorrectionService
+	photos      services.PhotoService
+	chats       services.ChatService
+	routes      services.RouteService
+	updates     services.UpdateService
+	popularity  services.PopularityService
+	catalog     services.CatalogService
+	// flushBuildingCache is nil if the building cache is disabled
+	flushBuildingCache func()
+}
+
+func newBotServices(
+	config configuration.StartupConfig,
+	dbpool *pgxpool.Pool,
+	m *metrics.Metrics,
+) botServices {
+	var buildingRepo repositories.BuildingRepository = repositories.NewBuildingRepo(dbpool)
+	// the cache is flushed by building changes in this and other processes
+	var flushBuildingCache func()
+	if config.BuildingCacheSize > 0 {
+		cachedBuildingRepo := repositories.NewCachedBuildingRepo(
+			buildingRepo,
+			config.BuildingCacheSize,
+			time.Duration(config.BuildingCacheTTL)*time.Second,
+			m,
+		)
+		buildingRepo, flushBuildingCache = cachedBuildingRepo, cachedBuildingRepo.Flush
+	}
+	actorRepo := repositories.NewActorRepo(dbpool)
+	return botServices{
+		services.NewBuildingService(buildingRepo, actorRepo),
+		services.NewUserService(
+			repositories.NewUserRepo(dbpool),
+			m,
+			config.UserCacheSize,
+			time.Duration(config.UserCacheTTL)*time.Second,
+		),
+		services.NewCorrectionService(
+			repositories.NewCorrectionRepo(dbpool),
+			buildingRepo,
+			repositories.NewTransactionRepo(dbpool),
+		),
+		services.NewPhotoService(repositories.NewPhotoRepo(dbpool)),
+		services.NewChatService(repositories.NewChatRepo(dbpool)),
+		services.NewRouteService(buildingRepo),
+		services.NewUpdateService(repositories.NewUpdateRepo(dbpool)),
+		services.NewPopularityService(
+			repositories.NewPopularityRepo(dbpool),
+			buildingRepo,
+			[]byte(config.PopularitySalt),
+		),
+		services.NewCatalogService(repositories.NewNeighbourhoodRepo(dbpool), actorRepo),
+		flushBuildingCache,
+	}
+}
+
+func newHandlerContainer(
+	ui frontend.Frontend,
+	svc botServices,
+	m *metrics.Metrics,
+	settings *handlers.Settings,
+	botName string,
+	config configuration.StartupConfig,
+) handlers.HandlerContainer {
+	return handlers.NewCommandContainer(
+		ui,
+		svc.buildings,
+		svc.users,
+		svc.corrections,
+		svc.photos,
+		svc.chats,
+		svc.routes,
+		svc.popularity,
+		m,
+		settings,
+		botName,
+		time.Duration(config.HandlerTimeout)*time.Second,
+	)
+}
+
+// setMenuButton opens the Mini App from a menu button of private chats.
+func setMenuButton(bot *tgbotapi.BotAPI, webAppURL string) error {
+	params := tgbotapi.Params{}
+	err := params.AddInterface("menu_button", map[string]any{
+		"type":    "web_app",
+		"text":    "Buildings",
+		"web_app": map[string]string{"url": webAppURL},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := bot.MakeRequest("setChatMenuButton", params); err != nil {
+		return fmt.Errorf("can not set a menu button of the web app: %w", err)
+	}
+	return nil
+}
+
+func getRuntimeSettings(config configuration.StartupConfig) handlers.RuntimeSettings {
+	return handlers.RuntimeSettings{
+		RateLimit:    config.RateLimit,
+		AdminIDs:     config.AdminIDs,
+		SearchRadius: config.SearchRadius,
+		PageSize:     config.PageSize,
+	}
+}
+
+// reload applies settings that can safely change without a restart.
+// Other changed settings take effect after a restart. An invalid config
+// leaves current settings intact.
+func (s *Server) reload(ctx context.Context) {
+	config, err := configuration.LoadStartupConfig(s.configPath)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"can not reload the configuration, keep current settings",
+			slog.Any(logger.ErrorKey, err),
+		)
+		return
+	}
+	s.logLevel.Set(config.GetLogLevel())
+	s.settings.Set(getRuntimeSettings(config))
+	slog.InfoContext(
+		ctx,
+		"the configuration has been reloaded",
+		slog.String("log_level", config.GetLogLevel().String()),
+		slog.Int("rate_limit", config.RateLimit),
+		slog.Int("admins", len(config.AdminIDs)),
+		slog.Int("search_radius", config.SearchRadius),
+		slog.Int("page_size", config.PageSize),
+	)
+}
+
 func (s *Server) Shutdown(timeout time.Duration) {
 	// set the timeout to prevent a system hang
 	timeoutFunc := time.AfterFunc(timeout, func() {
@@ -133,18 +393,18 @@ func (s *Server) RunBot(ctx context.Context) error {
 	go func() {
 		signalCh := make(chan os.Signal, 4)
 		signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
-		select {
-		case sig := <-signalCh:
-			slog.InfoContext(ctx, fmt.Sprintf("receive an OS signal '%v'", sig))
-		case <-ctx.Done():
-			slog.InfoContext(ctx, fmt.Sprintf("start shutdown because of context"))
-		}
+		s.waitForShutdown(ctx, signalCh)
 
 		shutdownCtx, shutdownCtxCancel := context.WithTimeout(ctx, 5*time.Second)
 		defer shutdownCtxCancel()
 		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
 			slog.ErrorContext(ctx, "a metrics shutdown error", slog.Any(logger.ErrorKey, err))
 		}
+		if s.apiServer != nil {
+			if err := s.apiServer.Shutdown(shutdownCtx); err != nil {
+				slog.ErrorContext(ctx, "an API shutdown error", slog.Any(logger.ErrorKey, err))
+			}
+		}
 		close(idleConnectionsClosed)
 		cancelCtx()
 	}()
@@ -156,17 +416,44 @@ func (s *Server) RunBot(ctx context.Context) error {
 		cancelCtx()
 	}()
 
+	if s.apiServer != nil {
+		go func() {
+			slog.InfoContext(ctx, fmt.Sprintf("start the API on %v", s.apiServer.Addr))
+			if err := s.apiServer.ListenAndServe(); err != http.ErrServerClosed {
+				slog.ErrorContext(ctx, "can not start the API", slog.Any(logger.ErrorKey, err))
+			}
+			cancelCtx()
+		}()
+	}
+
 	if err := s.setBotCommands(ctx); err != nil {
 		return fmt.Errorf("can not set bot commands: %w", err)
 	}
 
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = s.tgUpdateTimeout
-	updates := s.bot.GetUpdatesChan(u)
+	offset, err := s.updateService.GetOffset(ctx)
+	if err != nil {
+		return fmt.Errorf("can not get an update offset: %w", err)
+	}
 	var wg sync.WaitGroup
-	for i := 0; i < s.updateReadersNumber; i++ {
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		s.removeExpiredEvents(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		s.observeLanguages(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		s.pollUpdates(ctx, offset)
+	}()
+	if s.flushBuildingCache != nil {
 		wg.Add(1)
-		go s.receiveUpdates(ctx, updates, &wg)
+		go func() {
+			defer wg.Done()
+			s.listenBuildingChanges(ctx)
+		}()
 	}
 	slog.InfoContext(
 		ctx,
@@ -175,20 +462,123 @@ func (s *Server) RunBot(ctx context.Context) error {
 
 	<-idleConnectionsClosed
 	wg.Wait()
+	s.handlers.Wait()
 	slog.InfoContext(ctx, "stopped listening for updates")
 	return nil
 }
 
+// waitForShutdown reloads runtime settings on SIGHUP until another signal
+// or a cancellation of a context.
+func (s *Server) waitForShutdown(ctx context.Context, signalCh <-chan os.Signal) {
+	for {
+		select {
+		case sig := <-signalCh:
+			slog.InfoContext(ctx, fmt.Sprintf("receive an OS signal '%v'", sig))
+			if sig != syscall.SIGHUP {
+				return
+			}
+			s.reload(ctx)
+		case <-ctx.Done():
+			slog.InfoContext(ctx, fmt.Sprintf("start shutdown because of context"))
+			return
+		}
+	}
+}
+
+// removeExpiredEvents periodically removes popularity events
+// that are older than the retention period.
+func (s *Server) removeExpiredEvents(ctx context.Context) {
+	ticker := time.NewTicker(popularityCleanupPeriod)
+	defer ticker.Stop()
+	for {
+		removed, err := s.popularityService.RemoveExpiredEvents(ctx, s.popularityRetention)
+		if err == nil {
+			slog.InfoContext(ctx, fmt.Sprintf("removed %v expired popularity events", removed))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeLanguages periodically counts users per preferred language.
+func (s *Server) observeLanguages(ctx context.Context) {
+	ticker := time.NewTicker(languageObservationPeriod)
+	defer ticker.Stop()
+	for {
+		if err := s.userService.ObserveLanguages(ctx); err != nil {
+			slog.WarnContext(
+				ctx,
+				"can not count users per language",
+				slog.Any(logger.ErrorKey, err),
+			)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// listenBuildingChanges flushes the building cache when another process,
+// for example, the populate command, changes buildings.
+func (s *Server) listenBuildingChanges(ctx context.Context) {
+	for {
+		// notifications may have been missed while there was no connection
+		s.flushBuildingCache()
+		err := s.buildingChanges.Listen(ctx, func() {
+			slog.InfoContext(ctx, "buildings have changed, flush the building cache")
+			s.flushBuildingCache()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(
+			ctx,
+			"stopped listening for building changes",
+			slog.Any(logger.ErrorKey, err),
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
 func (s *Server) setBotCommands(ctx context.Context) error {
+	err := s.setScopeCommands(
+		ctx,
+		tgbotapi.NewBotCommandScopeAllPrivateChats(),
+		s.handlers.HandlersPerCommand,
+	)
+	if err != nil {
+		return err
+	}
+	return s.setScopeCommands(
+		ctx,
+		tgbotapi.NewBotCommandScopeAllGroupChats(),
+		s.handlers.HandlersPerGroupCommand,
+	)
+}
+
+func (s *Server) setScopeCommands(
+	ctx context.Context,
+	scope tgbotapi.BotCommandScope,
+	handlersPerCommand map[string]handlers.CommandHandler,
+) error {
 	commands := []tgbotapi.BotCommand{}
-	for commandName, handler := range s.handlers.HandlersPerCommand {
+	for commandName, handler := range handlersPerCommand {
 		command := tgbotapi.BotCommand{
 			Command:     commandName,
 			Description: handler.Description,
 		}
 		commands = append(commands, command)
 	}
-	setCommandsConfig := tgbotapi.NewSetMyCommands(commands...)
+	setCommandsConfig := tgbotapi.NewSetMyCommandsWithScope(scope, commands...)
 	result, err := s.bot.Request(setCommandsConfig)
 	if err != nil {
 		slog.ErrorContext(
@@ -218,59 +608,164 @@ func (s *Server) setBotCommands(ctx context.Context) error {
 	return nil
 }
 
-func (s *Server) receiveUpdates(
-	ctx context.Context,
-	updates tgbotapi.UpdatesChannel,
-	wg *sync.WaitGroup,
-) {
-	defer wg.Done()
+// pollUpdates confirms updates to Telegram by an offset of the next request
+// only after a whole batch is handled, so updates which a stopped or crashed
+// bot has not handled come again after a restart.
+func (s *Server) pollUpdates(ctx context.Context, offset int) {
+	config := tgbotapi.NewUpdate(offset)
+	config.Timeout = s.tgUpdateTimeout
+	// handlers finish a started batch during a shutdown
+	handlerCtx := context.WithoutCancel(ctx)
+	readers := make(chan struct{}, s.updateReadersNumber)
 	for {
-		select {
-		case <-ctx.Done():
+		updates, err := s.getUpdates(ctx, config)
+		if ctx.Err() != nil {
 			return
-		case update := <-updates:
-			s.metrics.ChatUpdates.Inc()
-			s.handleUpdate(ctx, update)
 		}
+		if err != nil {
+			slog.WarnContext(ctx, "can not get updates", slog.Any(logger.ErrorKey, err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(updateRetryDelay):
+			}
+			continue
+		}
+		var handled sync.WaitGroup
+		for _, update := range updates {
+			if ctx.Err() != nil {
+				break
+			}
+			s.updateService.Receive(update.UpdateID)
+			readers <- struct{}{}
+			handled.Add(1)
+			go func(update tgbotapi.Update) {
+				defer func() { <-readers }()
+				defer handled.Done()
+				s.metrics.ChatUpdates.Inc()
+				s.processUpdate(handlerCtx, update)
+			}(update)
+			config.Offset = update.UpdateID + 1
+		}
+		handled.Wait()
+	}
+}
+
+// getUpdates stops waiting for a long polling request on a cancellation.
+// Telegram sends the updates of an abandoned request again because
+// the request does not change a confirmed offset.
+func (s *Server) getUpdates(
+	ctx context.Context,
+	config tgbotapi.UpdateConfig,
+) ([]tgbotapi.Update, error) {
+	type response struct {
+		updates []tgbotapi.Update
+		err     error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		updates, err := s.bot.GetUpdates(config)
+		responses <- response{updates, err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-responses:
+		return r.updates, r.err
+	}
+}
+
+// processUpdate skips updates which have already been handled, for example,
+// before a crash, and marks an update as handled only after its handler returns.
+func (s *Server) processUpdate(ctx context.Context, update tgbotapi.Update) {
+	ctx, span := tracing.Start(
+		ctx,
+		"telegram update",
+		attribute.Int("telegram.update_id", update.UpdateID),
+	)
+	defer span.End()
+	defer func() {
+		// save an offset even if the bot is shutting down
+		err := s.updateService.Finish(context.WithoutCancel(ctx), update.UpdateID)
+		if err != nil {
+			slog.WarnContext(
+				ctx,
+				fmt.Sprintf("can not save an offset after the update %v", update.UpdateID),
+				slog.Any(logger.ErrorKey, err),
+			)
+		}
+	}()
+	isHandled, err := s.updateService.IsHandled(ctx, update.UpdateID)
+	if err != nil {
+		// it is better to handle an update twice than to lose it
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not check the update %v", update.UpdateID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	if isHandled {
+		slog.InfoContext(ctx, fmt.Sprintf("skip the processed update %v", update.UpdateID))
+		s.metrics.UnexpectedUpdates.With(
+			prom.Labels{"error": "duplicate update"},
+		).Inc()
+		return
 	}
+	s.handleUpdate(ctx, update)
 }
 
 func (s *Server) handleUpdate(ctx context.Context, update tgbotapi.Update) {
+	ctx = logger.WithAttrs(ctx, getUpdateAttrs(update)...)
 	switch {
 	case update.Message != nil:
 		s.handleMessage(ctx, update.Message)
 	case update.CallbackQuery != nil:
 		s.handleButton(ctx, update.CallbackQuery)
 	default:
-		slog.DebugContext(ctx, fmt.Sprintf("an unexpected update %v", update))
+		slog.DebugContext(ctx, "an unexpected update")
 	}
 }
 
+// getUpdateAttrs returns identifiers which help to find all log records
+// about an update.
+func getUpdateAttrs(update tgbotapi.Update) []slog.Attr {
+	attrs := []slog.Attr{slog.Int(logger.UpdateIDKey, update.UpdateID)}
+	if chat := update.FromChat(); chat != nil {
+		attrs = append(attrs, slog.Int64(logger.ChatIDKey, chat.ID))
+	}
+	if user := update.SentFrom(); user != nil {
+		attrs = append(attrs, slog.Int64(logger.UserIDKey, user.ID))
+	}
+	return attrs
+}
+
 func (s *Server) handleMessage(ctx context.Context, message *tgbotapi.Message) {
 	user := message.From
 	if user == nil {
 		s.metrics.UnexpectedUpdates.With(prom.Labels{"error": "no user"}).Inc()
 		return
 	}
-	handlerName := message.Command()
-	if message.Location != nil {
-		handlerName = "nearestAddresses"
-	}
-	handler, ok := s.handlers.GetCommandHandler(handlerName)
-	if ok {
-		slog.DebugContext(ctx, "handle a command", slog.String("command", message.Command()))
-		handler(ctx, message)
+	message, ok := telegram.FilterGroupMessage(message, s.botUser)
+	if !ok {
 		return
 	}
-	s.handlers.ProcessCommonMessage(ctx, message)
+	s.handlers.HandleMessage(ctx, telegram.NewMessage(message))
 }
 
 func (s *Server) handleButton(ctx context.Context, query *tgbotapi.CallbackQuery) {
-	var queryData handlers.NextButton
+	click, err := telegram.NewButtonClick(query)
+	if err != nil {
+		slog.WarnContext(ctx, "unexpected callback", slog.Any(logger.ErrorKey, err))
+		s.metrics.UnexpectedUpdates.With(
+			prom.Labels{"error": "unexpected callback"},
+		).Inc()
+		return
+	}
+	var queryData handlers.Button
 	if err := json.Unmarshal([]byte(query.Data), &queryData); err != nil {
 		slog.WarnContext(
 			ctx,
-			fmt.Sprintf("unexpected callback data %v", query),
+			"unexpected callback data",
 			slog.Any(logger.ErrorKey, err),
 		)
 		s.metrics.UnexpectedUpdates.With(
@@ -278,13 +773,14 @@ func (s *Server) handleButton(ctx context.Context, query *tgbotapi.CallbackQuery
 		).Inc()
 		return
 	}
+	ctx = logger.WithAttrs(ctx, slog.String(logger.CommandKey, queryData.Name))
 	handler, ok := s.handlers.GetButtonHandler(queryData.Name)
 	if !ok {
 		logMsg := fmt.Sprintf(
 			"the unexpected button name %v from the chat %v: initial message %v",
 			queryData.Name,
-			query.Message.Chat.ID,
-			query.Message.MessageID,
+			click.Message.Chat.ID,
+			click.Message.ID,
 		)
 		slog.WarnContext(ctx, logMsg)
 		s.metrics.UnexpectedUpdates.With(
@@ -292,5 +788,5 @@ func (s *Server) handleButton(ctx context.Context, query *tgbotapi.CallbackQuery
 		).Inc()
 		return
 	}
-	handler(ctx, query)
+	handler(ctx, click)
 }
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LRU is a concurrency-safe cache that evicts the least recently used
+// item when it is full. Items expire after a TTL.
+type LRU[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[K]*list.Element
+	order *list.List
+	now   func() time.Time
+}
+
+func NewLRU[K comparable, V any](size int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[K]*list.Element, size),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var empty V
+	element, ok := c.items[key]
+	if !ok {
+		return empty, false
+	}
+	item := element.Value.(*entry[K, V])
+	if !c.now().Before(item.expiresAt) {
+		c.removeElement(element)
+		return empty, false
+	}
+	c.order.MoveToFront(element)
+	return item.value, true
+}
+
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := c.now().Add(c.ttl)
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*entry[K, V])
+		item.value, item.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+	item := &entry[K, V]{key, value, expiresAt}
+	c.items[key] = c.order.PushFront(item)
+	if c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.items[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// Purge removes all items.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]*list.Element, c.size)
+	c.order.Init()
+}
+
+// Len returns a number of items including expired ones
+// which have not been evicted yet.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) removeElement(element *list.Element) {
+	item := c.order.Remove(element).(*entry[K, V])
+	delete(c.items, item.key)
+}
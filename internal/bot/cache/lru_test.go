@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU_eviction(t *testing.T) {
+	cache := NewLRU[string, int](2, time.Minute)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	value, ok := cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	// "b" is the least recently used item
+	cache.Set("c", 3)
+	_, ok = cache.Get("b")
+	require.False(t, ok)
+	require.Equal(t, 2, cache.Len())
+
+	cache.Set("a", 10)
+	value, ok = cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 10, value)
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestLRU_expiration(t *testing.T) {
+	now := time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)
+	cache := NewLRU[string, int](2, time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.Set("a", 1)
+	now = now.Add(30 * time.Second)
+	cache.Set("b", 2)
+	now = now.Add(30 * time.Second)
+	_, ok := cache.Get("a")
+	require.False(t, ok)
+	value, ok := cache.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestLRU_remove(t *testing.T) {
+	cache := NewLRU[int64, string](10, time.Minute)
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.Set(3, "c")
+	cache.Remove(1)
+	cache.Remove(100)
+	_, ok := cache.Get(1)
+	require.False(t, ok)
+	require.Equal(t, 2, cache.Len())
+	cache.Purge()
+	require.Equal(t, 0, cache.Len())
+	_, ok = cache.Get(2)
+	require.False(t, ok)
+}
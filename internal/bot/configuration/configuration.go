@@ -1,14 +1,57 @@
 package configuration
 
 type StartupConfig struct {
-	BotAPIToken         string `env:"BOT_TOKEN,required,notEmpty"`
-	DatabaseURL         string `env:"DATABASE_URL,required,notEmpty"`
-	Debug               bool   `env:"DEBUG"`
-	TGUpdateTimeout     int    `env:"UPDATE_TIMEOUT" envDefault:"60"`
-	UpdateReadersNumber int    `env:"UPDATE_READERS_NUMBER" envDefault:"10"`
-	MetricsUser         string `env:"METRICS_USER,required,notEmpty"`
-	MetricsPassword     string `env:"METRICS_PASSWORD,required,notEmpty"`
-	MetricsPort         int    `env:"METRICS_PORT,required,notEmpty"`
+	BotAPIToken         string  `env:"BOT_TOKEN,required,notEmpty"`
+	DatabaseURL         string  `env:"DATABASE_URL,required,notEmpty"`
+	Debug               bool    `env:"DEBUG"`
+	TGUpdateTimeout     int     `env:"UPDATE_TIMEOUT" envDefault:"60"`
+	UpdateReadersNumber int     `env:"UPDATE_READERS_NUMBER" envDefault:"10"`
+	MetricsUser         string  `env:"METRICS_USER,required,notEmpty"`
+	MetricsPassword     string  `env:"METRICS_PASSWORD,required,notEmpty"`
+	MetricsPort         int     `env:"METRICS_PORT,required,notEmpty"`
+	AdminIDs            []int64 `env:"ADMIN_IDS" envSeparator:","`
+	// HandlerTimeout limits the processing of one update in seconds
+	HandlerTimeout int `env:"HANDLER_TIMEOUT" envDefault:"30"`
+	// RateLimit is a number of requests per minute a user can send
+	RateLimit int `env:"RATE_LIMIT" envDefault:"30"`
+	// LogPrivateData disables the redaction of coordinates and message texts
+	LogPrivateData bool `env:"LOG_PRIVATE_DATA"`
+	// OTLPEndpoint receives traces, for example, http://localhost:4318
+	OTLPEndpoint string `env:"OTLP_ENDPOINT"`
+	// BotAPIEndpoint is a URL format of Bot API methods with a token and
+	// a method name, for example, of a local Bot API server
+	BotAPIEndpoint string `env:"BOT_API_ENDPOINT" envDefault:"https://api.telegram.org/bot%s/%s"`
+	// PopularitySalt is a secret key to hash user IDs in popularity events.
+	// It differs from the bot token, so a token neither reveals users
+	// nor changes their hashes after a rotation.
+	PopularitySalt string `env:"POPULARITY_SALT,required,notEmpty"`
+	// PopularityRetention is a number of days to keep popularity events
+	PopularityRetention int `env:"POPULARITY_RETENTION" envDefault:"90"`
+	// BuildingCacheSize is a number of cached building queries, zero disables the cache
+	BuildingCacheSize int `env:"BUILDING_CACHE_SIZE" envDefault:"1000"`
+	// BuildingCacheTTL is a lifetime of a cached building query in seconds
+	BuildingCacheTTL int `env:"BUILDING_CACHE_TTL" envDefault:"300"`
+	// UserCacheSize is a number of users whose preferences are cached,
+	// zero disables the cache
+	UserCacheSize int `env:"USER_CACHE_SIZE" envDefault:"10000"`
+	// UserCacheTTL is a lifetime of cached user preferences in seconds
+	UserCacheTTL int `env:"USER_CACHE_TTL" envDefault:"3600"`
+	// LogLevel is one of debug, info, warn and error. Debug overrides it.
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+	// SearchRadius is a distance to the nearest buildings in metres
+	SearchRadius int `env:"SEARCH_RADIUS" envDefault:"100"`
+	// PageSize is a number of buildings on a page of a list
+	PageSize int `env:"PAGE_SIZE" envDefault:"10"`
+	// APIPort serves the public HTTP API, zero disables the API
+	APIPort int `env:"API_PORT"`
+	// APIKeys are keys of API clients. A key can have its own rate limit
+	// after a colon, for example, partner:120.
+	APIKeys []string `env:"API_KEYS" envSeparator:","`
+	// APIRateLimit is a number of requests per minute an API key can send
+	APIRateLimit int `env:"API_RATE_LIMIT" envDefault:"60"`
+	// WebAppURL is a public HTTPS address of the Mini App which the API server
+	// serves under /webapp/. The URL enables the app and its menu button.
+	WebAppURL string `env:"WEBAPP_URL"`
 }
 
 type PopulatorConfig struct {
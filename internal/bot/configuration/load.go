@@ -0,0 +1,261 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/env/v9"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxSearchRadius = 5000
+	// maxPageSize keeps a page far below the Telegram limit of 100 buttons
+	maxPageSize = 50
+)
+
+// KeyError is an invalid value of a configuration key. A key of a file is
+// a lower case name of an environment variable, for example, rate_limit.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e KeyError) Error() string {
+	return fmt.Sprintf("invalid key %q (%v): %v", e.Key, strings.ToUpper(e.Key), e.Err)
+}
+
+func (e KeyError) Unwrap() error {
+	return e.Err
+}
+
+// LoadStartupConfig reads an optional YAML file and environment variables,
+// which override values of the file. An empty path means no file.
+func LoadStartupConfig(path string) (StartupConfig, error) {
+	config := StartupConfig{}
+	environment := map[string]string{}
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		environment[name] = value
+	}
+	if path != "" {
+		fileValues, err := readFile(path)
+		if err != nil {
+			return config, err
+		}
+		for name, value := range fileValues {
+			if _, ok := environment[name]; !ok {
+				environment[name] = value
+			}
+		}
+	}
+	err := env.ParseWithOptions(&config, env.Options{Environment: environment})
+	if err != nil {
+		return config, getKeyErrors(err)
+	}
+	return config, config.Validate()
+}
+
+// readFile returns values of a configuration file per environment variable.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can not read a configuration file: %w", err)
+	}
+	var keys map[string]any
+	if err := yaml.Unmarshal(content, &keys); err != nil {
+		return nil, fmt.Errorf("can not parse a configuration file %v: %w", path, err)
+	}
+	knownNames := map[string]bool{}
+	for _, name := range getEnvNames() {
+		knownNames[name] = true
+	}
+	values := map[string]string{}
+	var errs []error
+	for key, value := range keys {
+		name := strings.ToUpper(key)
+		if !knownNames[name] || key != strings.ToLower(key) {
+			errs = append(errs, KeyError{key, errors.New("unknown key")})
+			continue
+		}
+		converted, err := toEnvValue(value)
+		if err != nil {
+			errs = append(errs, KeyError{key, err})
+			continue
+		}
+		values[name] = converted
+	}
+	return values, errors.Join(errs...)
+}
+
+// toEnvValue converts a YAML value into a format of environment variables.
+func toEnvValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			converted, err := toEnvValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = converted
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", errors.New("expected a value or a list, got a mapping")
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// getEnvNames returns environment variables per field of a startup config.
+func getEnvNames() map[string]string {
+	names := map[string]string{}
+	configType := reflect.TypeOf(StartupConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("env"), ",")
+		names[field.Name] = name
+	}
+	return names
+}
+
+// getKeyErrors names keys in errors of the env library.
+func getKeyErrors(err error) error {
+	var aggregateErr env.AggregateError
+	if !errors.As(err, &aggregateErr) {
+		return err
+	}
+	envNames := getEnvNames()
+	errs := make([]error, len(aggregateErr.Errors))
+	for i, err := range aggregateErr.Errors {
+		var parseErr env.ParseError
+		var notSetErr env.EnvVarIsNotSetError
+		var emptyErr env.EmptyEnvVarError
+		switch {
+		case errors.As(err, &parseErr):
+			errs[i] = KeyError{strings.ToLower(envNames[parseErr.Name]), parseErr.Err}
+		case errors.As(err, &notSetErr):
+			errs[i] = KeyError{strings.ToLower(notSetErr.Key), errors.New("is required")}
+		case errors.As(err, &emptyErr):
+			errs[i] = KeyError{strings.ToLower(emptyErr.Key), errors.New("must not be empty")}
+		default:
+			errs[i] = err
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks values which the env library accepts but the bot can not use.
+func (c StartupConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, key, message string) {
+		if !ok {
+			errs = append(errs, KeyError{key, errors.New(message)})
+		}
+	}
+	check(
+		strings.Count(c.BotAPIEndpoint, "%s") == 2 &&
+			(strings.HasPrefix(c.BotAPIEndpoint, "https://") ||
+				strings.HasPrefix(c.BotAPIEndpoint, "http://")),
+		"bot_api_endpoint",
+		"must be an HTTP URL with placeholders of a token and a method",
+	)
+	check(c.TGUpdateTimeout >= 0, "update_timeout", "must not be negative")
+	check(c.UpdateReadersNumber > 0, "update_readers_number", "must be positive")
+	check(
+		c.MetricsPort > 0 && c.MetricsPort <= 65535,
+		"metrics_port",
+		"must be between 1 and 65535",
+	)
+	check(c.HandlerTimeout >= 0, "handler_timeout", "must not be negative")
+	check(c.RateLimit >= 0, "rate_limit", "must not be negative")
+	check(
+		c.PopularitySalt != c.BotAPIToken,
+		"popularity_salt",
+		"must differ from bot_token",
+	)
+	check(c.PopularityRetention > 0, "popularity_retention", "must be positive")
+	check(c.BuildingCacheSize >= 0, "building_cache_size", "must not be negative")
+	check(c.BuildingCacheTTL > 0, "building_cache_ttl", "must be positive")
+	check(c.UserCacheSize >= 0, "user_cache_size", "must not be negative")
+	check(c.UserCacheTTL > 0, "user_cache_ttl", "must be positive")
+	var level slog.Level
+	check(
+		level.UnmarshalText([]byte(c.LogLevel)) == nil,
+		"log_level",
+		"must be one of debug, info, warn and error",
+	)
+	check(
+		c.SearchRadius > 0 && c.SearchRadius <= maxSearchRadius,
+		"search_radius",
+		fmt.Sprintf("must be between 1 and %v", maxSearchRadius),
+	)
+	check(
+		c.PageSize > 0 && c.PageSize <= maxPageSize,
+		"page_size",
+		fmt.Sprintf("must be between 1 and %v", maxPageSize),
+	)
+	check(
+		c.APIPort >= 0 && c.APIPort <= 65535 && c.APIPort != c.MetricsPort,
+		"api_port",
+		"must be between 0 and 65535 and differ from metrics_port",
+	)
+	check(
+		c.APIPort == 0 || len(c.APIKeys) > 0 || c.WebAppURL != "",
+		"api_keys",
+		"are required by the API",
+	)
+	for _, apiKey := range c.APIKeys {
+		key, limit, err := parseAPIKey(apiKey, c.APIRateLimit)
+		check(
+			err == nil && key != "" && limit > 0,
+			"api_keys",
+			"must be keys with optional positive limits, for example, partner:120",
+		)
+	}
+	check(c.APIRateLimit > 0, "api_rate_limit", "must be positive")
+	check(
+		c.WebAppURL == "" || c.APIPort != 0 && strings.HasPrefix(c.WebAppURL, "https://"),
+		"webapp_url",
+		"must be an HTTPS URL and requires api_port",
+	)
+	return errors.Join(errs...)
+}
+
+// parseAPIKey splits an API key and its optional rate limit.
+func parseAPIKey(apiKey string, defaultLimit int) (string, int, error) {
+	key, limit, ok := strings.Cut(apiKey, ":")
+	if !ok {
+		return key, defaultLimit, nil
+	}
+	rateLimit, err := strconv.Atoi(limit)
+	return key, rateLimit, err
+}
+
+// GetAPIKeys returns a rate limit per API key of a valid config.
+func (c StartupConfig) GetAPIKeys() map[string]int {
+	limitPerKey := make(map[string]int, len(c.APIKeys))
+	for _, apiKey := range c.APIKeys {
+		key, limit, _ := parseAPIKey(apiKey, c.APIRateLimit)
+		limitPerKey[key] = limit
+	}
+	return limitPerKey
+}
+
+// GetLogLevel returns a level of a valid config.
+func (c StartupConfig) GetLogLevel() slog.Level {
+	if c.Debug {
+		return slog.LevelDebug
+	}
+	var level slog.Level
+	level.UnmarshalText([]byte(c.LogLevel))
+	return level
+}
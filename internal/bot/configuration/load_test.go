@@ -0,0 +1,116 @@
+package configuration
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const requiredKeys = `
+bot_token: file token
+database_url: postgres://localhost/db
+metrics_user: user
+metrics_password: password
+metrics_port: 9090
+popularity_salt: file salt
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadStartupConfig(t *testing.T) {
+	t.Setenv("RATE_LIMIT", "7")
+	path := writeConfig(t, requiredKeys+`
+rate_limit: 5
+page_size: 20
+admin_ids: [1, 2]
+log_level: warn
+debug: false
+`)
+	config, err := LoadStartupConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "file token", config.BotAPIToken)
+	require.Equal(t, 9090, config.MetricsPort)
+	require.Equal(t, 7, config.RateLimit)
+	require.Equal(t, 20, config.PageSize)
+	require.Equal(t, []int64{1, 2}, config.AdminIDs)
+	require.Equal(t, slog.LevelWarn, config.GetLogLevel())
+	require.Equal(t, 100, config.SearchRadius)
+	require.Equal(t, "https://api.telegram.org/bot%s/%s", config.BotAPIEndpoint)
+}
+
+func TestStartupConfig_GetAPIKeys(t *testing.T) {
+	config := StartupConfig{APIKeys: []string{"first", "second:120"}, APIRateLimit: 60}
+	require.Equal(t, map[string]int{"first": 60, "second": 120}, config.GetAPIKeys())
+}
+
+func TestLoadStartupConfig_environmentOnly(t *testing.T) {
+	t.Setenv("BOT_TOKEN", "env token")
+	t.Setenv("DATABASE_URL", "postgres://localhost/db")
+	t.Setenv("METRICS_USER", "user")
+	t.Setenv("METRICS_PASSWORD", "password")
+	t.Setenv("METRICS_PORT", "9090")
+	t.Setenv("POPULARITY_SALT", "env salt")
+	t.Setenv("DEBUG", "true")
+	config, err := LoadStartupConfig("")
+	require.NoError(t, err)
+	require.Equal(t, "env token", config.BotAPIToken)
+	require.Equal(t, 10, config.PageSize)
+	require.Equal(t, slog.LevelDebug, config.GetLogLevel())
+}
+
+func TestLoadStartupConfig_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		env         map[string]string
+		expectedKey string
+	}{
+		{"unknown key", "rate_limt: 5", nil, "rate_limt"},
+		{"upper case key", "RATE_LIMIT: 5", nil, "RATE_LIMIT"},
+		{"not a number", "rate_limit: many", nil, "rate_limit"},
+		{"a mapping", "admin_ids: {a: 1}", nil, "admin_ids"},
+		{"a negative limit", "rate_limit: -1", nil, "rate_limit"},
+		{"a large page", "page_size: 100", nil, "page_size"},
+		{"a zero radius", "search_radius: 0", nil, "search_radius"},
+		{"an unknown level", "log_level: loud", nil, "log_level"},
+		{"an invalid port", "", map[string]string{"METRICS_PORT": "70000"}, "metrics_port"},
+		{"an empty token", "", map[string]string{"BOT_TOKEN": ""}, "bot_token"},
+		{"an empty salt", "", map[string]string{"POPULARITY_SALT": ""}, "popularity_salt"},
+		{"a salt equal to the token", "", map[string]string{"POPULARITY_SALT": "file token"}, "popularity_salt"},
+		{"an endpoint without a method", "bot_api_endpoint: http://localhost/bot%s", nil, "bot_api_endpoint"},
+		{"an API without keys", "api_port: 8080", nil, "api_keys"},
+		{"an API on the metrics port", "api_port: 9090\napi_keys: [key]", nil, "api_port"},
+		{"an invalid key limit", "api_keys: [key:many]", nil, "api_keys"},
+		{"a zero key limit", "api_keys: [key:0]", nil, "api_keys"},
+		{"a negative key limit", "api_keys: [key:-1]", nil, "api_keys"},
+		{"a zero default limit", "api_keys: [key:120]\napi_rate_limit: 0", nil, "api_rate_limit"},
+		{"a zero default limit of a key", "api_keys: [key]\napi_rate_limit: 0", nil, "api_keys"},
+		{"a web app without the API", "webapp_url: https://example.com/webapp/", nil, "webapp_url"},
+		{"a web app over HTTP", "api_port: 8080\nwebapp_url: http://example.com", nil, "webapp_url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := writeConfig(t, requiredKeys+tt.content)
+			_, err := LoadStartupConfig(path)
+			var keyErr KeyError
+			require.ErrorAs(t, err, &keyErr)
+			require.Equal(t, tt.expectedKey, keyErr.Key)
+			require.Contains(t, err.Error(), `"`+tt.expectedKey+`"`)
+		})
+	}
+}
+
+func TestLoadStartupConfig_absentFile(t *testing.T) {
+	_, err := LoadStartupConfig(filepath.Join(t.TempDir(), "absent.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
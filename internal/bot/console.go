@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/configuration"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend/console"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/handlers"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// consoleBotName makes deep links of the console distinguishable
+const consoleBotName = "console"
+
+// RunConsole passes lines of an input to the handlers as events of one user
+// in a private chat and writes the answers of the bot to an output.
+// The console uses the services and the database of the bot without Telegram.
+// It stops at the end of the input.
+func RunConsole(
+	ctx context.Context,
+	config configuration.StartupConfig,
+	user frontend.User,
+	in io.Reader,
+	out io.Writer,
+) error {
+	dbpool, err := newDBPool(ctx, config.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer dbpool.Close()
+	m := metrics.NewMetrics(prom.NewRegistry())
+	ui := console.NewFrontend(out)
+	container := newHandlerContainer(
+		ui,
+		newBotServices(config, dbpool, m),
+		m,
+		handlers.NewSettings(getRuntimeSettings(config)),
+		consoleBotName,
+		config,
+	)
+	defer container.Wait()
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		input, err := ui.Parse(scanner.Text(), user)
+		switch {
+		case err != nil:
+		case input.Message != nil:
+			err = container.HandleMessage(ctx, *input.Message)
+		case input.Click != nil:
+			err = handleConsoleClick(ctx, container, *input.Click)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+		}
+	}
+	return scanner.Err()
+}
+
+func handleConsoleClick(
+	ctx context.Context,
+	container handlers.HandlerContainer,
+	click frontend.ButtonClick,
+) error {
+	var button handlers.Button
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		return fmt.Errorf("unexpected button data '%s': %w", click.Data, err)
+	}
+	handler, ok := container.GetButtonHandler(button.Name)
+	if !ok {
+		return fmt.Errorf("unexpected button name '%s'", button.Name)
+	}
+	return handler(ctx, click)
+}
@@ -0,0 +1,167 @@
+// Package console shows views of the guide in a terminal and turns lines
+// typed in a terminal into events of the guide.
+package console
+
+import (
+	c "context"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+)
+
+var (
+	linkPattern = regexp.MustCompile(`<a href="([^"]*)">(.*?)</a>`)
+	tagPattern  = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Frontend writes views as plain text and remembers sent messages, so
+// a user can click their buttons and reply to them.
+type Frontend struct {
+	mu  sync.Mutex
+	out io.Writer
+	// lastID is an ID of the last message of a chat
+	lastID   int
+	messages map[int]frontend.Message
+	// keyboardID is a message whose buttons are numbered for a click
+	keyboardID int
+	// replyID is the last message which asks a user to reply
+	replyID int
+}
+
+func NewFrontend(out io.Writer) *Frontend {
+	return &Frontend{out: out, messages: map[int]frontend.Message{}}
+}
+
+func (f *Frontend) Send(ctx c.Context, chatID int64, view frontend.View) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastID++
+	message := frontend.Message{ID: f.lastID, Chat: frontend.Chat{ID: chatID}}
+	var text strings.Builder
+	switch v := view.(type) {
+	case frontend.TextView:
+		message.Text = getPlainText(v.Text, v.Format)
+		text.WriteString(message.Text)
+		if v.ReplyRequest {
+			f.replyID = message.ID
+			text.WriteString("\n(reply with '> your text')")
+		}
+		if v.LocationRequest != "" {
+			fmt.Fprintf(&text, "\n(%s: send '@latitude,longitude')", v.LocationRequest)
+		}
+	case frontend.ButtonListView:
+		message.Text = getPlainText(v.Text, v.Format)
+		message.Buttons = v.Rows
+		text.WriteString(message.Text)
+	case frontend.LocationView:
+		writeLocation(&text, v)
+	case frontend.PhotoView:
+		message.Text = v.Caption
+		message.Buttons = v.Rows
+		writePhoto(&text, v)
+	case frontend.AlbumView:
+		for i, photo := range v.Photos {
+			if i > 0 {
+				text.WriteString("\n")
+			}
+			writePhoto(&text, photo)
+		}
+	case frontend.DocumentView:
+		message.Text = v.Caption
+		fmt.Fprintf(&text, "[document %s, %d bytes]\n%s", v.Name, len(v.Content), v.Caption)
+	default:
+		return fmt.Errorf("can not show %T: %w", view, frontend.ErrUnsupportedView)
+	}
+	f.messages[message.ID] = message
+	if len(message.Buttons) > 0 {
+		f.keyboardID = message.ID
+	}
+	return f.write(fmt.Sprintf("message %d", message.ID), text.String(), message.Buttons)
+}
+
+func (f *Frontend) Edit(
+	ctx c.Context,
+	chatID int64,
+	messageID int,
+	view frontend.View,
+) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	message, ok := f.messages[messageID]
+	if !ok {
+		return fmt.Errorf("the message %d is not found", messageID)
+	}
+	switch v := view.(type) {
+	case frontend.TextView:
+		message.Text, message.Buttons = getPlainText(v.Text, v.Format), nil
+	case frontend.ButtonListView:
+		message.Text, message.Buttons = getPlainText(v.Text, v.Format), v.Rows
+	case frontend.PhotoView:
+		message.Text, message.Buttons = v.Caption, v.Rows
+	default:
+		return fmt.Errorf("can not edit a message with %T: %w", view, frontend.ErrUnsupportedView)
+	}
+	f.messages[messageID] = message
+	if len(message.Buttons) > 0 {
+		f.keyboardID = messageID
+	}
+	return f.write(fmt.Sprintf("message %d edited", messageID), message.Text, message.Buttons)
+}
+
+func (f *Frontend) Answer(ctx c.Context, click frontend.ButtonClick, text string) error {
+	if text == "" {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err := fmt.Fprintf(f.out, "(%s)\n", text)
+	return err
+}
+
+// write numbers data and URL buttons of a message in the order of rows.
+func (f *Frontend) write(header, text string, rows [][]frontend.Button) error {
+	var output strings.Builder
+	fmt.Fprintf(&output, "--- %s ---\n%s\n", header, text)
+	number := 0
+	for _, row := range rows {
+		labels := make([]string, len(row))
+		for i, button := range row {
+			number++
+			labels[i] = fmt.Sprintf("[%d] %s", number, button.Label)
+			if button.URL != "" {
+				labels[i] += " -> " + button.URL
+			}
+		}
+		output.WriteString(strings.Join(labels, "  ") + "\n")
+	}
+	_, err := io.WriteString(f.out, output.String())
+	return err
+}
+
+func writeLocation(text *strings.Builder, view frontend.LocationView) {
+	fmt.Fprintf(text, "[location %v, %v]", view.Latitude, view.Longitude)
+	if view.Title != "" {
+		fmt.Fprintf(text, "\n%s\n%s", view.Title, view.Address)
+	}
+}
+
+func writePhoto(text *strings.Builder, view frontend.PhotoView) {
+	fmt.Fprintf(text, "[photo %s]", view.FileID)
+	if view.Caption != "" {
+		text.WriteString("\n" + view.Caption)
+	}
+}
+
+// getPlainText keeps addresses of links because a terminal can not open them.
+func getPlainText(text string, format frontend.Format) string {
+	if format != frontend.HTML {
+		return text
+	}
+	text = linkPattern.ReplaceAllString(text, "$2 ($1)")
+	return html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+}
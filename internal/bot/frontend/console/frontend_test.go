@@ -0,0 +1,111 @@
+package console
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontend_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		view     frontend.View
+		expected string
+	}{
+		{
+			"an HTML text",
+			frontend.TextView{
+				Text:   `<b>Kalevankatu 1</b> &amp; <a href="https://hel.fi">map</a>`,
+				Format: frontend.HTML,
+			},
+			"--- message 1 ---\nKalevankatu 1 & map (https://hel.fi)\n",
+		},
+		{
+			"a plain text keeps tags",
+			frontend.TextView{Text: "<b>test</b>"},
+			"--- message 1 ---\n<b>test</b>\n",
+		},
+		{
+			"a location request",
+			frontend.TextView{Text: "test", LocationRequest: "Share"},
+			"--- message 1 ---\ntest\n(Share: send '@latitude,longitude')\n",
+		},
+		{
+			"a button list",
+			frontend.ButtonListView{
+				Text: "test",
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("first", "1"), frontend.NewDataButton("second", "2")},
+					{frontend.NewURLButton("Share", "https://t.me/share")},
+				},
+			},
+			"--- message 1 ---\ntest\n[1] first  [2] second\n[3] Share -> https://t.me/share\n",
+		},
+		{
+			"a venue",
+			frontend.LocationView{
+				Location: frontend.Location{Latitude: 60.17, Longitude: 24.94},
+				Title:    "test",
+				Address:  "test address",
+			},
+			"--- message 1 ---\n[location 60.17, 24.94]\ntest\ntest address\n",
+		},
+		{
+			"an album",
+			frontend.AlbumView{Photos: []frontend.PhotoView{
+				{FileID: "first", Caption: "© first"},
+				{FileID: "second"},
+			}},
+			"--- message 1 ---\n[photo first]\n© first\n[photo second]\n",
+		},
+		{
+			"a document",
+			frontend.DocumentView{Name: "route.gpx", Content: []byte("test"), Caption: "test"},
+			"--- message 1 ---\n[document route.gpx, 4 bytes]\ntest\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := NewFrontend(&out).Send(context.Background(), 1, tt.view)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestFrontend_Edit(t *testing.T) {
+	var out strings.Builder
+	ui := NewFrontend(&out)
+	ctx := context.Background()
+	view := frontend.ButtonListView{
+		Text: "page 1",
+		Rows: [][]frontend.Button{{frontend.NewDataButton("next", "2")}},
+	}
+	require.NoError(t, ui.Send(ctx, 1, view))
+	out.Reset()
+
+	view = frontend.ButtonListView{
+		Text: "page 2",
+		Rows: [][]frontend.Button{{frontend.NewDataButton("previous", "1")}},
+	}
+	require.NoError(t, ui.Edit(ctx, 1, 1, view))
+	require.Equal(t, "--- message 1 edited ---\npage 2\n[1] previous\n", out.String())
+	require.ErrorIs(
+		t,
+		ui.Edit(ctx, 1, 1, frontend.LocationView{}),
+		frontend.ErrUnsupportedView,
+	)
+	require.Error(t, ui.Edit(ctx, 1, 2, view))
+}
+
+func TestFrontend_Answer(t *testing.T) {
+	var out strings.Builder
+	ui := NewFrontend(&out)
+	require.NoError(t, ui.Answer(context.Background(), frontend.ButtonClick{}, ""))
+	require.NoError(t, ui.Answer(context.Background(), frontend.ButtonClick{}, "test"))
+	require.Equal(t, "(test)\n", out.String())
+}
@@ -0,0 +1,128 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+)
+
+var ErrInvalidInput = errors.New("invalid input")
+
+const (
+	clickPrefix    = "!click"
+	locationPrefix = "@"
+	replyPrefix    = ">"
+)
+
+// Input is either a message or a click of a user.
+type Input struct {
+	Message *frontend.Message
+	Click   *frontend.ButtonClick
+}
+
+// Parse turns a line into an event of a user in a private chat:
+//
+//	/command arguments
+//	@latitude,longitude
+//	!click N clicks the N-th button of the last message with buttons
+//	> text replies to the last message which asks for a reply
+//	any other text
+//
+// An empty line is an empty input.
+func (f *Frontend) Parse(line string, user frontend.User) (Input, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return Input{}, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if arguments, ok := strings.CutPrefix(line, clickPrefix); ok {
+		click, err := f.getClick(strings.TrimSpace(arguments), user)
+		if err != nil {
+			return Input{}, err
+		}
+		return Input{Click: &click}, nil
+	}
+	f.lastID++
+	message := frontend.Message{
+		ID:   f.lastID,
+		Chat: frontend.Chat{ID: user.ID},
+		From: &user,
+	}
+	switch {
+	case strings.HasPrefix(line, "/"):
+		command, arguments, _ := strings.Cut(line[1:], " ")
+		message.Command, message.Text = command, strings.TrimSpace(arguments)
+	case strings.HasPrefix(line, locationPrefix):
+		location, err := parseLocation(line[len(locationPrefix):])
+		if err != nil {
+			return Input{}, err
+		}
+		message.Location = &location
+	case strings.HasPrefix(line, replyPrefix):
+		reply, ok := f.messages[f.replyID]
+		if !ok {
+			return Input{}, fmt.Errorf("no message asks for a reply: %w", ErrInvalidInput)
+		}
+		message.Text, message.ReplyTo = strings.TrimSpace(line[len(replyPrefix):]), &reply
+	default:
+		message.Text = line
+	}
+	return Input{Message: &message}, nil
+}
+
+func (f *Frontend) getClick(argument string, user frontend.User) (frontend.ButtonClick, error) {
+	number, err := strconv.Atoi(argument)
+	if err != nil || number < 1 {
+		return frontend.ButtonClick{}, fmt.Errorf(
+			"'%s' is not a button number: %w",
+			argument,
+			ErrInvalidInput,
+		)
+	}
+	message := f.messages[f.keyboardID]
+	for _, row := range message.Buttons {
+		if number > len(row) {
+			number -= len(row)
+			continue
+		}
+		button := row[number-1]
+		if button.Data == "" {
+			return frontend.ButtonClick{}, fmt.Errorf(
+				"the button %s opens %s: %w",
+				argument,
+				button.URL,
+				ErrInvalidInput,
+			)
+		}
+		click := frontend.ButtonClick{
+			ID:      strconv.Itoa(message.ID) + ":" + argument,
+			From:    &user,
+			Data:    button.Data,
+			Message: message,
+		}
+		return click, nil
+	}
+	return frontend.ButtonClick{}, fmt.Errorf(
+		"the last message has no button %s: %w",
+		argument,
+		ErrInvalidInput,
+	)
+}
+
+func parseLocation(coordinates string) (frontend.Location, error) {
+	latitude, longitude, ok := strings.Cut(coordinates, ",")
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if !ok || latErr != nil || lonErr != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return frontend.Location{}, fmt.Errorf(
+			"'%s' is not 'latitude,longitude': %w",
+			coordinates,
+			ErrInvalidInput,
+		)
+	}
+	return frontend.Location{Latitude: lat, Longitude: lon}, nil
+}
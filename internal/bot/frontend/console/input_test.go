@@ -0,0 +1,129 @@
+package console
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontend_Parse(t *testing.T) {
+	user := frontend.User{ID: 7}
+	chat := frontend.Chat{ID: 7}
+	tests := []struct {
+		name     string
+		line     string
+		expected Input
+	}{
+		{"an empty line", "  ", Input{}},
+		{
+			"a command",
+			"/addresses",
+			Input{Message: &frontend.Message{ID: 1, Chat: chat, From: &user, Command: "addresses"}},
+		},
+		{
+			"a command with arguments",
+			"/start building_12 ",
+			Input{Message: &frontend.Message{
+				ID:      1,
+				Chat:    chat,
+				From:    &user,
+				Command: "start",
+				Text:    "building_12",
+			}},
+		},
+		{
+			"a text",
+			"Lauttasaari",
+			Input{Message: &frontend.Message{ID: 1, Chat: chat, From: &user, Text: "Lauttasaari"}},
+		},
+		{
+			"a location",
+			"@60.16, 24.88",
+			Input{Message: &frontend.Message{
+				ID:       1,
+				Chat:     chat,
+				From:     &user,
+				Location: &frontend.Location{Latitude: 60.16, Longitude: 24.88},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := NewFrontend(io.Discard).Parse(tt.line, user)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, input)
+		})
+	}
+}
+
+func TestFrontend_Parse_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"a location without longitude", "@60.16"},
+		{"a location out of range", "@160.16,24.88"},
+		{"a click without a number", "!click next"},
+		{"a click of zero", "!click 0"},
+		{"a click without buttons", "!click 1"},
+		{"a reply without a request", "> test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFrontend(io.Discard).Parse(tt.line, frontend.User{ID: 7})
+			require.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestFrontend_Parse_click(t *testing.T) {
+	ui := NewFrontend(io.Discard)
+	rows := [][]frontend.Button{
+		{frontend.NewDataButton("first", "1")},
+		{frontend.NewDataButton("second", "2"), frontend.NewURLButton("Share", "https://t.me")},
+	}
+	ctx := context.Background()
+	require.NoError(t, ui.Send(ctx, 7, frontend.ButtonListView{Text: "test", Rows: rows}))
+	require.NoError(t, ui.Send(ctx, 7, frontend.TextView{Text: "no buttons"}))
+	user := frontend.User{ID: 7}
+
+	input, err := ui.Parse("!click 2", user)
+	require.NoError(t, err)
+	expected := frontend.ButtonClick{
+		ID:   "1:2",
+		From: &user,
+		Data: "2",
+		Message: frontend.Message{
+			ID:      1,
+			Chat:    frontend.Chat{ID: 7},
+			Text:    "test",
+			Buttons: rows,
+		},
+	}
+	require.Equal(t, Input{Click: &expected}, input)
+	_, err = ui.Parse("!click 3", user)
+	require.ErrorIs(t, err, ErrInvalidInput)
+	_, err = ui.Parse("!click 4", user)
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestFrontend_Parse_reply(t *testing.T) {
+	ui := NewFrontend(io.Discard)
+	view := frontend.TextView{Text: "Correction: Kalevankatu 1", ReplyRequest: true}
+	require.NoError(t, ui.Send(context.Background(), 7, view))
+	user := frontend.User{ID: 7}
+
+	input, err := ui.Parse("> a new address", user)
+	require.NoError(t, err)
+	expected := frontend.Message{
+		ID:      2,
+		Chat:    frontend.Chat{ID: 7},
+		From:    &user,
+		Text:    "a new address",
+		ReplyTo: &frontend.Message{ID: 1, Chat: frontend.Chat{ID: 7}, Text: "Correction: Kalevankatu 1"},
+	}
+	require.Equal(t, Input{Message: &expected}, input)
+}
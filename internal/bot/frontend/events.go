@@ -0,0 +1,78 @@
+// Package frontend describes what a user sends to the guide and what the guide
+// shows in return without a reference to a particular messenger.
+package frontend
+
+// EventKind is a kind of a message. A click on a button is a separate event,
+// see ButtonClick.
+type EventKind string
+
+const (
+	TextEvent     EventKind = "text"
+	CommandEvent  EventKind = "command"
+	LocationEvent EventKind = "location"
+	PhotoEvent    EventKind = "photo"
+)
+
+type Chat struct {
+	ID int64
+	// IsGroup is true for chats of many users
+	IsGroup bool
+}
+
+type User struct {
+	ID int64
+	// LanguageCode is a language of a messenger interface, for example, "fi"
+	LanguageCode string
+	FirstName    string
+	LastName     string
+	UserName     string
+}
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Photo struct {
+	FileID string
+	// FileUniqueID is the same for copies of a photo
+	FileUniqueID string
+}
+
+// Message is a text, a command, a location or a photo a user sends.
+// It also describes a message of the bot a user replies to or clicks.
+type Message struct {
+	ID   int
+	Chat Chat
+	From *User
+	// Text is a caption of a photo and arguments of a command
+	Text string
+	// Command is a command name without a slash
+	Command  string
+	Location *Location
+	Photo    *Photo
+	ReplyTo  *Message
+	// Buttons are rows of buttons of a message of the bot
+	Buttons [][]Button
+}
+
+func (m Message) Kind() EventKind {
+	switch {
+	case m.Location != nil:
+		return LocationEvent
+	case m.Photo != nil:
+		return PhotoEvent
+	case m.Command != "":
+		return CommandEvent
+	}
+	return TextEvent
+}
+
+// ButtonClick is a click on a button of a message of the bot.
+type ButtonClick struct {
+	ID   string
+	From *User
+	// Data is data of a clicked button
+	Data    string
+	Message Message
+}
@@ -0,0 +1,25 @@
+package frontend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Kind(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  Message
+		expected EventKind
+	}{
+		{"a text", Message{Text: "Kuusikallionkuja"}, TextEvent},
+		{"a command", Message{Command: "start", Text: "b_12"}, CommandEvent},
+		{"a location", Message{Location: &Location{Latitude: 60.17}}, LocationEvent},
+		{"a photo", Message{Photo: &Photo{FileID: "file"}, Text: "© Test"}, PhotoEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.message.Kind())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package telegram
+
+import (
+	c "context"
+	"fmt"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/middlewares"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type InternalBot interface {
+	Request(tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
+	Send(tgbotapi.Chattable) (tgbotapi.Message, error)
+	GetUpdates(tgbotapi.UpdateConfig) ([]tgbotapi.Update, error)
+}
+
+// contextBot relates outgoing requests to a handled update.
+type contextBot interface {
+	WithContext(c.Context) InternalBot
+}
+
+func withContext(bot InternalBot, ctx c.Context) InternalBot {
+	if bot, ok := bot.(contextBot); ok {
+		return bot.WithContext(ctx)
+	}
+	return bot
+}
+
+type BotWithMetrics struct {
+	clientName string
+	*tgbotapi.BotAPI
+	m *metrics.Metrics
+	// ctx is a context of a handled update
+	ctx c.Context
+}
+
+func NewBotWithMetrics(bot *tgbotapi.BotAPI, m *metrics.Metrics) *BotWithMetrics {
+	return &BotWithMetrics{"Telegram", bot, m, c.Background()}
+}
+
+// WithContext returns a bot which adds spans of requests to a context.
+func (b *BotWithMetrics) WithContext(ctx c.Context) InternalBot {
+	bot := *b
+	bot.ctx = ctx
+	return &bot
+}
+
+func (b *BotWithMetrics) startSpan(method string, chattable tgbotapi.Chattable) trace.Span {
+	_, span := tracing.Start(
+		b.ctx,
+		"telegram "+method,
+		attribute.String("telegram.request", fmt.Sprintf("%T", chattable)),
+	)
+	return span
+}
+
+func (b *BotWithMetrics) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	span := b.startSpan("Send", c)
+	result, err := middlewares.Duration(
+		func() (interface{}, error) { return b.BotAPI.Send(c) },
+		b.m,
+		b.clientName,
+		"Send",
+	)
+	tracing.End(span, err)
+	message := result.(tgbotapi.Message)
+	return message, err
+}
+
+func (b *BotWithMetrics) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	span := b.startSpan("Request", c)
+	result, err := middlewares.Duration(
+		func() (interface{}, error) { return b.BotAPI.Request(c) },
+		b.m,
+		b.clientName,
+		"Request",
+	)
+	tracing.End(span, err)
+	response := result.(*tgbotapi.APIResponse)
+	return response, err
+}
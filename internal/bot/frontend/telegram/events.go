@@ -0,0 +1,154 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var ErrNoMessage = errors.New("a callback has no message")
+
+// NewMessage returns an event of a message with a chat. A text of a command
+// contains only command arguments.
+func NewMessage(message *tgbotapi.Message) frontend.Message {
+	event := frontend.Message{
+		ID:      message.MessageID,
+		Chat:    getChat(message.Chat),
+		From:    getUser(message.From),
+		Text:    message.Text,
+		Command: message.Command(),
+		Buttons: getButtons(message.ReplyMarkup),
+	}
+	if event.Command != "" {
+		event.Text = message.CommandArguments()
+	}
+	// Telegram sends a caption of a photo or a document instead of a text
+	if message.Caption != "" {
+		event.Text = message.Caption
+	}
+	if message.Location != nil {
+		event.Location = &frontend.Location{
+			Latitude:  message.Location.Latitude,
+			Longitude: message.Location.Longitude,
+		}
+	}
+	if len(message.Photo) > 0 {
+		// Telegram sorts photo sizes in ascending order
+		largestPhoto := message.Photo[len(message.Photo)-1]
+		event.Photo = &frontend.Photo{
+			FileID:       largestPhoto.FileID,
+			FileUniqueID: largestPhoto.FileUniqueID,
+		}
+	}
+	if message.ReplyToMessage != nil {
+		reply := NewMessage(message.ReplyToMessage)
+		event.ReplyTo = &reply
+	}
+	return event
+}
+
+// NewButtonClick returns an event of a click on a button of a message
+// in a chat. Buttons of inline messages do not belong to a chat.
+func NewButtonClick(query *tgbotapi.CallbackQuery) (frontend.ButtonClick, error) {
+	if query.Message == nil || query.Message.Chat == nil {
+		return frontend.ButtonClick{}, fmt.Errorf("%w: %v", ErrNoMessage, query.ID)
+	}
+	click := frontend.ButtonClick{
+		ID:      query.ID,
+		From:    getUser(query.From),
+		Data:    query.Data,
+		Message: NewMessage(query.Message),
+	}
+	return click, nil
+}
+
+func getChat(chat *tgbotapi.Chat) frontend.Chat {
+	if chat == nil {
+		return frontend.Chat{}
+	}
+	return frontend.Chat{ID: chat.ID, IsGroup: isGroup(chat)}
+}
+
+func getUser(user *tgbotapi.User) *frontend.User {
+	if user == nil {
+		return nil
+	}
+	return &frontend.User{
+		ID:           user.ID,
+		LanguageCode: user.LanguageCode,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		UserName:     user.UserName,
+	}
+}
+
+func getButtons(markup *tgbotapi.InlineKeyboardMarkup) [][]frontend.Button {
+	if markup == nil {
+		return nil
+	}
+	rows := make([][]frontend.Button, len(markup.InlineKeyboard))
+	for i, row := range markup.InlineKeyboard {
+		rows[i] = make([]frontend.Button, len(row))
+		for j, button := range row {
+			rows[i][j].Label = button.Text
+			if button.CallbackData != nil {
+				rows[i][j].Data = *button.CallbackData
+			}
+			if button.URL != nil {
+				rows[i][j].URL = *button.URL
+			}
+		}
+	}
+	return rows
+}
+
+func isGroup(chat *tgbotapi.Chat) bool {
+	return chat != nil && (chat.IsGroup() || chat.IsSuperGroup())
+}
+
+// FilterGroupMessage reports whether the bot should answer a message.
+// In group chats the bot answers only to commands addressed to it,
+// mentions and replies to its messages. The function removes a mention
+// from a text, so the rest of the text can be processed as an address.
+func FilterGroupMessage(
+	message *tgbotapi.Message,
+	botUser tgbotapi.User,
+) (*tgbotapi.Message, bool) {
+	if !isGroup(message.Chat) {
+		return message, true
+	}
+	if message.IsCommand() {
+		_, botName, found := strings.Cut(message.CommandWithAt(), "@")
+		return message, !found || strings.EqualFold(botName, botUser.UserName)
+	}
+	reply := message.ReplyToMessage
+	if reply != nil && reply.From != nil && reply.From.ID == botUser.ID {
+		return message, true
+	}
+	text := utf16.Encode([]rune(message.Text))
+	for _, entity := range message.Entities {
+		end := entity.Offset + entity.Length
+		if entity.Type != "mention" || entity.Offset < 0 || end > len(text) {
+			continue
+		}
+		mention := string(utf16.Decode(text[entity.Offset:end]))
+		if !strings.EqualFold(mention, "@"+botUser.UserName) {
+			continue
+		}
+		filtered := *message
+		filtered.Text = strings.Join(
+			strings.Fields(
+				string(utf16.Decode(text[:entity.Offset]))+" "+
+					string(utf16.Decode(text[end:])),
+			),
+			" ",
+		)
+		filtered.Entities = nil
+		return &filtered, true
+	}
+	return message, false
+}
@@ -0,0 +1,222 @@
+package telegram
+
+import (
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessage(t *testing.T) {
+	private := &tgbotapi.Chat{ID: 5, Type: "private"}
+	user := &tgbotapi.User{ID: 5, LanguageCode: "fi", FirstName: "Test"}
+	buildingButton := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("Report a mistake", `{"name":"report","id":"12"}`),
+			tgbotapi.NewInlineKeyboardButtonURL("Share", "https://t.me/share"),
+		),
+	)
+	tests := []struct {
+		name     string
+		message  *tgbotapi.Message
+		expected frontend.Message
+	}{
+		{
+			"a text",
+			&tgbotapi.Message{MessageID: 7, Chat: private, From: user, Text: "Kuusikallionkuja"},
+			frontend.Message{
+				ID:   7,
+				Chat: frontend.Chat{ID: 5},
+				From: &frontend.User{ID: 5, LanguageCode: "fi", FirstName: "Test"},
+				Text: "Kuusikallionkuja",
+			},
+		},
+		{
+			"a command",
+			&tgbotapi.Message{
+				Chat:     &tgbotapi.Chat{ID: -100, Type: "supergroup"},
+				Text:     "/start@HelsinkiGuide_bot b_12",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 24}},
+			},
+			frontend.Message{
+				Chat:    frontend.Chat{ID: -100, IsGroup: true},
+				Text:    "b_12",
+				Command: "start",
+			},
+		},
+		{
+			"a photo with a caption",
+			&tgbotapi.Message{
+				Chat:    private,
+				Caption: "© Test",
+				Photo: []tgbotapi.PhotoSize{
+					{FileID: "small", FileUniqueID: "small-unique"},
+					{FileID: "large", FileUniqueID: "large-unique"},
+				},
+				ReplyToMessage: &tgbotapi.Message{
+					MessageID:   3,
+					Chat:        private,
+					ReplyMarkup: &buildingButton,
+				},
+			},
+			frontend.Message{
+				Chat:  frontend.Chat{ID: 5},
+				Text:  "© Test",
+				Photo: &frontend.Photo{FileID: "large", FileUniqueID: "large-unique"},
+				ReplyTo: &frontend.Message{
+					ID:   3,
+					Chat: frontend.Chat{ID: 5},
+					Buttons: [][]frontend.Button{{
+						frontend.NewDataButton("Report a mistake", `{"name":"report","id":"12"}`),
+						frontend.NewURLButton("Share", "https://t.me/share"),
+					}},
+				},
+			},
+		},
+		{
+			"a location",
+			&tgbotapi.Message{
+				Chat:     private,
+				Location: &tgbotapi.Location{Latitude: 60.17, Longitude: 24.94},
+			},
+			frontend.Message{
+				Chat:     frontend.Chat{ID: 5},
+				Location: &frontend.Location{Latitude: 60.17, Longitude: 24.94},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NewMessage(tt.message))
+		})
+	}
+}
+
+func TestNewButtonClick(t *testing.T) {
+	query := &tgbotapi.CallbackQuery{
+		ID:   "123",
+		From: &tgbotapi.User{ID: 5},
+		Message: &tgbotapi.Message{
+			MessageID: 44,
+			Chat:      &tgbotapi.Chat{ID: 99, Type: "private"},
+		},
+		Data: `{"name":"building","id":"12"}`,
+	}
+	click, err := NewButtonClick(query)
+	require.NoError(t, err)
+	expected := frontend.ButtonClick{
+		ID:      "123",
+		From:    &frontend.User{ID: 5},
+		Data:    `{"name":"building","id":"12"}`,
+		Message: frontend.Message{ID: 44, Chat: frontend.Chat{ID: 99}},
+	}
+	require.Equal(t, expected, click)
+
+	// a button of an inline message has no chat
+	_, err = NewButtonClick(&tgbotapi.CallbackQuery{ID: "123"})
+	require.ErrorIs(t, err, ErrNoMessage)
+	_, err = NewButtonClick(&tgbotapi.CallbackQuery{ID: "123", Message: &tgbotapi.Message{}})
+	require.ErrorIs(t, err, ErrNoMessage)
+}
+
+func TestFilterGroupMessage(t *testing.T) {
+	botUser := tgbotapi.User{ID: 1, UserName: "HelsinkiGuide_bot"}
+	group := &tgbotapi.Chat{ID: -100, Type: "supergroup"}
+	tests := []struct {
+		name         string
+		message      *tgbotapi.Message
+		expectedText string
+		expectedOk   bool
+	}{
+		{
+			"private chat",
+			&tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 5, Type: "private"}, Text: "Kuusikallionkuja"},
+			"Kuusikallionkuja",
+			true,
+		},
+		{
+			"group message",
+			&tgbotapi.Message{Chat: group, Text: "Kuusikallionkuja"},
+			"Kuusikallionkuja",
+			false,
+		},
+		{
+			"command without a bot name",
+			&tgbotapi.Message{
+				Chat:     group,
+				Text:     "/help",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 5}},
+			},
+			"/help",
+			true,
+		},
+		{
+			"command for the bot",
+			&tgbotapi.Message{
+				Chat:     group,
+				Text:     "/help@helsinkiguide_bot",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 23}},
+			},
+			"/help@helsinkiguide_bot",
+			true,
+		},
+		{
+			"command for another bot",
+			&tgbotapi.Message{
+				Chat:     group,
+				Text:     "/help@other_bot",
+				Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Length: 15}},
+			},
+			"/help@other_bot",
+			false,
+		},
+		{
+			"reply to the bot",
+			&tgbotapi.Message{
+				Chat:           group,
+				Text:           "1931",
+				ReplyToMessage: &tgbotapi.Message{From: &botUser},
+			},
+			"1931",
+			true,
+		},
+		{
+			"reply to another user",
+			&tgbotapi.Message{
+				Chat:           group,
+				Text:           "1931",
+				ReplyToMessage: &tgbotapi.Message{From: &tgbotapi.User{ID: 2}},
+			},
+			"1931",
+			false,
+		},
+		{
+			"mention",
+			&tgbotapi.Message{
+				Chat:     group,
+				Text:     "ёж @HelsinkiGuide_bot Kuusikallionkuja",
+				Entities: []tgbotapi.MessageEntity{{Type: "mention", Offset: 3, Length: 18}},
+			},
+			"ёж Kuusikallionkuja",
+			true,
+		},
+		{
+			"mention of another user",
+			&tgbotapi.Message{
+				Chat:     group,
+				Text:     "@someone Kuusikallionkuja",
+				Entities: []tgbotapi.MessageEntity{{Type: "mention", Length: 8}},
+			},
+			"@someone Kuusikallionkuja",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FilterGroupMessage(tt.message, botUser)
+			require.Equal(t, tt.expectedOk, ok)
+			require.Equal(t, tt.expectedText, got.Text)
+		})
+	}
+}
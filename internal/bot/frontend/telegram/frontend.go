@@ -0,0 +1,167 @@
+// Package telegram shows views of the guide in Telegram and turns
+// Telegram updates into events of the guide.
+package telegram
+
+import (
+	c "context"
+	"fmt"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Frontend sends views through the Bot API.
+type Frontend struct {
+	bot InternalBot
+}
+
+func NewFrontend(bot InternalBot) *Frontend {
+	return &Frontend{bot}
+}
+
+func (f *Frontend) Send(ctx c.Context, chatID int64, view frontend.View) error {
+	bot := withContext(f.bot, ctx)
+	album, ok := view.(frontend.AlbumView)
+	// a media group should contain at least two items
+	if ok && len(album.Photos) == 1 {
+		view = album.Photos[0]
+	} else if ok {
+		_, err := bot.Request(getMediaGroup(chatID, album))
+		return err
+	}
+	chattable, err := getChattable(chatID, view)
+	if err != nil {
+		return err
+	}
+	_, err = bot.Send(chattable)
+	return err
+}
+
+func (f *Frontend) Edit(
+	ctx c.Context,
+	chatID int64,
+	messageID int,
+	view frontend.View,
+) error {
+	var edit tgbotapi.Chattable
+	switch v := view.(type) {
+	case frontend.TextView:
+		edit = getTextEdit(chatID, messageID, v.Text, v.Format, nil)
+	case frontend.ButtonListView:
+		edit = getTextEdit(chatID, messageID, v.Text, v.Format, v.Rows)
+	case frontend.PhotoView:
+		captionEdit := tgbotapi.NewEditMessageCaption(chatID, messageID, v.Caption)
+		markup := getInlineKeyboard(v.Rows)
+		captionEdit.ReplyMarkup = &markup
+		edit = captionEdit
+	default:
+		return fmt.Errorf("can not edit a message with %T: %w", view, frontend.ErrUnsupportedView)
+	}
+	_, err := withContext(f.bot, ctx).Send(edit)
+	return err
+}
+
+// Answer is required because Telegram shows a button in a loading state
+// until a bot answers a click.
+func (f *Frontend) Answer(ctx c.Context, click frontend.ButtonClick, text string) error {
+	_, err := withContext(f.bot, ctx).Request(tgbotapi.NewCallback(click.ID, text))
+	return err
+}
+
+func getChattable(chatID int64, view frontend.View) (tgbotapi.Chattable, error) {
+	switch v := view.(type) {
+	case frontend.TextView:
+		msg := tgbotapi.NewMessage(chatID, v.Text)
+		msg.ParseMode = getParseMode(v.Format)
+		if v.ReplyRequest {
+			msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true, Selective: true}
+		}
+		if v.LocationRequest != "" {
+			msg.ReplyMarkup = tgbotapi.NewOneTimeReplyKeyboard(
+				[]tgbotapi.KeyboardButton{
+					tgbotapi.NewKeyboardButtonLocation(v.LocationRequest),
+				},
+			)
+		}
+		return msg, nil
+	case frontend.ButtonListView:
+		msg := tgbotapi.NewMessage(chatID, v.Text)
+		msg.ParseMode = getParseMode(v.Format)
+		if len(v.Rows) > 0 {
+			msg.ReplyMarkup = getInlineKeyboard(v.Rows)
+		}
+		return msg, nil
+	case frontend.LocationView:
+		if v.Title == "" {
+			return tgbotapi.NewLocation(chatID, v.Latitude, v.Longitude), nil
+		}
+		return tgbotapi.NewVenue(chatID, v.Title, v.Address, v.Latitude, v.Longitude), nil
+	case frontend.PhotoView:
+		msg := tgbotapi.NewPhoto(chatID, tgbotapi.FileID(v.FileID))
+		msg.Caption = v.Caption
+		if len(v.Rows) > 0 {
+			msg.ReplyMarkup = getInlineKeyboard(v.Rows)
+		}
+		return msg, nil
+	case frontend.DocumentView:
+		document := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+			Name:  v.Name,
+			Bytes: v.Content,
+		})
+		document.Caption = v.Caption
+		return document, nil
+	}
+	return nil, fmt.Errorf("can not send %T: %w", view, frontend.ErrUnsupportedView)
+}
+
+func getParseMode(format frontend.Format) string {
+	if format == frontend.HTML {
+		return tgbotapi.ModeHTML
+	}
+	return ""
+}
+
+func getMediaGroup(chatID int64, album frontend.AlbumView) tgbotapi.MediaGroupConfig {
+	media := make([]any, len(album.Photos))
+	for i, photo := range album.Photos {
+		item := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID(photo.FileID))
+		item.Caption = photo.Caption
+		media[i] = item
+	}
+	return tgbotapi.NewMediaGroup(chatID, media)
+}
+
+// getTextEdit removes buttons of a message if a view has no buttons.
+func getTextEdit(
+	chatID int64,
+	messageID int,
+	text string,
+	format frontend.Format,
+	rows [][]frontend.Button,
+) tgbotapi.EditMessageTextConfig {
+	edit := tgbotapi.NewEditMessageTextAndMarkup(
+		chatID,
+		messageID,
+		text,
+		getInlineKeyboard(rows),
+	)
+	edit.ParseMode = getParseMode(format)
+	return edit
+}
+
+func getInlineKeyboard(rows [][]frontend.Button) tgbotapi.InlineKeyboardMarkup {
+	// the Bot API rejects a null keyboard
+	keyboard := [][]tgbotapi.InlineKeyboardButton{}
+	for _, row := range rows {
+		keyboardRow := make([]tgbotapi.InlineKeyboardButton, len(row))
+		for i, button := range row {
+			if button.URL != "" {
+				keyboardRow[i] = tgbotapi.NewInlineKeyboardButtonURL(button.Label, button.URL)
+				continue
+			}
+			keyboardRow[i] = tgbotapi.NewInlineKeyboardButtonData(button.Label, button.Data)
+		}
+		keyboard = append(keyboard, keyboardRow)
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
@@ -0,0 +1,220 @@
+package telegram
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing/tracingtest"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontend_Send(t *testing.T) {
+	rows := [][]frontend.Button{{
+		frontend.NewDataButton("test 1", `{"name":"building","id":"1"}`),
+		frontend.NewURLButton("Share", "https://t.me/share"),
+	}}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("test 1", `{"name":"building","id":"1"}`),
+			tgbotapi.NewInlineKeyboardButtonURL("Share", "https://t.me/share"),
+		),
+	)
+	htmlMessage := tgbotapi.NewMessage(99, "<b>test</b>")
+	htmlMessage.ParseMode = tgbotapi.ModeHTML
+	replyRequest := tgbotapi.NewMessage(99, "test")
+	replyRequest.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true, Selective: true}
+	locationRequest := tgbotapi.NewMessage(99, "test")
+	locationRequest.ReplyMarkup = tgbotapi.NewOneTimeReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonLocation("Share")),
+	)
+	buttonList := tgbotapi.NewMessage(99, "test")
+	buttonList.ReplyMarkup = keyboard
+	photo := tgbotapi.NewPhoto(99, tgbotapi.FileID("file"))
+	photo.Caption = "test"
+	document := tgbotapi.NewDocument(99, tgbotapi.FileBytes{Name: "test.gpx", Bytes: []byte("test")})
+	document.Caption = "test"
+	tests := []struct {
+		name     string
+		view     frontend.View
+		expected tgbotapi.Chattable
+	}{
+		{
+			"a text",
+			frontend.TextView{Text: "<b>test</b>", Format: frontend.HTML},
+			htmlMessage,
+		},
+		{
+			"a reply request",
+			frontend.TextView{Text: "test", ReplyRequest: true},
+			replyRequest,
+		},
+		{
+			"a location request",
+			frontend.TextView{Text: "test", LocationRequest: "Share"},
+			locationRequest,
+		},
+		{
+			"a button list",
+			frontend.ButtonListView{Text: "test", Rows: rows},
+			buttonList,
+		},
+		{
+			"an empty button list",
+			frontend.ButtonListView{Text: "test"},
+			tgbotapi.NewMessage(99, "test"),
+		},
+		{
+			"a location",
+			frontend.LocationView{Location: frontend.Location{Latitude: 60.17, Longitude: 24.94}},
+			tgbotapi.NewLocation(99, 60.17, 24.94),
+		},
+		{
+			"a venue",
+			frontend.LocationView{
+				Location: frontend.Location{Latitude: 60.17, Longitude: 24.94},
+				Title:    "test",
+				Address:  "test address",
+			},
+			tgbotapi.NewVenue(99, "test", "test address", 60.17, 24.94),
+		},
+		{
+			"a photo",
+			frontend.PhotoView{FileID: "file", Caption: "test"},
+			photo,
+		},
+		{
+			"an album of one photo",
+			frontend.AlbumView{Photos: []frontend.PhotoView{{FileID: "file", Caption: "test"}}},
+			photo,
+		},
+		{
+			"a document",
+			frontend.DocumentView{Name: "test.gpx", Content: []byte("test"), Caption: "test"},
+			document,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			botMock := NewInternalBot_mock(t)
+			botMock.EXPECT().Send(tt.expected).Return(tgbotapi.Message{}, nil)
+			err := NewFrontend(botMock).Send(context.Background(), 99, tt.view)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFrontend_Send_album(t *testing.T) {
+	first := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("first"))
+	first.Caption = "© first"
+	second := tgbotapi.NewInputMediaPhoto(tgbotapi.FileID("second"))
+	second.Caption = "© second"
+	botMock := NewInternalBot_mock(t)
+	botMock.EXPECT().
+		Request(tgbotapi.NewMediaGroup(99, []any{first, second})).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+	album := frontend.AlbumView{Photos: []frontend.PhotoView{
+		{FileID: "first", Caption: "© first"},
+		{FileID: "second", Caption: "© second"},
+	}}
+	err := NewFrontend(botMock).Send(context.Background(), 99, album)
+	require.NoError(t, err)
+}
+
+func TestFrontend_Edit(t *testing.T) {
+	emptyKeyboard := tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	}
+	captionEdit := tgbotapi.NewEditMessageCaption(99, 44, "test")
+	captionEdit.ReplyMarkup = &emptyKeyboard
+	htmlEdit := tgbotapi.NewEditMessageTextAndMarkup(
+		99,
+		44,
+		"<b>test</b>",
+		tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("test", "data")),
+		),
+	)
+	htmlEdit.ParseMode = tgbotapi.ModeHTML
+	tests := []struct {
+		name     string
+		view     frontend.View
+		expected tgbotapi.Chattable
+	}{
+		{
+			"a text removes buttons",
+			frontend.TextView{Text: "test"},
+			tgbotapi.NewEditMessageTextAndMarkup(99, 44, "test", emptyKeyboard),
+		},
+		{
+			"a button list",
+			frontend.ButtonListView{
+				Text:   "<b>test</b>",
+				Format: frontend.HTML,
+				Rows:   [][]frontend.Button{{frontend.NewDataButton("test", "data")}},
+			},
+			htmlEdit,
+		},
+		{
+			"a photo caption",
+			frontend.PhotoView{Caption: "test"},
+			captionEdit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			botMock := NewInternalBot_mock(t)
+			botMock.EXPECT().Send(tt.expected).Return(tgbotapi.Message{}, nil)
+			err := NewFrontend(botMock).Edit(context.Background(), 99, 44, tt.view)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFrontend_Edit_unsupportedView(t *testing.T) {
+	ui := NewFrontend(NewInternalBot_mock(t))
+	view := frontend.LocationView{Location: frontend.Location{Latitude: 60.17}}
+	err := ui.Edit(context.Background(), 99, 44, view)
+	require.ErrorIs(t, err, frontend.ErrUnsupportedView)
+}
+
+func TestFrontend_Answer(t *testing.T) {
+	botMock := NewInternalBot_mock(t)
+	botMock.EXPECT().
+		Request(tgbotapi.NewCallback("123", "test")).
+		Return(&tgbotapi.APIResponse{Ok: true}, nil)
+	click := frontend.ButtonClick{ID: "123", Data: "data"}
+	err := NewFrontend(botMock).Answer(context.Background(), click, "test")
+	require.NoError(t, err)
+}
+
+func getTestBotAPI(t *testing.T) *tgbotapi.BotAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the result suits both getMe and sendMessage
+		w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"message_id":2}}`))
+	}))
+	t.Cleanup(server.Close)
+	api, err := tgbotapi.NewBotAPIWithClient("token", server.URL+"/bot%s/%s", server.Client())
+	require.NoError(t, err)
+	return api
+}
+
+func TestFrontend_Send_trace(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	ui := NewFrontend(NewBotWithMetrics(getTestBotAPI(t), m))
+	exporter := tracingtest.SetExporter(t)
+	ctx, span := tracing.Start(context.Background(), "handler test")
+
+	err := ui.Send(ctx, 99, frontend.TextView{Text: "test"})
+	require.NoError(t, err)
+	span.End()
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"telegram Send", "handler test"}, tracingtest.GetSpanNames(exporter))
+	require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
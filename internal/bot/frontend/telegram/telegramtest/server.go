@@ -0,0 +1,319 @@
+// Package telegramtest runs a fake Bot API server for end-to-end tests.
+// Tests inject updates of users and check requests of a bot.
+package telegramtest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Token is the only token the server accepts
+	Token = "123456:test-token"
+	// WaitTimeout limits a wait for requests of a bot
+	WaitTimeout = 5 * time.Second
+	// maxUploadSize is kept in memory, the server does not store files
+	maxUploadSize = 1 << 20
+)
+
+// Bot is a user of a bot which the server authorizes by Token.
+var Bot = tgbotapi.User{ID: 123456, IsBot: true, FirstName: "Guide", UserName: "test_guide_bot"}
+
+// Call is a request of a bot except getUpdates.
+type Call struct {
+	Method string
+	Params url.Values
+	// MessageID is a sent or edited message, it is zero for other methods
+	MessageID int
+}
+
+// Server answers getMe, getUpdates, methods which send and edit messages,
+// answerCallbackQuery and setMyCommands. Other methods fail with 404
+// like unknown methods of the Bot API.
+type Server struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	// updates are not confirmed by an offset of getUpdates
+	updates      []tgbotapi.Update
+	lastUpdateID int
+	// messages are sent by the bot, users can click their buttons
+	messages      map[int]tgbotapi.Message
+	lastMessageID int
+	calls         []Call
+	// changed is closed and replaced after every change
+	changed chan struct{}
+	closed  chan struct{}
+}
+
+// NewServer starts a server which stops at the end of a test.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		messages: map[int]tgbotapi.Message{},
+		changed:  make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.server.Close)
+	// release long polling requests before the server waits for them
+	t.Cleanup(func() { close(s.closed) })
+	return s
+}
+
+// Endpoint is a format of Bot API URLs for tgbotapi and BOT_API_ENDPOINT.
+func (s *Server) Endpoint() string {
+	return s.server.URL + "/bot%s/%s"
+}
+
+// AddUpdate sets an ID of an update and returns it to the next getUpdates.
+func (s *Server) AddUpdate(update tgbotapi.Update) tgbotapi.Update {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUpdateID++
+	update.UpdateID = s.lastUpdateID
+	s.updates = append(s.updates, update)
+	s.notify()
+	return update
+}
+
+// SendMessage adds a message of a user in a private chat. A text which starts
+// with a slash is a command.
+func (s *Server) SendMessage(user tgbotapi.User, text string) tgbotapi.Message {
+	s.mu.Lock()
+	s.lastMessageID++
+	message := tgbotapi.Message{
+		MessageID: s.lastMessageID,
+		From:      &user,
+		Date:      int(time.Now().Unix()),
+		Chat:      &tgbotapi.Chat{ID: user.ID, Type: "private"},
+		Text:      text,
+	}
+	s.mu.Unlock()
+	if strings.HasPrefix(text, "/") {
+		command, _, _ := strings.Cut(text, " ")
+		message.Entities = []tgbotapi.MessageEntity{
+			{Type: "bot_command", Offset: 0, Length: len(command)},
+		}
+	}
+	s.AddUpdate(tgbotapi.Update{Message: &message})
+	return message
+}
+
+// Click adds a click of a user on a button of a message the bot has sent.
+func (s *Server) Click(t testing.TB, user tgbotapi.User, messageID int, data string) {
+	s.mu.Lock()
+	message, ok := s.messages[messageID]
+	s.mu.Unlock()
+	require.Truef(t, ok, "the bot has not sent a message %v", messageID)
+	s.AddUpdate(tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+		ID:      strconv.Itoa(messageID) + ":" + data,
+		From:    &user,
+		Message: &message,
+		Data:    data,
+	}})
+}
+
+// GetMessage returns a message of the bot with its last edit.
+func (s *Server) GetMessage(t testing.TB, messageID int) tgbotapi.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	message, ok := s.messages[messageID]
+	require.Truef(t, ok, "the bot has not sent a message %v", messageID)
+	return message
+}
+
+// GetCalls returns calls of a method in the order of requests.
+func (s *Server) GetCalls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.getCalls(method)
+}
+
+// WaitForCalls waits for a number of calls of a method and returns all
+// calls of the method. A test fails after WaitTimeout.
+func (s *Server) WaitForCalls(t testing.TB, method string, number int) []Call {
+	timeout := time.NewTimer(WaitTimeout)
+	defer timeout.Stop()
+	for {
+		s.mu.Lock()
+		calls, changed := s.getCalls(method), s.changed
+		s.mu.Unlock()
+		if len(calls) >= number {
+			return calls
+		}
+		select {
+		case <-changed:
+		case <-timeout.C:
+			require.FailNowf(
+				t,
+				"not enough calls",
+				"expected %v calls of %v, got %v",
+				number,
+				method,
+				len(calls),
+			)
+		}
+	}
+}
+
+func (s *Server) getCalls(method string) []Call {
+	calls := []Call{}
+	for _, call := range s.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// notify wakes up waiting requests and tests, the caller holds the lock.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	token, method, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/bot"), "/")
+	if !ok || token != Token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	err := r.ParseMultipartForm(maxUploadSize)
+	if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+	if method == "getUpdates" {
+		writeResult(w, s.getUpdates(r))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	call := Call{Method: method, Params: r.Form}
+	defer func() {
+		s.calls = append(s.calls, call)
+		s.notify()
+	}()
+	switch method {
+	case "getMe":
+		writeResult(w, Bot)
+	case "sendMessage", "sendPhoto", "sendLocation", "sendVenue", "sendDocument":
+		s.lastMessageID++
+		message := tgbotapi.Message{MessageID: s.lastMessageID}
+		if err := s.setMessage(&message, r.Form); err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+			return
+		}
+		call.MessageID = message.MessageID
+		writeResult(w, message)
+	case "editMessageText", "editMessageCaption", "editMessageReplyMarkup":
+		messageID, _ := strconv.Atoi(r.Form.Get("message_id"))
+		message, ok := s.messages[messageID]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Bad Request: message to edit not found")
+			return
+		}
+		// an edit without a reply markup removes buttons
+		message.ReplyMarkup = nil
+		if err := s.setMessage(&message, r.Form); err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+			return
+		}
+		call.MessageID = message.MessageID
+		writeResult(w, message)
+	case "answerCallbackQuery", "setMyCommands":
+		writeResult(w, true)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// setMessage applies parameters of a sent or edited message and saves it.
+func (s *Server) setMessage(message *tgbotapi.Message, params url.Values) error {
+	chatID, err := strconv.ParseInt(params.Get("chat_id"), 10, 64)
+	if err != nil {
+		return errors.New("invalid chat_id")
+	}
+	message.Chat = &tgbotapi.Chat{ID: chatID, Type: "private"}
+	message.From = &Bot
+	message.Date = int(time.Now().Unix())
+	if params.Has("text") {
+		message.Text = params.Get("text")
+	}
+	if params.Has("caption") {
+		message.Caption = params.Get("caption")
+	}
+	if params.Has("reply_markup") {
+		var markup tgbotapi.InlineKeyboardMarkup
+		if err := json.Unmarshal([]byte(params.Get("reply_markup")), &markup); err != nil {
+			return errors.New("invalid reply_markup")
+		}
+		// a reply markup can be a reply keyboard or a reply request
+		if len(markup.InlineKeyboard) > 0 {
+			message.ReplyMarkup = &markup
+		}
+	}
+	s.messages[message.MessageID] = *message
+	return nil
+}
+
+// getUpdates confirms updates before an offset and waits for new updates
+// as long as a timeout of a request allows.
+func (s *Server) getUpdates(r *http.Request) []tgbotapi.Update {
+	offset, _ := strconv.Atoi(r.Form.Get("offset"))
+	timeoutSeconds, _ := strconv.Atoi(r.Form.Get("timeout"))
+	timeout := time.NewTimer(time.Duration(timeoutSeconds) * time.Second)
+	defer timeout.Stop()
+	for {
+		s.mu.Lock()
+		pending := []tgbotapi.Update{}
+		for _, update := range s.updates {
+			if update.UpdateID >= offset {
+				pending = append(pending, update)
+			}
+		}
+		s.updates = pending
+		changed := s.changed
+		s.mu.Unlock()
+		if len(pending) > 0 {
+			return pending
+		}
+		select {
+		case <-changed:
+		case <-timeout.C:
+			return pending
+		case <-r.Context().Done():
+			return pending
+		case <-s.closed:
+			return pending
+		}
+	}
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	writeResponse(w, http.StatusOK, map[string]any{"ok": true, "result": result})
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeResponse(w, status, map[string]any{
+		"ok":          false,
+		"error_code":  status,
+		"description": description,
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, response map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
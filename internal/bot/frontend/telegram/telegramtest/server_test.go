@@ -0,0 +1,89 @@
+package telegramtest
+
+import (
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBot(t *testing.T, server *Server) *tgbotapi.BotAPI {
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint(Token, server.Endpoint())
+	require.NoError(t, err)
+	require.Equal(t, Bot.UserName, bot.Self.UserName)
+	return bot
+}
+
+func TestServer_unauthorized(t *testing.T) {
+	server := NewServer(t)
+	_, err := tgbotapi.NewBotAPIWithAPIEndpoint("wrong", server.Endpoint())
+	require.ErrorContains(t, err, "Unauthorized")
+}
+
+func TestServer_getUpdates(t *testing.T) {
+	server := NewServer(t)
+	bot := newTestBot(t, server)
+	user := tgbotapi.User{ID: 42, FirstName: "Test"}
+	go func() {
+		// an update comes during long polling
+		time.Sleep(50 * time.Millisecond)
+		server.SendMessage(user, "/start building_1")
+	}()
+
+	updates, err := bot.GetUpdates(tgbotapi.UpdateConfig{Offset: 0, Timeout: 5})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	message := updates[0].Message
+	require.Equal(t, "start", message.Command())
+	require.Equal(t, "building_1", message.CommandArguments())
+	require.Equal(t, int64(42), message.Chat.ID)
+
+	updates, err = bot.GetUpdates(tgbotapi.UpdateConfig{Offset: updates[0].UpdateID + 1})
+	require.NoError(t, err)
+	require.Empty(t, updates)
+}
+
+func TestServer_click(t *testing.T) {
+	server := NewServer(t)
+	bot := newTestBot(t, server)
+	msg := tgbotapi.NewMessage(42, "Choose a language")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData("English", "en")),
+	)
+	sent, err := bot.Send(msg)
+	require.NoError(t, err)
+	calls := server.WaitForCalls(t, "sendMessage", 1)
+	require.Equal(t, "Choose a language", calls[0].Params.Get("text"))
+	require.Equal(t, sent.MessageID, calls[0].MessageID)
+
+	user := tgbotapi.User{ID: 42, FirstName: "Test"}
+	server.Click(t, user, sent.MessageID, "en")
+	updates, err := bot.GetUpdates(tgbotapi.UpdateConfig{})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	query := updates[0].CallbackQuery
+	require.Equal(t, "en", query.Data)
+	require.Equal(t, sent.MessageID, query.Message.MessageID)
+	require.Equal(t, msg.ReplyMarkup, *query.Message.ReplyMarkup)
+
+	_, err = bot.Request(tgbotapi.NewCallback(query.ID, ""))
+	require.NoError(t, err)
+	edit := tgbotapi.NewEditMessageText(42, sent.MessageID, "English")
+	_, err = bot.Send(edit)
+	require.NoError(t, err)
+	require.Len(t, server.GetCalls("answerCallbackQuery"), 1)
+	edited := server.GetMessage(t, sent.MessageID)
+	require.Equal(t, "English", edited.Text)
+	require.Nil(t, edited.ReplyMarkup)
+}
+
+func TestServer_unknownMethod(t *testing.T) {
+	server := NewServer(t)
+	bot := newTestBot(t, server)
+	_, err := bot.Request(tgbotapi.NewChatAction(42, tgbotapi.ChatTyping))
+	var apiErr *tgbotapi.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.Code)
+	require.Len(t, server.GetCalls("sendChatAction"), 1)
+}
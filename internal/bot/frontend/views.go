@@ -0,0 +1,96 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrUnsupportedView = errors.New("a view is not supported")
+
+// Format is a markup of a text. A frontend removes a markup it can not show.
+type Format string
+
+const (
+	Plain Format = ""
+	// HTML allows the tags <b>, <i> and <a>
+	HTML Format = "html"
+)
+
+// Button is a button of a view. A click on a button with data returns the data
+// to the bot, a button with a URL opens a link.
+type Button struct {
+	Label string
+	Data  string
+	URL   string
+}
+
+func NewDataButton(label, data string) Button {
+	return Button{Label: label, Data: data}
+}
+
+func NewURLButton(label, url string) Button {
+	return Button{Label: label, URL: url}
+}
+
+// View is something the bot shows in a chat.
+type View interface {
+	isView()
+}
+
+type TextView struct {
+	Text   string
+	Format Format
+	// ReplyRequest asks a user to reply to a text
+	ReplyRequest bool
+	// LocationRequest is a label of a button which shares a user location
+	LocationRequest string
+}
+
+// ButtonListView is a text with rows of buttons under it.
+type ButtonListView struct {
+	Text   string
+	Format Format
+	Rows   [][]Button
+}
+
+type LocationView struct {
+	Location
+	// Title and Address are optional
+	Title   string
+	Address string
+}
+
+type PhotoView struct {
+	FileID  string
+	Caption string
+	Rows    [][]Button
+}
+
+// AlbumView shows photos as one message.
+type AlbumView struct {
+	Photos []PhotoView
+}
+
+type DocumentView struct {
+	Name    string
+	Content []byte
+	Caption string
+}
+
+func (TextView) isView()       {}
+func (ButtonListView) isView() {}
+func (LocationView) isView()   {}
+func (PhotoView) isView()      {}
+func (AlbumView) isView()      {}
+func (DocumentView) isView()   {}
+
+// Frontend shows views to users of a messenger.
+type Frontend interface {
+	Send(ctx context.Context, chatID int64, view View) error
+	// Edit replaces a sent message with a view of the same kind.
+	// A frontend can edit texts, button lists and photos.
+	Edit(ctx context.Context, chatID int64, messageID int, view View) error
+	// Answer tells a messenger a click is handled. A text is an optional
+	// notification for a user.
+	Answer(ctx context.Context, click ButtonClick, text string) error
+}
@@ -9,128 +9,103 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// Telegram requires bots to explicitly answer every callback call
-func (h HandlerContainer) getCallbackAnswerFunc(ctx c.Context, queryID string) func() {
+const notSettingsOwnerText = "Only the member who opened the settings can change them."
+
+// A messenger can wait for an answer to every click
+func (h HandlerContainer) getCallbackAnswerFunc(ctx c.Context, click frontend.ButtonClick) func() {
+	return h.getCallbackNotificationFunc(ctx, click, new(string))
+}
+
+// getCallbackNotificationFunc answers a click with a notification
+// which a handler can set before the answer.
+func (h HandlerContainer) getCallbackNotificationFunc(
+	ctx c.Context,
+	click frontend.ButtonClick,
+	text *string,
+) func() {
 	return func() {
-		callbackAnswer := tgbotapi.NewCallback(queryID, "")
-		_, err := h.bot.Request(callbackAnswer)
+		err := h.ui.Answer(ctx, click, *text)
 		if err != nil {
 			slog.WarnContext(
 				ctx,
-				fmt.Sprintf("could not answer to a callback %v", queryID),
+				fmt.Sprintf("could not answer to a callback %v", click.ID),
 				slog.Any(logger.ErrorKey, err),
 			)
 		}
 	}
 }
 
-func (h HandlerContainer) next(ctx c.Context, query *tgbotapi.CallbackQuery) error {
-	defer h.getCallbackAnswerFunc(ctx, query.ID)()
-
-	message := query.Message
-	if message == nil {
-		err := fmt.Errorf("a callback has no message %v", query.ID)
-		slog.WarnContext(ctx, err.Error())
-		return errors.Join(err, ErrUnexpectedCallback)
+func (h HandlerContainer) addressPage(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	button, err := parsePageClick(ctx, click)
+	if err != nil {
+		return err
 	}
-	msgID := query.Message.MessageID
-	chat := query.Message.Chat
-	if chat == nil {
-		err := fmt.Errorf("a callback has no chat %v", query.ID)
-		slog.WarnContext(ctx, err.Error())
-		return errors.Join(err, ErrUnexpectedCallback)
+	message := click.Message
+	address, err := getSearchAddress(ctx, message)
+	if err != nil {
+		return err
 	}
-	var button NextButton
-	if err := json.Unmarshal([]byte(query.Data), &button); err != nil {
-		logMsg := fmt.Sprintf(
-			"unexpected callback data %v from a message %v and the chat %v",
-			query.Data,
-			msgID,
-			chat.ID,
-		)
-		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
-		return errors.Join(err, ErrUnexpectedCallback)
+	page, err := h.getAddressPage(
+		ctx,
+		message.Chat,
+		click.From,
+		address,
+		button.Page,
+		h.runtimeSettings.Get().PageSize,
+	)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
 	}
+	return h.editListPage(ctx, message, page)
+}
+
+// getSearchAddress returns an address of an address list message.
+func getSearchAddress(ctx c.Context, message frontend.Message) (string, error) {
 	// I need to extract an address from a message text
-	//  instead of using query data because the Telegram API specifies that
-	//  query data should be less than 64 bytes.
-	firstRow, _, found := strings.Cut(query.Message.Text, "\n")
-	logMsg := fmt.Sprintf(unexpectedTextTmpl, query.Message.Text, msgID, chat.ID)
+	//  instead of using button data because the Telegram API specifies that
+	//  callback data should be less than 64 bytes.
+	firstRow, _, found := strings.Cut(message.Text, "\n")
+	logMsg := fmt.Sprintf(
+		unexpectedTextTmpl,
+		message.Text,
+		message.ID,
+		message.Chat.ID,
+	)
 	if !found {
 		slog.ErrorContext(ctx, logMsg)
-		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+		return "", fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
 	}
 	_, address, found := strings.Cut(firstRow, ":")
 	if !found {
 		slog.ErrorContext(ctx, logMsg)
-		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
-	}
-	address = strings.TrimSpace(address)
-	if err := h.returnAddresses(
-		ctx,
-		chat.ID,
-		query.From,
-		address,
-		button.Limit,
-		button.Offset,
-	); err != nil {
-		return err
-	}
-	if query.Message.ReplyMarkup == nil {
-		return nil
-	}
-	keyboard := query.Message.ReplyMarkup.InlineKeyboard
-	if len(keyboard) < 1 {
-		return nil
-	}
-	editedMessage := tgbotapi.NewEditMessageReplyMarkup(
-		chat.ID,
-		msgID,
-		tgbotapi.InlineKeyboardMarkup{
-			InlineKeyboard: keyboard[:len(keyboard)-1],
-		},
-	)
-	_, err := h.bot.Send(editedMessage)
-	if err != nil {
-		slog.WarnContext(
-			ctx,
-			fmt.Sprintf("can not edit a message %v: %v", chat.ID, msgID),
-			slog.Any(logger.ErrorKey, err),
-		)
+		return "", fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
 	}
-	return err
+	return strings.TrimSpace(address), nil
 }
 
-func (h HandlerContainer) language(ctx c.Context, query *tgbotapi.CallbackQuery) error {
-	defer h.getCallbackAnswerFunc(ctx, query.ID)()
-	message := query.Message
-	if message == nil {
-		err := fmt.Errorf("a callback button has no message %v", query.ID)
+func (h HandlerContainer) language(ctx c.Context, click frontend.ButtonClick) error {
+	var notification string
+	defer h.getCallbackNotificationFunc(ctx, click, &notification)()
+	if click.From == nil {
+		err := fmt.Errorf("a callback has no sender %v", click.ID)
 		slog.WarnContext(ctx, err.Error())
 		return errors.Join(err, ErrUnexpectedCallback)
 	}
-	if query.From == nil {
-		err := fmt.Errorf("a callback has no sender %v", query.ID)
-		slog.WarnContext(ctx, err.Error())
-		return errors.Join(err, ErrUnexpectedCallback)
-	}
-	chat := query.Message.Chat
-	if chat == nil {
-		errMsg := fmt.Sprintf("a callback has no chat %v", query.ID)
-		slog.WarnContext(ctx, errMsg)
-		return fmt.Errorf("%v: %w", errMsg, ErrUnexpectedCallback)
-	}
-	msgID := query.Message.MessageID
+	chat := click.Message.Chat
+	msgID := click.Message.ID
 	var button LanguageButton
-	if err := json.Unmarshal([]byte(query.Data), &button); err != nil {
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
 		logMsg := fmt.Sprintf(
 			"unexpected callback data %v from a message %v and the chat %v",
-			query.Data,
+			click.Data,
 			msgID,
 			chat.ID,
 		)
@@ -147,27 +122,37 @@ func (h HandlerContainer) language(ctx c.Context, query *tgbotapi.CallbackQuery)
 		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
 		return errors.Join(sendErr, err)
 	}
-	if err := h.userService.SetLanguage(
-		ctx,
-		query.From.ID,
-		language,
-	); err != nil {
+	if chat.IsGroup && button.OwnerID != click.From.ID {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf(
+				"the user %v can not change the language of the chat %v",
+				click.From.ID,
+				chat.ID,
+			),
+		)
+		notification = notSettingsOwnerText
+		return ErrNotSettingsOwner
+	}
+	setLanguage := h.userService.SetLanguage
+	settingsOwnerID := click.From.ID
+	setting := "language"
+	if chat.IsGroup {
+		setLanguage, settingsOwnerID = h.chatService.SetLanguage, chat.ID
+		setting = "chat_language"
+	}
+	if err := setLanguage(ctx, settingsOwnerID, language); err != nil {
 		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
 		return errors.Join(sendErr, err)
 	}
+	h.metrics.SettingsChanges.With(
+		prometheus.Labels{"setting": setting, "value": string(language)},
+	).Inc()
 	approve := fmt.Sprintf(
 		"I will return the building information in %s.",
 		languageCodes[button.Language],
 	)
-	editedMessage := tgbotapi.NewEditMessageTextAndMarkup(
-		chat.ID,
-		msgID,
-		approve,
-		tgbotapi.InlineKeyboardMarkup{
-			InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
-		},
-	)
-	_, err := h.bot.Send(editedMessage)
+	err := h.ui.Edit(ctx, chat.ID, msgID, frontend.ButtonListView{Text: approve})
 	if err != nil {
 		slog.WarnContext(
 			ctx,
@@ -178,26 +163,15 @@ func (h HandlerContainer) language(ctx c.Context, query *tgbotapi.CallbackQuery)
 	return err
 }
 
-func (h HandlerContainer) building(ctx c.Context, query *tgbotapi.CallbackQuery) error {
-	defer h.getCallbackAnswerFunc(ctx, query.ID)()
-	message := query.Message
-	if message == nil {
-		err := fmt.Errorf("a callback has no message %v", query.ID)
-		slog.WarnContext(ctx, err.Error())
-		return errors.Join(err, ErrUnexpectedCallback)
-	}
-	chat := query.Message.Chat
-	if chat == nil {
-		errMsg := fmt.Sprintf("a callback has no chat %v", query.ID)
-		slog.WarnContext(ctx, errMsg)
-		return fmt.Errorf("%v: %w", errMsg, ErrUnexpectedCallback)
-	}
-	msgID := query.Message.MessageID
+func (h HandlerContainer) building(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	chat := click.Message.Chat
+	msgID := click.Message.ID
 	var button BuildingButton
-	if err := json.Unmarshal([]byte(query.Data), &button); err != nil {
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
 		err2 := fmt.Errorf(
 			"unexpected callback data '%v' from a message %v and the chat %v: %w",
-			query.Data,
+			click.Data,
 			msgID,
 			chat.ID,
 			err,
@@ -218,43 +192,83 @@ func (h HandlerContainer) building(ctx c.Context, query *tgbotapi.CallbackQuery)
 		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
 		return errors.Join(sendErr, err)
 	}
+	err = h.sendBuildingCard(ctx, chat, click.From, buildingID)
+	if errors.Is(err, ErrNoBuilding) {
+		return errors.Join(err, ErrUnexpectedCallback)
+	}
+	return err
+}
+
+func (h HandlerContainer) sendBuildingCard(
+	ctx c.Context,
+	chat frontend.Chat,
+	user *frontend.User,
+	buildingID int64,
+) error {
 	building, err := h.buildingService.GetBuildingByID(ctx, buildingID)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
-			fmt.Sprintf("can not get a building '%v'", button.ID),
+			fmt.Sprintf("can not get a building '%v'", buildingID),
 			slog.Any(logger.ErrorKey, err),
 		)
 		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
 		return errors.Join(sendErr, err)
 	}
 	if building == nil {
-		err := fmt.Errorf("a building does not exist '%v'", button.ID)
+		err := fmt.Errorf("%w: '%v'", ErrNoBuilding, buildingID)
 		slog.ErrorContext(
 			ctx,
 			err.Error(),
 			slog.Any(logger.ErrorKey, err),
 		)
 		sendErr := h.SendMessage(ctx, chat.ID, "Can not find the building.", "")
-		return errors.Join(sendErr, ErrUnexpectedCallback, err)
+		return errors.Join(sendErr, err)
 	}
-	userLanguage := h.getPreferredLanguage(ctx, query.From)
+	userLanguage := h.getChatLanguage(ctx, chat, user)
 	serializedItem, err := SerializeIntoMessage(*building, userLanguage)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
-			fmt.Sprintf("can not serialize a building '%v'", button.ID),
+			fmt.Sprintf("can not serialize a building '%v'", buildingID),
 			slog.Any(logger.ErrorKey, err),
 		)
 		sendErr := h.SendMessage(ctx, chat.ID, "Internal error.", "")
 		return errors.Join(sendErr, err)
 	}
-	return h.SendMessage(ctx, message.Chat.ID, serializedItem, tgbotapi.ModeHTML)
+	h.sendBuildingPhotos(ctx, chat.ID, building.ID)
+	buttonRow, err := h.getBuildingCardRow(ctx, userLanguage, building.ID)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error.", "")
+		return errors.Join(sendErr, err)
+	}
+	card := frontend.ButtonListView{
+		Text:   serializedItem,
+		Format: frontend.HTML,
+		Rows:   [][]frontend.Button{buttonRow},
+	}
+	err = h.ui.Send(ctx, chat.ID, card)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a building %v to: %v", building.ID, chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return err
+	}
+	h.metrics.BuildingViews.Inc()
+	if user != nil {
+		userID, buildingID := user.ID, building.ID
+		h.recordPopularity(ctx, func(ctx c.Context) error {
+			return h.popularityService.RecordView(ctx, userID, buildingID)
+		})
+	}
+	return nil
 }
 
 func (h HandlerContainer) getPreferredLanguage(
 	ctx c.Context,
-	user *tgbotapi.User,
+	user *frontend.User,
 ) services.Language {
 	userLanguage := services.English
 	if user == nil {
@@ -4,63 +4,23 @@ import (
 	"context"
 	"errors"
 	"strconv"
+	"sync"
 	"testing"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
 	"github.com/AndreyAD1/helsinki-guide/internal/utils"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-func TestHandlerContainer_building_errWithoutResponse(t *testing.T) {
-	tests := []struct {
-		name         string
-		calbackQuery *tgbotapi.CallbackQuery
-		queryID      string
-	}{
-		{
-			"empty callback query",
-			&tgbotapi.CallbackQuery{ID: "123"},
-			"123",
-		},
-		{
-			"no chat",
-			&tgbotapi.CallbackQuery{
-				ID:      "123",
-				From:    &tgbotapi.User{},
-				Message: &tgbotapi.Message{},
-			},
-			"123",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			botMock := NewInternalBot_mock(t)
-			botMock.EXPECT().
-				Request(tgbotapi.NewCallback(tt.queryID, "")).Return(nil, nil)
-			h := HandlerContainer{
-				services.NewBuildings_mock(t),
-				services.NewUsers_mock(t),
-				botMock,
-				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
-				"",
-				metrics.NewMetrics(prometheus.NewRegistry()),
-				map[string]CommandHandler{},
-			}
-			err := h.building(context.Background(), tt.calbackQuery)
-			require.ErrorIs(t, err, ErrUnexpectedCallback)
-		})
-	}
-}
-
 func TestHandlerContainer_building_unexpectedButtonData(t *testing.T) {
-	calbackQuery := &tgbotapi.CallbackQuery{
+	click := frontend.ButtonClick{
 		ID:      "123",
-		From:    &tgbotapi.User{},
-		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+		From:    &frontend.User{},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 	}
 	tests := []struct {
 		name       string
@@ -72,135 +32,168 @@ func TestHandlerContainer_building_unexpectedButtonData(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			botMock := NewInternalBot_mock(t)
-			botMock.EXPECT().
-				Send(tgbotapi.NewMessage(calbackQuery.Message.Chat.ID, "Internal error")).
-				Return(tgbotapi.Message{}, nil).
-				On("Request", tgbotapi.NewCallback(calbackQuery.ID, "")).Return(nil, nil)
+			click.Data = tt.buttonData
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			uiMock.EXPECT().
+				Send(ctx, click.Message.Chat.ID, frontend.TextView{Text: "Internal error"}).
+				Return(nil).
+				On("Answer", ctx, click, "").Return(nil)
 			h := HandlerContainer{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				botMock,
+				uiMock,
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
 				metrics.NewMetrics(prometheus.NewRegistry()),
-				map[string]CommandHandler{},
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				"",
+				nil,
+				nil,
+				nil,
+				nil,
 			}
-			calbackQuery.Data = tt.buttonData
-			err := h.building(context.Background(), calbackQuery)
+			err := h.building(ctx, click)
 			require.Error(t, err)
 		})
 	}
 }
 
 func TestHandlerContainer_building_serviceError(t *testing.T) {
-	calbackQuery := &tgbotapi.CallbackQuery{
+	click := frontend.ButtonClick{
 		ID:      "123",
-		From:    &tgbotapi.User{},
-		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+		From:    &frontend.User{},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 		Data:    `{"name": "building", "id": "123"}`,
 	}
-	botMock := NewInternalBot_mock(t)
-	buildingMock := services.NewBuildings_mock(t)
-	botMock.EXPECT().
-		Send(tgbotapi.NewMessage(calbackQuery.Message.Chat.ID, "Internal error")).
-		Return(tgbotapi.Message{}, nil).
-		On("Request", tgbotapi.NewCallback(calbackQuery.ID, "")).
-		Return(nil, nil)
 	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	uiMock.EXPECT().
+		Send(ctx, click.Message.Chat.ID, frontend.TextView{Text: "Internal error"}).
+		Return(nil).
+		On("Answer", ctx, click, "").
+		Return(nil)
 	buildingMock.EXPECT().GetBuildingByID(ctx, int64(123)).Return(nil, errors.New("test"))
 	h := HandlerContainer{
 		buildingMock,
 		services.NewUsers_mock(t),
-		botMock,
+		uiMock,
 		map[string]CommandHandler{},
-		map[string]internalButtonHandler{},
 		"",
 		metrics.NewMetrics(prometheus.NewRegistry()),
-		map[string]CommandHandler{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
 	}
-	err := h.building(ctx, calbackQuery)
+	err := h.building(ctx, click)
 	require.Error(t, err)
 }
 
 func TestHandlerContainer_building_noBuilding(t *testing.T) {
-	calbackQuery := &tgbotapi.CallbackQuery{
+	click := frontend.ButtonClick{
 		ID:      "123",
-		From:    &tgbotapi.User{},
-		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+		From:    &frontend.User{},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 		Data:    `{"name": "building", "id": "123"}`,
 	}
-	botMock := NewInternalBot_mock(t)
-	buildingMock := services.NewBuildings_mock(t)
-	botMock.EXPECT().
-		Send(tgbotapi.NewMessage(calbackQuery.Message.Chat.ID, "Can not find the building.")).
-		Return(tgbotapi.Message{}, nil).
-		On("Request", tgbotapi.NewCallback(calbackQuery.ID, "")).
-		Return(nil, nil)
 	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	uiMock.EXPECT().
+		Send(ctx, click.Message.Chat.ID, frontend.TextView{Text: "Can not find the building."}).
+		Return(nil).
+		On("Answer", ctx, click, "").
+		Return(nil)
 	buildingMock.EXPECT().GetBuildingByID(ctx, int64(123)).Return(nil, nil)
 	h := HandlerContainer{
 		buildingMock,
 		services.NewUsers_mock(t),
-		botMock,
+		uiMock,
 		map[string]CommandHandler{},
-		map[string]internalButtonHandler{},
 		"",
 		metrics.NewMetrics(prometheus.NewRegistry()),
-		map[string]CommandHandler{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
 	}
-	err := h.building(ctx, calbackQuery)
+	err := h.building(ctx, click)
 	require.ErrorIs(t, err, ErrUnexpectedCallback)
 }
 
 func TestHandlerContainer_building_serializationError(t *testing.T) {
-	calbackQuery := &tgbotapi.CallbackQuery{
+	click := frontend.ButtonClick{
 		ID:      "123",
-		From:    &tgbotapi.User{ID: 555},
-		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+		From:    &frontend.User{ID: 555},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 		Data:    `{"name": "building", "id": "123"}`,
 	}
-	botMock := NewInternalBot_mock(t)
+	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
 	buildingMock := services.NewBuildings_mock(t)
 	userMock := services.NewUsers_mock(t)
-	botMock.EXPECT().
-		Send(tgbotapi.NewMessage(calbackQuery.Message.Chat.ID, "Internal error.")).
-		Return(tgbotapi.Message{}, nil).
-		On("Request", tgbotapi.NewCallback(calbackQuery.ID, "")).
-		Return(nil, nil)
-	ctx := context.Background()
+	uiMock.EXPECT().
+		Send(ctx, click.Message.Chat.ID, frontend.TextView{Text: "Internal error."}).
+		Return(nil).
+		On("Answer", ctx, click, "").
+		Return(nil)
 	buildingMock.EXPECT().GetBuildingByID(ctx, int64(123)).
 		Return(&services.BuildingDTO{}, nil)
 	l := services.Language("unknown")
-	userMock.EXPECT().GetPreferredLanguage(ctx, calbackQuery.From.ID).
+	userMock.EXPECT().GetPreferredLanguage(ctx, click.From.ID).
 		Return(&l, nil)
 	h := HandlerContainer{
 		buildingMock,
 		userMock,
-		botMock,
+		uiMock,
 		map[string]CommandHandler{},
-		map[string]internalButtonHandler{},
 		"",
 		metrics.NewMetrics(prometheus.NewRegistry()),
-		map[string]CommandHandler{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
 	}
-	err := h.building(ctx, calbackQuery)
+	err := h.building(ctx, click)
 	require.Error(t, err)
 }
 
 func TestHandlerContainer_building_ok_noLanguageCheck(t *testing.T) {
-	callbackQuery := &tgbotapi.CallbackQuery{
+	click := frontend.ButtonClick{
 		ID:      "123",
-		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 		Data:    `{"name": "building", "id": "123"}`,
 	}
-	botMock := NewInternalBot_mock(t)
+	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
 	buildingMock := services.NewBuildings_mock(t)
 	userMock := services.NewUsers_mock(t)
-	expectedMessage := tgbotapi.NewMessage(
-		callbackQuery.Message.Chat.ID,
-		`<b>Name:</b> no data
+	expectedMessage := frontend.ButtonListView{
+		Text: `<b>Name:</b> no data
 <b>Address:</b> test address
 <b>Description:</b> no data
 <b>Completion year:</b> no data
@@ -210,51 +203,76 @@ func TestHandlerContainer_building_ok_noLanguageCheck(t *testing.T) {
 <b>Notable features:</b> no data
 <b>Surroundings:</b> no data
 <b>Building history:</b> no data`,
-	)
-	expectedMessage.ParseMode = tgbotapi.ModeHTML
-	botMock.EXPECT().
-		Send(expectedMessage).
-		Return(tgbotapi.Message{}, nil).
-		On("Request", tgbotapi.NewCallback(callbackQuery.ID, "")).
-		Return(nil, nil)
-	ctx := context.Background()
+		Format: frontend.HTML,
+		Rows: [][]frontend.Button{
+			{frontend.NewDataButton("Report a mistake", `{"name":"report","id":"0"}`)},
+		},
+	}
+	expectedAlbum := frontend.AlbumView{
+		Photos: []frontend.PhotoView{
+			{FileID: "file1", Caption: "© author 1"},
+			{FileID: "file2", Caption: "© author 2"},
+		},
+	}
+	uiMock.EXPECT().
+		Send(ctx, click.Message.Chat.ID, expectedMessage).
+		Return(nil).
+		On("Send", ctx, click.Message.Chat.ID, expectedAlbum).
+		Return(nil).
+		On("Answer", ctx, click, "").
+		Return(nil)
 	buildingMock.EXPECT().GetBuildingByID(ctx, int64(123)).
 		Return(&services.BuildingDTO{Address: "test address"}, nil)
+	photoMock := services.NewPhotos_mock(t)
+	photoMock.EXPECT().GetBuildingPhotos(ctx, int64(0)).Return(
+		[]services.PhotoDTO{
+			{ID: 1, FileID: "file1", Attribution: "author 1"},
+			{ID: 2, FileID: "file2", Attribution: "author 2"},
+		},
+		nil,
+	)
 	h := HandlerContainer{
 		buildingMock,
 		userMock,
-		botMock,
+		uiMock,
 		map[string]CommandHandler{},
-		map[string]internalButtonHandler{},
 		"",
 		metrics.NewMetrics(prometheus.NewRegistry()),
-		map[string]CommandHandler{},
+		nil,
+		nil,
+		photoMock,
+		nil,
+		nil,
+		"",
+		nil,
+		nil,
+		nil,
+		nil,
 	}
-	err := h.building(ctx, callbackQuery)
+	err := h.building(ctx, click)
 	require.NoError(t, err)
 }
 
 func TestHandlerContainer_building_ok(t *testing.T) {
 	tests := []struct {
 		name              string
-		callbackQuery     *tgbotapi.CallbackQuery
-		expectedMessage   tgbotapi.MessageConfig
+		click             frontend.ButtonClick
+		expectedText      string
 		building          *services.BuildingDTO
 		buildingError     error
 		preferredLanguage *services.Language
 		languageError     error
+		reportLabel       string
 	}{
 		{
 			"default en",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "en"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "en"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Name:</b> no data
+			`<b>Name:</b> no data
 <b>Address:</b> test address
 <b>Description:</b> no data
 <b>Completion year:</b> no data
@@ -264,23 +282,21 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Notable features:</b> no data
 <b>Surroundings:</b> no data
 <b>Building history:</b> no data`,
-			),
 			&services.BuildingDTO{Address: "test address"},
 			nil,
 			nil,
 			nil,
+			"Report a mistake",
 		},
 		{
 			"default ru",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "ru"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "ru"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Имя:</b> тестовое имя
+			`<b>Имя:</b> тестовое имя
 <b>Адрес:</b> test address
 <b>Описание:</b> нет данных
 <b>Год постройки:</b> нет данных
@@ -290,7 +306,6 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Примечательные особенности:</b> нет данных
 <b>Окрестности:</b> нет данных
 <b>История здания:</b> нет данных`,
-			),
 			&services.BuildingDTO{
 				Address: "test address",
 				NameRu:  utils.GetPointer("тестовое имя"),
@@ -298,18 +313,17 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			"Сообщить об ошибке",
 		},
 		{
 			"default fi",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "fi"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "fi"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Nimi:</b> testi rakennus
+			`<b>Nimi:</b> testi rakennus
 <b>Katuosoite:</b> test address
 <b>Kerrosluku:</b> ei tietoja
 <b>Käyttöönottovuosi:</b> ei tietoja
@@ -319,7 +333,6 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Huomattavia ominaisuuksia:</b> ei tietoja
 <b>Ympäristönkuvaus:</b> ei tietoja
 <b>Rakennushistoria:</b> ei tietoja`,
-			),
 			&services.BuildingDTO{
 				Address: "test address",
 				NameFi:  utils.GetPointer("testi rakennus"),
@@ -329,18 +342,17 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			"Ilmoita virheestä",
 		},
 		{
 			"unknown default language",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "unknown"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "unknown"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Name:</b> test building
+			`<b>Name:</b> test building
 <b>Address:</b> test address
 <b>Description:</b> no data
 <b>Completion year:</b> no data
@@ -350,7 +362,6 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Notable features:</b> no data
 <b>Surroundings:</b> no data
 <b>Building history:</b> no data`,
-			),
 			&services.BuildingDTO{
 				Address: "test address",
 				NameFi:  utils.GetPointer("testi rakennus"),
@@ -360,18 +371,17 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			"Report a mistake",
 		},
 		{
 			"preferred Finnish",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "en"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "en"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Nimi:</b> testi rakennus
+			`<b>Nimi:</b> testi rakennus
 <b>Katuosoite:</b> test address
 <b>Kerrosluku:</b> ei tietoja
 <b>Käyttöönottovuosi:</b> ei tietoja
@@ -381,7 +391,6 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Huomattavia ominaisuuksia:</b> ei tietoja
 <b>Ympäristönkuvaus:</b> ei tietoja
 <b>Rakennushistoria:</b> ei tietoja`,
-			),
 			&services.BuildingDTO{
 				Address: "test address",
 				NameFi:  utils.GetPointer("testi rakennus"),
@@ -391,18 +400,17 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 			nil,
 			&services.Finnish,
 			nil,
+			"Ilmoita virheestä",
 		},
 		{
 			"language service error",
-			&tgbotapi.CallbackQuery{
+			frontend.ButtonClick{
 				ID:      "123",
-				From:    &tgbotapi.User{ID: 555, LanguageCode: "ru"},
-				Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 99}},
+				From:    &frontend.User{ID: 555, LanguageCode: "ru"},
+				Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
 				Data:    `{"name": "building", "id": "123"}`,
 			},
-			tgbotapi.NewMessage(
-				99,
-				`<b>Имя:</b> тестовое имя
+			`<b>Имя:</b> тестовое имя
 <b>Адрес:</b> test address
 <b>Описание:</b> нет данных
 <b>Год постройки:</b> нет данных
@@ -412,7 +420,6 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 <b>Примечательные особенности:</b> нет данных
 <b>Окрестности:</b> нет данных
 <b>История здания:</b> нет данных`,
-			),
 			&services.BuildingDTO{
 				Address: "test address",
 				NameFi:  utils.GetPointer("testi rakennus"),
@@ -422,38 +429,60 @@ func TestHandlerContainer_building_ok(t *testing.T) {
 			nil,
 			nil,
 			errors.New("some language error"),
+			"Сообщить об ошибке",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			botMock := NewInternalBot_mock(t)
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
 			buildingMock := services.NewBuildings_mock(t)
 			userMock := services.NewUsers_mock(t)
-			tt.expectedMessage.ParseMode = tgbotapi.ModeHTML
-			botMock.EXPECT().
-				Send(tt.expectedMessage).
-				Return(tgbotapi.Message{}, nil).
-				On("Request", tgbotapi.NewCallback(tt.callbackQuery.ID, "")).
-				Return(nil, nil)
-			ctx := context.Background()
-			id, err := strconv.ParseInt(tt.callbackQuery.ID, 0, 64)
+			expectedMessage := frontend.ButtonListView{
+				Text:   tt.expectedText,
+				Format: frontend.HTML,
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton(tt.reportLabel, `{"name":"report","id":"0"}`)},
+				},
+			}
+			uiMock.EXPECT().
+				Send(ctx, int64(99), expectedMessage).
+				Return(nil).
+				On("Answer", ctx, tt.click, "").
+				Return(nil)
+			id, err := strconv.ParseInt(tt.click.ID, 0, 64)
 			require.NoError(t, err)
 			buildingMock.EXPECT().GetBuildingByID(ctx, id).
 				Return(tt.building, tt.buildingError)
-			userMock.EXPECT().GetPreferredLanguage(ctx, tt.callbackQuery.From.ID).
+			userMock.EXPECT().GetPreferredLanguage(ctx, tt.click.From.ID).
 				Return(tt.preferredLanguage, tt.languageError)
+			photoMock := services.NewPhotos_mock(t)
+			photoMock.EXPECT().GetBuildingPhotos(ctx, int64(0)).Return(nil, nil)
+			popularityMock := services.NewPopularity_mock(t)
+			popularityMock.EXPECT().
+				RecordView(mock.Anything, tt.click.From.ID, int64(0)).
+				Return(nil)
 			h := HandlerContainer{
 				buildingMock,
 				userMock,
-				botMock,
+				uiMock,
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
 				metrics.NewMetrics(prometheus.NewRegistry()),
-				map[string]CommandHandler{},
+				nil,
+				nil,
+				photoMock,
+				nil,
+				nil,
+				"",
+				nil,
+				nil,
+				popularityMock,
+				new(sync.WaitGroup),
 			}
-			err = h.building(ctx, tt.callbackQuery)
+			err = h.building(ctx, tt.click)
 			require.NoError(t, err)
+			h.Wait()
 		})
 	}
 }
@@ -0,0 +1,442 @@
+package handlers
+
+import (
+	c "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+const (
+	// the first row of a correction request should not depend on a user
+	// language because the bot extracts a building and a field from it
+	correctionHeaderTemplate = "Correction: building %d, field %s"
+	correctionHeaderPrefix   = "Correction: building"
+	MAX_CORRECTION_LENGTH    = 2000
+	MAX_MODERATED_LENGTH     = 1000
+)
+
+var reportButtonLabels = map[services.Language]string{
+	services.Finnish: "Ilmoita virheestä",
+	services.English: "Report a mistake",
+	services.Russian: "Сообщить об ошибке",
+}
+var chooseFieldTexts = map[services.Language]string{
+	services.Finnish: "Missä kentässä on virhe?",
+	services.English: "Which field contains a mistake?",
+	services.Russian: "В каком поле ошибка?",
+}
+var correctionRequestTexts = map[services.Language]string{
+	services.Finnish: "Vastaa tähän viestiin oikealla tekstillä.",
+	services.English: "Please reply to this message with the correct text.",
+	services.Russian: "Пожалуйста, ответьте на это сообщение правильным текстом.",
+}
+var correctionThanksTexts = map[services.Language]string{
+	services.Finnish: "Kiitos! Moderaattorit tarkistavat korjauksesi.",
+	services.English: "Thank you! Moderators will review your correction.",
+	services.Russian: "Спасибо! Модераторы рассмотрят ваше исправление.",
+}
+var invalidCorrectionTexts = map[services.Language]string{
+	services.Finnish: "Korjaus ei kelpaa. Kirjoita vuosi numeroina ja enintään %v merkkiä.",
+	services.English: "The correction is invalid. Please send a year as a number and no more than %v characters.",
+	services.Russian: "Исправление некорректно. Год нужно указать числом, а текст не должен превышать %v символов.",
+}
+var acceptedCorrectionTemplates = map[services.Language]string{
+	services.Finnish: "Korjauksesi kenttään \"%s\" on hyväksytty. Kiitos!",
+	services.English: "Your correction of the field \"%s\" has been accepted. Thank you!",
+	services.Russian: "Ваше исправление поля \"%s\" принято. Спасибо!",
+}
+var rejectedCorrectionTemplates = map[services.Language]string{
+	services.Finnish: "Korjauksesi kenttään \"%s\" on hylätty.",
+	services.English: "Your correction of the field \"%s\" has been rejected.",
+	services.Russian: "Ваше исправление поля \"%s\" отклонено.",
+}
+var fieldLabels = map[services.Language]map[services.CorrectionField]string{
+	services.Finnish: {
+		services.NameField:            "Nimi",
+		services.DescriptionField:     "Kerrosluku",
+		services.CompletionYearField:  "Käyttöönottovuosi",
+		services.FacadesField:         "Julkisivut",
+		services.DetailsField:         "Erityispiirteet",
+		services.NotableFeaturesField: "Huomattavia ominaisuuksia",
+		services.SurroundingsField:    "Ympäristönkuvaus",
+		services.HistoryField:         "Rakennushistoria",
+	},
+	services.English: {
+		services.NameField:            "Name",
+		services.DescriptionField:     "Description",
+		services.CompletionYearField:  "Completion year",
+		services.FacadesField:         "Facades",
+		services.DetailsField:         "Interesting details",
+		services.NotableFeaturesField: "Notable features",
+		services.SurroundingsField:    "Surroundings",
+		services.HistoryField:         "Building history",
+	},
+	services.Russian: {
+		services.NameField:            "Имя",
+		services.DescriptionField:     "Описание",
+		services.CompletionYearField:  "Год постройки",
+		services.FacadesField:         "Фасады",
+		services.DetailsField:         "Интересные детали",
+		services.NotableFeaturesField: "Примечательные особенности",
+		services.SurroundingsField:    "Окрестности",
+		services.HistoryField:         "История здания",
+	},
+}
+
+func getLocalized(texts map[services.Language]string, language services.Language) string {
+	text, ok := texts[language]
+	if !ok {
+		return texts[services.English]
+	}
+	return text
+}
+
+func getFieldLabel(field services.CorrectionField, language services.Language) string {
+	labels, ok := fieldLabels[language]
+	if !ok {
+		labels = fieldLabels[services.English]
+	}
+	label, ok := labels[field]
+	if !ok {
+		return string(field)
+	}
+	return label
+}
+
+func getReportButtonRow(
+	ctx c.Context,
+	language services.Language,
+	buildingID int64,
+) ([]frontend.Button, error) {
+	button := BuildingButton{
+		Button{getLocalized(reportButtonLabels, language), REPORT_BUTTON},
+		strconv.FormatInt(buildingID, 10),
+	}
+	buttonCallbackData, err := json.Marshal(button)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not create a button %v", button),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	return []frontend.Button{
+		frontend.NewDataButton(button.label, string(buttonCallbackData)),
+	}, nil
+}
+
+// IsCorrectionReply reports whether a message answers a bot request
+// to correct a building field.
+func IsCorrectionReply(message frontend.Message) bool {
+	reply := message.ReplyTo
+	if reply == nil {
+		return false
+	}
+	return strings.HasPrefix(reply.Text, correctionHeaderPrefix)
+}
+
+func (h HandlerContainer) report(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	var button BuildingButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	language := h.getChatLanguage(ctx, chat, click.From)
+	keyboardRows := [][]frontend.Button{}
+	for _, field := range services.CorrectionFields {
+		fieldButton := FieldButton{
+			Button{getFieldLabel(field, language), FIELD_BUTTON},
+			button.ID,
+			string(field),
+		}
+		buttonCallbackData, err := json.Marshal(fieldButton)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				fmt.Sprintf("can not create a button %v", fieldButton),
+				slog.Any(logger.ErrorKey, err),
+			)
+			sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		keyboardRows = append(
+			keyboardRows,
+			[]frontend.Button{
+				frontend.NewDataButton(
+					fieldButton.label,
+					string(buttonCallbackData),
+				),
+			},
+		)
+	}
+	msg := frontend.ButtonListView{
+		Text: getLocalized(chooseFieldTexts, language),
+		Rows: keyboardRows,
+	}
+	err := h.ui.Send(ctx, chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a field keyboard to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func (h HandlerContainer) correctionField(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	var button FieldButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	buildingID, err := strconv.ParseInt(button.ID, 10, 64)
+	field := services.CorrectionField(button.Field)
+	if err != nil || !slices.Contains(services.CorrectionFields, field) {
+		logMsg := fmt.Sprintf(
+			"unexpected field button %v from a message %v and the chat %v",
+			click.Data,
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, ErrUnexpectedCallback, err)
+	}
+	language := h.getChatLanguage(ctx, chat, click.From)
+	msgText := fmt.Sprintf(correctionHeaderTemplate, buildingID, field)
+	msgText += "\n" + getFieldLabel(field, language) + "\n"
+	msgText += getLocalized(correctionRequestTexts, language)
+	msg := frontend.TextView{Text: msgText, ReplyRequest: true}
+	err = h.ui.Send(ctx, chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a correction request to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func (h HandlerContainer) saveCorrection(ctx c.Context, message frontend.Message) error {
+	if message.From == nil || !IsCorrectionReply(message) {
+		return ErrNoCorrectionReply
+	}
+	firstRow, _, _ := strings.Cut(message.ReplyTo.Text, "\n")
+	var buildingID int64
+	var field string
+	if _, err := fmt.Sscanf(
+		firstRow,
+		correctionHeaderTemplate,
+		&buildingID,
+		&field,
+	); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("unexpected correction request '%v'", firstRow),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return errors.Join(ErrNoCorrectionReply, err)
+	}
+	language := h.getChatLanguage(ctx, message.Chat, message.From)
+	value := strings.TrimSpace(message.Text)
+	if value == "" || utf8.RuneCountInString(value) > MAX_CORRECTION_LENGTH {
+		text := fmt.Sprintf(
+			getLocalized(invalidCorrectionTexts, language),
+			MAX_CORRECTION_LENGTH,
+		)
+		return h.SendMessage(ctx, message.Chat.ID, text, "")
+	}
+	correction := services.CorrectionDTO{
+		BuildingID: buildingID,
+		Field:      services.CorrectionField(field),
+		Language:   language,
+		Value:      value,
+		ReporterID: message.From.ID,
+		ChatID:     message.Chat.ID,
+	}
+	err := h.correctionService.Report(ctx, correction)
+	if errors.Is(err, services.ErrInvalidCorrection) {
+		text := fmt.Sprintf(
+			getLocalized(invalidCorrectionTexts, language),
+			MAX_CORRECTION_LENGTH,
+		)
+		return h.SendMessage(ctx, message.Chat.ID, text, "")
+	}
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	return h.SendMessage(
+		ctx,
+		message.Chat.ID,
+		getLocalized(correctionThanksTexts, language),
+		"",
+	)
+}
+
+// moderation is an admin route, so the router rejects other users.
+func (h HandlerContainer) moderation(ctx c.Context, message frontend.Message) error {
+	return errors.Join(
+		h.sendPendingCorrection(ctx, message.Chat.ID),
+		h.sendPendingPhoto(ctx, message.Chat.ID),
+	)
+}
+
+func (h HandlerContainer) sendPendingCorrection(ctx c.Context, chatID int64) error {
+	corrections, err := h.correctionService.GetPendingCorrections(ctx, 1, 0)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	if len(corrections) == 0 {
+		return h.SendMessage(ctx, chatID, "No pending corrections.", "")
+	}
+	correction := corrections[0]
+	currentValue := noDataPerLanguages[services.English]
+	if correction.CurrentValue != nil {
+		currentValue = truncate(*correction.CurrentValue, MAX_MODERATED_LENGTH)
+	}
+	text := fmt.Sprintf(
+		"Correction #%v\nBuilding: %v, %s\nField: %s (%s)\nCurrent value: %s\nSuggested value: %s",
+		correction.ID,
+		correction.BuildingID,
+		correction.BuildingAddress,
+		getFieldLabel(correction.Field, services.English),
+		correction.Language,
+		currentValue,
+		truncate(correction.Value, MAX_MODERATED_LENGTH),
+	)
+	buttons := []frontend.Button{}
+	correctionID := strconv.FormatInt(correction.ID, 10)
+	moderationButtons := []ModerationButton{
+		{Button{"Accept", MODERATION_BUTTON}, correctionID, true},
+		{Button{"Reject", MODERATION_BUTTON}, correctionID, false},
+	}
+	for _, button := range moderationButtons {
+		buttonCallbackData, err := json.Marshal(button)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				fmt.Sprintf("can not create a button %v", button),
+				slog.Any(logger.ErrorKey, err),
+			)
+			sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		buttons = append(
+			buttons,
+			frontend.NewDataButton(
+				button.label,
+				string(buttonCallbackData),
+			),
+		)
+	}
+	msg := frontend.ButtonListView{Text: text, Rows: [][]frontend.Button{buttons}}
+	err = h.ui.Send(ctx, chatID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a correction to moderate to: %v", chatID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func (h HandlerContainer) moderate(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	msgID := message.ID
+	var button ModerationButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			msgID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	correctionID, err := strconv.ParseInt(button.ID, 10, 64)
+	if err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected correction ID %v from a message %v and the chat %v",
+			button.ID,
+			msgID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	decide, decision, templates := h.correctionService.Reject, "rejected", rejectedCorrectionTemplates
+	if button.Accept {
+		decide, decision, templates = h.correctionService.Accept, "accepted", acceptedCorrectionTemplates
+	}
+	correction, err := decide(ctx, correctionID)
+	if errors.Is(err, services.ErrCorrectionModerated) || errors.Is(err, services.ErrNoCorrection) {
+		decision = "already moderated"
+	} else if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	editedMessage := frontend.ButtonListView{
+		Text: fmt.Sprintf("%s\n\nDecision: %s", message.Text, decision),
+	}
+	if err := h.ui.Edit(ctx, chat.ID, msgID, editedMessage); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not edit a message %v: %v", chat.ID, msgID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	if correction != nil {
+		notification := fmt.Sprintf(
+			getLocalized(templates, correction.Language),
+			getFieldLabel(correction.Field, correction.Language),
+		)
+		// a reporter could block the bot, so a moderator should not get an error
+		h.SendMessage(ctx, correction.ChatID, notification, "")
+	}
+	return h.sendPendingCorrection(ctx, chat.ID)
+}
+
+func truncate(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	return string([]rune(text)[:maxLength]) + "…"
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerContainer_correctionField(t *testing.T) {
+	click := frontend.ButtonClick{
+		ID:      "123",
+		From:    &frontend.User{ID: 5, LanguageCode: "en"},
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
+		Data:    `{"name":"field","id":"12","field":"history"}`,
+	}
+	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	expectedMessage := frontend.TextView{
+		Text: `Correction: building 12, field history
+Building history
+Please reply to this message with the correct text.`,
+		ReplyRequest: true,
+	}
+	uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		userService: userMock,
+		ui:          uiMock,
+		metrics:     metrics.NewMetrics(prometheus.NewRegistry()),
+	}
+	err := h.correctionField(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_saveCorrection(t *testing.T) {
+	serviceError := errors.New("test error")
+	correctionRequest := frontend.Message{
+		Text: "Correction: building 12, field year\nCompletion year\nPlease reply",
+	}
+	tests := []struct {
+		name          string
+		message       frontend.Message
+		correction    *services.CorrectionDTO
+		serviceError  error
+		expectedText  string
+		expectedError error
+	}{
+		{
+			"success",
+			frontend.Message{
+				Chat:    frontend.Chat{ID: 99},
+				From:    &frontend.User{ID: 5},
+				Text:    " 1931 ",
+				ReplyTo: &correctionRequest,
+			},
+			&services.CorrectionDTO{
+				BuildingID: 12,
+				Field:      services.CompletionYearField,
+				Language:   services.English,
+				Value:      "1931",
+				ReporterID: 5,
+				ChatID:     99,
+			},
+			nil,
+			"Thank you! Moderators will review your correction.",
+			nil,
+		},
+		{
+			"invalid value",
+			frontend.Message{
+				Chat:    frontend.Chat{ID: 99},
+				From:    &frontend.User{ID: 5},
+				Text:    "about 1931",
+				ReplyTo: &correctionRequest,
+			},
+			&services.CorrectionDTO{
+				BuildingID: 12,
+				Field:      services.CompletionYearField,
+				Language:   services.English,
+				Value:      "about 1931",
+				ReporterID: 5,
+				ChatID:     99,
+			},
+			services.ErrInvalidCorrection,
+			"The correction is invalid. Please send a year as a number and no more than 2000 characters.",
+			nil,
+		},
+		{
+			"service error",
+			frontend.Message{
+				Chat:    frontend.Chat{ID: 99},
+				From:    &frontend.User{ID: 5},
+				Text:    "1931",
+				ReplyTo: &correctionRequest,
+			},
+			&services.CorrectionDTO{
+				BuildingID: 12,
+				Field:      services.CompletionYearField,
+				Language:   services.English,
+				Value:      "1931",
+				ReporterID: 5,
+				ChatID:     99,
+			},
+			serviceError,
+			"Internal error",
+			serviceError,
+		},
+		{
+			"not a reply",
+			frontend.Message{
+				Chat: frontend.Chat{ID: 99},
+				From: &frontend.User{ID: 5},
+				Text: "1931",
+			},
+			nil,
+			nil,
+			"",
+			ErrNoCorrectionReply,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			correctionMock := services.NewCorrections_mock(t)
+			if tt.correction != nil {
+				userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+				correctionMock.EXPECT().Report(ctx, *tt.correction).
+					Return(tt.serviceError)
+			}
+			if tt.expectedText != "" {
+				uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: tt.expectedText}).
+					Return(nil)
+			}
+			h := HandlerContainer{
+				userService:       userMock,
+				ui:                uiMock,
+				correctionService: correctionMock,
+			}
+			err := h.saveCorrection(ctx, tt.message)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestHandlerContainer_moderate(t *testing.T) {
+	ctx := context.Background()
+	click := frontend.ButtonClick{
+		ID:   "123",
+		From: &frontend.User{ID: 1},
+		Message: frontend.Message{
+			ID:   44,
+			Chat: frontend.Chat{ID: 99},
+			Text: "Correction #7",
+		},
+		Data: `{"name":"moderate","id":"7","accept":true}`,
+	}
+	uiMock := frontend.NewFrontend_mock(t)
+	correctionMock := services.NewCorrections_mock(t)
+	correctionMock.EXPECT().Accept(ctx, int64(7)).Return(
+		&services.CorrectionDTO{
+			ID:       7,
+			Field:    services.HistoryField,
+			Language: services.Finnish,
+			ChatID:   33,
+		},
+		nil,
+	)
+	correctionMock.EXPECT().GetPendingCorrections(ctx, 1, 0).
+		Return([]services.CorrectionDTO{}, nil)
+	editedMessage := frontend.ButtonListView{
+		Text: "Correction #7\n\nDecision: accepted",
+	}
+	notification := frontend.TextView{
+		Text: "Korjauksesi kenttään \"Rakennushistoria\" on hyväksytty. Kiitos!",
+	}
+	uiMock.EXPECT().Edit(ctx, int64(99), 44, editedMessage).Return(nil)
+	uiMock.EXPECT().Send(ctx, int64(33), notification).Return(nil)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "No pending corrections."}).
+		Return(nil)
+	uiMock.EXPECT().Answer(ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		ui:                uiMock,
+		correctionService: correctionMock,
+		runtimeSettings:   NewSettings(RuntimeSettings{AdminIDs: []int64{1}}),
+	}
+	err := h.moderate(ctx, click)
+	require.NoError(t, err)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"bytes"
+	c "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/export"
+)
+
+const (
+	EXPORT_FILE_NAME = "helsinki-buildings"
+	// MAX_EXPORT_BUILDINGS caps a file of a long list
+	MAX_EXPORT_BUILDINGS = 1000
+)
+
+var exportButtonLabels = map[export.Format]string{
+	export.GPX:     "GPX",
+	export.KML:     "KML",
+	export.GeoJSON: "GeoJSON",
+}
+var noExportTexts = map[services.Language]string{
+	services.Finnish: "Ei vietäviä rakennuksia, joiden sijainti tunnetaan.",
+	services.English: "There are no buildings with a known location to export.",
+	services.Russian: "Нет зданий с известным местоположением для экспорта.",
+}
+var exportCaptionTemplates = map[services.Language]string{
+	services.Finnish: "Rakennuksia: %v.",
+	services.English: "Buildings: %v.",
+	services.Russian: "Зданий: %v.",
+}
+
+// getExportButtonRow returns buttons that export buildings of a message
+// into files for GIS and hiking apps.
+func getExportButtonRow(ctx c.Context) ([]frontend.Button, error) {
+	row := make([]frontend.Button, len(export.Formats))
+	for i, format := range export.Formats {
+		button := ExportButton{
+			Button{exportButtonLabels[format], EXPORT_BUTTON},
+			format,
+		}
+		buttonCallbackData, err := json.Marshal(button)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				fmt.Sprintf("can not create a button %v", button),
+				slog.Any(logger.ErrorKey, err),
+			)
+			return nil, err
+		}
+		row[i] = frontend.NewDataButton(
+			button.label,
+			string(buttonCallbackData),
+		)
+	}
+	return row, nil
+}
+
+// getPlaces returns buildings with known coordinates.
+func getPlaces(
+	buildings []services.BuildingDTO,
+	language services.Language,
+) []export.Place {
+	var places []export.Place
+	for _, building := range buildings {
+		if building.Latitude == nil || building.Longitude == nil {
+			continue
+		}
+		place := export.Place{
+			Name:      getBuildingName(building, language),
+			Address:   building.Address,
+			Year:      building.CompletionYear,
+			Latitude:  *building.Latitude,
+			Longitude: *building.Longitude,
+		}
+		if building.Authors != nil {
+			place.Authors = *building.Authors
+		}
+		places = append(places, place)
+	}
+	return places
+}
+
+func (h HandlerContainer) export(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	var button ExportButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	buildingIDs := getMessageBuildingIDs(message)
+	if len(buildingIDs) == 0 {
+		logMsg := fmt.Sprintf(
+			"a message %v in the chat %v has no buildings to export",
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg)
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	if pageButton, ok := getMessagePageButton(message); ok {
+		var err error
+		buildingIDs, err = h.getListBuildingIDs(ctx, message, pageButton)
+		if errors.Is(err, ErrUnexpectedCallback) {
+			return err
+		}
+		if err != nil {
+			sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+	}
+	language := h.getChatLanguage(ctx, chat, click.From)
+	buildings, err := h.buildingService.GetBuildingsByIDs(ctx, buildingIDs)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	places := getPlaces(buildings, language)
+	if len(places) == 0 {
+		return h.SendMessage(ctx, chat.ID, getLocalized(noExportTexts, language), "")
+	}
+	var content bytes.Buffer
+	if err := export.Write(&content, button.Format, places); err != nil {
+		logMsg := fmt.Sprintf(
+			"can not export buildings %v into '%v'",
+			buildingIDs,
+			button.Format,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err, ErrUnexpectedCallback)
+	}
+	document := frontend.DocumentView{
+		Name:    export.FileName(EXPORT_FILE_NAME, button.Format),
+		Content: content.Bytes(),
+	}
+	document.Caption = fmt.Sprintf(
+		getLocalized(exportCaptionTemplates, language),
+		len(places),
+	)
+	if skipped := len(buildingIDs) - len(places); skipped > 0 {
+		document.Caption += "\n" + fmt.Sprintf(
+			getLocalized(skippedBuildingsTemplates, language),
+			skipped,
+		)
+	}
+	err = h.ui.Send(ctx, chat.ID, document)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send an export to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+// getListBuildingIDs searches buildings of a paginated list again
+// without pages, so that an export contains the whole list.
+func (h HandlerContainer) getListBuildingIDs(
+	ctx c.Context,
+	message frontend.Message,
+	pageButton PageButton,
+) ([]int64, error) {
+	var buildings []services.BuildingDTO
+	var err error
+	switch pageButton.Name {
+	case ADDRESS_PAGE_BUTTON:
+		address, addressErr := getSearchAddress(ctx, message)
+		if addressErr != nil {
+			return nil, addressErr
+		}
+		buildings, err = h.buildingService.GetBuildings(
+			ctx,
+			address,
+			MAX_EXPORT_BUILDINGS,
+			0,
+		)
+	case NEAREST_PAGE_BUTTON:
+		buildings, err = h.buildingService.GetNearestBuildings(
+			ctx,
+			h.runtimeSettings.Get().SearchRadius,
+			pageButton.Latitude,
+			pageButton.Longitude,
+			MAX_EXPORT_BUILDINGS,
+			0,
+		)
+	default:
+		return getMessageBuildingIDs(message), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	buildingIDs := make([]int64, len(buildings))
+	for i, building := range buildings {
+		buildingIDs[i] = building.ID
+	}
+	return buildingIDs, nil
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testExportRow = []frontend.Button{
+	frontend.NewDataButton("GPX", `{"name":"export","format":"gpx"}`),
+	frontend.NewDataButton("KML", `{"name":"export","format":"kml"}`),
+	frontend.NewDataButton("GeoJSON", `{"name":"export","format":"geojson"}`),
+}
+
+func TestHandlerContainer_export(t *testing.T) {
+	ctx := context.Background()
+	click := getRouteClick(`{"name":"export","format":"geojson"}`)
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(&services.Finnish, nil)
+	buildingMock.EXPECT().GetBuildingsByIDs(ctx, []int64{1, 2}).Return(
+		[]services.BuildingDTO{
+			{
+				ID:             1,
+				Address:        "test 1",
+				NameFi:         utils.GetPointer("nimi 1"),
+				CompletionYear: utils.GetPointer(1930),
+				Authors:        &[]string{"author 1"},
+				Latitude:       utils.GetPointer(60.17),
+				Longitude:      utils.GetPointer(24.94),
+			},
+			{ID: 2, Address: "test 2", NameFi: utils.GetPointer("nimi 2")},
+		},
+		nil,
+	)
+	expectedDocument := frontend.DocumentView{
+		Name: "helsinki-buildings.geojson",
+		Content: []byte(`{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "Point",
+        "coordinates": [
+          24.94,
+          60.17
+        ]
+      },
+      "properties": {
+        "name": "nimi 1",
+        "address": "test 1",
+        "year": 1930,
+        "authors": [
+          "author 1"
+        ]
+      }
+    }
+  ]
+}
+`),
+		Caption: "Rakennuksia: 1.\nRakennuksia ilman koordinaatteja: 1.",
+	}
+	uiMock.EXPECT().Send(ctx, int64(99), expectedDocument).Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		buildingService: buildingMock,
+		ui:              uiMock,
+		userService:     userMock,
+	}
+	err := h.export(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_export_noCoordinates(t *testing.T) {
+	ctx := context.Background()
+	click := getRouteClick(`{"name":"export","format":"gpx"}`)
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	buildingMock.EXPECT().GetBuildingsByIDs(ctx, []int64{1, 2}).Return(
+		[]services.BuildingDTO{{ID: 1, Address: "test 1"}},
+		nil,
+	)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "There are no buildings with a known location to export."}).
+		Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		buildingService: buildingMock,
+		ui:              uiMock,
+		userService:     userMock,
+	}
+	err := h.export(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_export_unknownFormat(t *testing.T) {
+	ctx := context.Background()
+	click := getRouteClick(`{"name":"export","format":"shp"}`)
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	buildingMock.EXPECT().GetBuildingsByIDs(ctx, []int64{1, 2}).Return(
+		[]services.BuildingDTO{{
+			ID:        1,
+			Address:   "test 1",
+			Latitude:  utils.GetPointer(60.17),
+			Longitude: utils.GetPointer(24.94),
+		}},
+		nil,
+	)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "Internal error"}).
+		Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		buildingService: buildingMock,
+		ui:              uiMock,
+		userService:     userMock,
+	}
+	err := h.export(ctx, click)
+	require.ErrorIs(t, err, ErrUnexpectedCallback)
+}
+
+func TestHandlerContainer_export_wholeList(t *testing.T) {
+	buildings := []services.BuildingDTO{
+		{ID: 1, Address: "test 1", Latitude: utils.GetPointer(60.17), Longitude: utils.GetPointer(24.94)},
+		{ID: 2, Address: "test 2", Latitude: utils.GetPointer(60.18), Longitude: utils.GetPointer(24.95)},
+		{ID: 3, Address: "test 3", Latitude: utils.GetPointer(60.19), Longitude: utils.GetPointer(24.96)},
+	}
+	tests := []struct {
+		name       string
+		text       string
+		pageButton string
+		search     func(*services.Buildings_mock)
+	}{
+		{
+			"addresses",
+			"Search address: test\nAvailable building addresses and names:",
+			`{"name":"addresses","page":2}`,
+			func(m *services.Buildings_mock) {
+				m.EXPECT().GetBuildings(mock.Anything, "test", MAX_EXPORT_BUILDINGS, 0).
+					Return(buildings, nil)
+			},
+		},
+		{
+			"nearest buildings",
+			"Nearest buildings:",
+			`{"name":"nearest","page":2,"lat":60.17,"lon":24.94}`,
+			func(m *services.Buildings_mock) {
+				m.EXPECT().
+					GetNearestBuildings(
+						mock.Anything,
+						testSearchRadius,
+						60.17,
+						24.94,
+						MAX_EXPORT_BUILDINGS,
+						0,
+					).
+					Return(buildings, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			click := getRouteClick(`{"name":"export","format":"gpx"}`)
+			click.Message.Text = tt.text
+			click.Message.Buttons = append(
+				click.Message.Buttons,
+				[]frontend.Button{frontend.NewDataButton("Next »", tt.pageButton)},
+			)
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			buildingMock := services.NewBuildings_mock(t)
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+			tt.search(buildingMock)
+			buildingMock.EXPECT().GetBuildingsByIDs(ctx, []int64{1, 2, 3}).
+				Return(buildings, nil)
+			uiMock.EXPECT().
+				Send(
+					ctx,
+					int64(99),
+					mock.MatchedBy(func(document frontend.DocumentView) bool {
+						return document.Caption == "Buildings: 3."
+					}),
+				).
+				Return(nil).
+				On("Answer", ctx, click, "").Return(nil)
+			h := HandlerContainer{
+				buildingService: buildingMock,
+				ui:              uiMock,
+				userService:     userMock,
+				runtimeSettings: newTestSettings(),
+			}
+			err := h.export(ctx, click)
+			require.NoError(t, err)
+		})
+	}
+}
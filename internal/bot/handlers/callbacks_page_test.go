@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	c "context"
+	"fmt"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerContainer_addressPage_positive(t *testing.T) {
+	ctx := c.Background()
+	buildingService := services.NewBuildings_mock(t)
+	uiMock := frontend.NewFrontend_mock(t)
+	click := frontend.ButtonClick{
+		ID: "123",
+		Message: frontend.Message{
+			ID:   7,
+			Chat: frontend.Chat{ID: 99},
+			Text: "address: test address   \nPage 1 of 2.",
+		},
+		Data: `{"name":"addresses","page":2}`,
+	}
+	buildingService.EXPECT().
+		GetBuildings(ctx, "test address", testPageSize, testPageSize).
+		Return(
+			[]services.BuildingDTO{
+				{ID: 11, Address: "test 11", NameEn: utils.GetPointer("name 11")},
+			},
+			nil,
+		)
+	buildingService.EXPECT().CountBuildings(ctx, "test address").Return(11, nil)
+	expectedEdit := frontend.ButtonListView{
+		Text: "Search address: test address\nAvailable building addresses and names:\nPage 2 of 2.",
+		Rows: [][]frontend.Button{
+			{
+				frontend.NewDataButton(
+					"test 11 - name 11",
+					`{"name":"building","id":"11"}`,
+				),
+			},
+			testExportRow,
+			{
+				frontend.NewDataButton(
+					"« Previous",
+					`{"name":"addresses","page":1}`,
+				),
+			},
+		},
+	}
+	uiMock.EXPECT().Answer(ctx, click, "").Return(nil)
+	uiMock.EXPECT().Edit(ctx, int64(99), 7, expectedEdit).Return(nil)
+
+	h := HandlerContainer{
+		buildingService: buildingService,
+		ui:              uiMock,
+		metrics:         metrics.NewMetrics(prometheus.NewRegistry()),
+		runtimeSettings: newTestSettings(),
+	}
+	err := h.addressPage(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_addressPage_negative(t *testing.T) {
+	type fields struct {
+		buildingService *services.Buildings_mock
+		userService     *services.Users_mock
+		ui              *frontend.Frontend_mock
+	}
+	type args struct {
+		ctx   c.Context
+		click frontend.ButtonClick
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+	}{
+		{
+			"empty callback query",
+			fields{
+				services.NewBuildings_mock(t),
+				services.NewUsers_mock(t),
+				frontend.NewFrontend_mock(t),
+			},
+			args{
+				c.Background(),
+				frontend.ButtonClick{ID: "123"},
+			},
+		},
+		{
+			"invalid callback data",
+			fields{
+				services.NewBuildings_mock(t),
+				services.NewUsers_mock(t),
+				frontend.NewFrontend_mock(t),
+			},
+			args{
+				c.Background(),
+				frontend.ButtonClick{
+					ID:      "123",
+					Message: frontend.Message{Chat: frontend.Chat{}},
+				},
+			},
+		},
+		{
+			"invalid callback text",
+			fields{
+				services.NewBuildings_mock(t),
+				services.NewUsers_mock(t),
+				frontend.NewFrontend_mock(t),
+			},
+			args{
+				c.Background(),
+				frontend.ButtonClick{
+					ID: "123",
+					Message: frontend.Message{
+						Chat: frontend.Chat{},
+						Text: "one-line text",
+					},
+					Data: `{"name":"addresses","page":2}`,
+				},
+			},
+		},
+		{
+			"text without a colon",
+			fields{
+				services.NewBuildings_mock(t),
+				services.NewUsers_mock(t),
+				frontend.NewFrontend_mock(t),
+			},
+			args{
+				c.Background(),
+				frontend.ButtonClick{
+					ID: "123",
+					Message: frontend.Message{
+						Chat: frontend.Chat{},
+						Text: "address test address   \nadditional text",
+					},
+					Data: `{"name":"addresses","page":2}`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fields.ui.EXPECT().Answer(tt.args.ctx, tt.args.click, "").Return(nil)
+			h := HandlerContainer{
+				tt.fields.buildingService,
+				tt.fields.userService,
+				tt.fields.ui,
+				map[string]CommandHandler{},
+				"",
+				metrics.NewMetrics(prometheus.NewRegistry()),
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				"",
+				nil,
+				nil,
+				nil,
+				nil,
+			}
+			err := h.addressPage(tt.args.ctx, tt.args.click)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_nearestPage(t *testing.T) {
+	ctx := c.Background()
+	buildingService := services.NewBuildings_mock(t)
+	uiMock := frontend.NewFrontend_mock(t)
+	click := frontend.ButtonClick{
+		ID: "123",
+		Message: frontend.Message{
+			ID:   7,
+			Chat: frontend.Chat{ID: 99},
+		},
+		Data: `{"name":"nearest","page":3,"lat":60.17,"lon":24.94}`,
+	}
+	buildingService.EXPECT().
+		GetNearestBuildings(ctx, testSearchRadius, 60.17, 24.94, testPageSize, 2*testPageSize).
+		Return(
+			[]services.BuildingDTO{
+				{ID: 21, Address: "test 21", NameEn: utils.GetPointer("name 21")},
+			},
+			nil,
+		)
+	buildingService.EXPECT().
+		CountNearestBuildings(ctx, testSearchRadius, 60.17, 24.94).
+		Return(21, nil)
+	expectedEdit := frontend.ButtonListView{
+		Text: fmt.Sprintf(nearestBuildingsEnglishTemplate, testSearchRadius) + "\nPage 3 of 3.",
+		Rows: [][]frontend.Button{
+			{
+				frontend.NewDataButton(
+					"test 21 - name 21",
+					`{"name":"building","id":"21"}`,
+				),
+			},
+			testExportRow,
+			{
+				frontend.NewDataButton(
+					"« Previous",
+					`{"name":"nearest","page":2,"lat":60.17,"lon":24.94}`,
+				),
+			},
+		},
+	}
+	uiMock.EXPECT().Answer(ctx, click, "").Return(nil)
+	uiMock.EXPECT().Edit(ctx, int64(99), 7, expectedEdit).Return(nil)
+
+	h := HandlerContainer{
+		buildingService: buildingService,
+		ui:              uiMock,
+		runtimeSettings: newTestSettings(),
+	}
+	err := h.nearestPage(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_nearestPage_invalidPage(t *testing.T) {
+	uiMock := frontend.NewFrontend_mock(t)
+	click := frontend.ButtonClick{
+		ID:      "123",
+		Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
+		Data:    `{"name":"nearest","lat":60.17,"lon":24.94}`,
+	}
+	ctx := c.Background()
+	uiMock.EXPECT().Answer(ctx, click, "").Return(nil)
+	h := HandlerContainer{ui: uiMock}
+	err := h.nearestPage(ctx, click)
+	require.ErrorIs(t, err, ErrUnexpectedCallback)
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	c "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+var photoHintTexts = map[services.Language]string{
+	services.Finnish: "Lisää kuva vastaamalla rakennuksen korttiin kuvalla.",
+	services.English: "To add a photo, reply to a building card with the photo.",
+	services.Russian: "Чтобы добавить фото, ответьте фотографией на карточку здания.",
+}
+var photoThanksTexts = map[services.Language]string{
+	services.Finnish: "Kiitos! Moderaattorit tarkistavat kuvasi.",
+	services.English: "Thank you! Moderators will review your photo.",
+	services.Russian: "Спасибо! Модераторы рассмотрят вашу фотографию.",
+}
+var photoAddedTexts = map[services.Language]string{
+	services.Finnish: "Kuva on lisätty.",
+	services.English: "The photo has been added.",
+	services.Russian: "Фотография добавлена.",
+}
+var duplicatePhotoTexts = map[services.Language]string{
+	services.Finnish: "Tämä kuva on jo lisätty rakennukseen.",
+	services.English: "This photo is already attached to the building.",
+	services.Russian: "Эта фотография уже добавлена к зданию.",
+}
+var acceptedPhotoTexts = map[services.Language]string{
+	services.Finnish: "Kuvasi on hyväksytty. Kiitos!",
+	services.English: "Your photo has been accepted. Thank you!",
+	services.Russian: "Ваша фотография принята. Спасибо!",
+}
+var rejectedPhotoTexts = map[services.Language]string{
+	services.Finnish: "Kuvasi on hylätty.",
+	services.English: "Your photo has been rejected.",
+	services.Russian: "Ваша фотография отклонена.",
+}
+
+// getRepliedBuildingID returns a building ID from buttons
+// of a building card a message replies to.
+func getRepliedBuildingID(message frontend.Message) (int64, bool) {
+	reply := message.ReplyTo
+	if reply == nil {
+		return 0, false
+	}
+	for _, row := range reply.Buttons {
+		for _, button := range row {
+			if button.Data == "" {
+				continue
+			}
+			var buildingButton BuildingButton
+			err := json.Unmarshal([]byte(button.Data), &buildingButton)
+			if err != nil || buildingButton.Name != REPORT_BUTTON {
+				continue
+			}
+			buildingID, err := strconv.ParseInt(buildingButton.ID, 10, 64)
+			if err != nil {
+				continue
+			}
+			return buildingID, true
+		}
+	}
+	return 0, false
+}
+
+func getAttribution(message frontend.Message) string {
+	if caption := strings.TrimSpace(message.Text); caption != "" {
+		return truncate(caption, MAX_MODERATED_LENGTH)
+	}
+	user := message.From
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if name != "" {
+		return name
+	}
+	return user.UserName
+}
+
+func (h HandlerContainer) savePhoto(ctx c.Context, message frontend.Message) error {
+	if message.From == nil || message.Photo == nil {
+		return ErrNoPhoto
+	}
+	language := h.getChatLanguage(ctx, message.Chat, message.From)
+	buildingID, ok := getRepliedBuildingID(message)
+	if !ok {
+		return h.SendMessage(
+			ctx,
+			message.Chat.ID,
+			getLocalized(photoHintTexts, language),
+			"",
+		)
+	}
+	approved := isAdmin(h.runtimeSettings.Get().AdminIDs, message.From)
+	photo := services.PhotoDTO{
+		BuildingID:   buildingID,
+		FileID:       message.Photo.FileID,
+		FileUniqueID: message.Photo.FileUniqueID,
+		Attribution:  getAttribution(message),
+		UploaderID:   message.From.ID,
+		ChatID:       message.Chat.ID,
+	}
+	_, err := h.photoService.AddPhoto(ctx, photo, approved)
+	if errors.Is(err, services.ErrDuplicatePhoto) {
+		return h.SendMessage(
+			ctx,
+			message.Chat.ID,
+			getLocalized(duplicatePhotoTexts, language),
+			"",
+		)
+	}
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	texts := photoThanksTexts
+	if approved {
+		texts = photoAddedTexts
+	}
+	return h.SendMessage(ctx, message.Chat.ID, getLocalized(texts, language), "")
+}
+
+// sendBuildingPhotos sends accepted photos of a building as an album.
+// A building card is still useful without photos, so the function
+// only logs errors.
+func (h HandlerContainer) sendBuildingPhotos(ctx c.Context, chatID, buildingID int64) {
+	photos, err := h.photoService.GetBuildingPhotos(ctx, buildingID)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not get photos of a building %v", buildingID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return
+	}
+	if len(photos) == 0 {
+		return
+	}
+	album := frontend.AlbumView{Photos: make([]frontend.PhotoView, len(photos))}
+	for i, photo := range photos {
+		album.Photos[i] = frontend.PhotoView{
+			FileID:  photo.FileID,
+			Caption: "© " + photo.Attribution,
+		}
+	}
+	if err := h.ui.Send(ctx, chatID, album); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send photos of a building %v to: %v", buildingID, chatID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+}
+
+func (h HandlerContainer) sendPendingPhoto(ctx c.Context, chatID int64) error {
+	photos, err := h.photoService.GetPendingPhotos(ctx, 1, 0)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	if len(photos) == 0 {
+		return h.SendMessage(ctx, chatID, "No pending photos.", "")
+	}
+	photo := photos[0]
+	photoID := strconv.FormatInt(photo.ID, 10)
+	buttons := []frontend.Button{}
+	moderationButtons := []ModerationButton{
+		{Button{"Accept", PHOTO_MODERATION_BUTTON}, photoID, true},
+		{Button{"Reject", PHOTO_MODERATION_BUTTON}, photoID, false},
+	}
+	for _, button := range moderationButtons {
+		buttonCallbackData, err := json.Marshal(button)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				fmt.Sprintf("can not create a button %v", button),
+				slog.Any(logger.ErrorKey, err),
+			)
+			sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		buttons = append(
+			buttons,
+			frontend.NewDataButton(
+				button.label,
+				string(buttonCallbackData),
+			),
+		)
+	}
+	msg := frontend.PhotoView{
+		FileID: photo.FileID,
+		Caption: fmt.Sprintf(
+			"Photo #%v\nBuilding: %v\nAttribution: %s",
+			photo.ID,
+			photo.BuildingID,
+			photo.Attribution,
+		),
+		Rows: [][]frontend.Button{buttons},
+	}
+	err = h.ui.Send(ctx, chatID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a photo to moderate to: %v", chatID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func (h HandlerContainer) moderatePhoto(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	msgID := message.ID
+	var button ModerationButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			msgID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	photoID, err := strconv.ParseInt(button.ID, 10, 64)
+	if err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected photo ID %v from a message %v and the chat %v",
+			button.ID,
+			msgID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	decide, decision, texts := h.photoService.RejectPhoto, "rejected", rejectedPhotoTexts
+	if button.Accept {
+		decide, decision, texts = h.photoService.AcceptPhoto, "accepted", acceptedPhotoTexts
+	}
+	photo, err := decide(ctx, photoID)
+	if errors.Is(err, services.ErrPhotoModerated) || errors.Is(err, services.ErrNoPhoto) {
+		decision = "already moderated"
+	} else if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	editedMessage := frontend.PhotoView{
+		Caption: fmt.Sprintf("%s\n\nDecision: %s", message.Text, decision),
+	}
+	if err := h.ui.Edit(ctx, chat.ID, msgID, editedMessage); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not edit a message %v: %v", chat.ID, msgID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	if photo != nil {
+		language := h.getPreferredLanguage(ctx, &frontend.User{ID: photo.UploaderID})
+		// an uploader could block the bot, so a moderator should not get an error
+		h.SendMessage(ctx, photo.ChatID, getLocalized(texts, language), "")
+	}
+	return h.sendPendingPhoto(ctx, chat.ID)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerContainer_savePhoto(t *testing.T) {
+	serviceError := errors.New("test error")
+	buildingCard := &frontend.Message{
+		Buttons: [][]frontend.Button{
+			{
+				frontend.NewDataButton(
+					"Report a mistake",
+					`{"name":"report","id":"12"}`,
+				),
+			},
+		},
+	}
+	photo := &frontend.Photo{FileID: "large", FileUniqueID: "large-unique"}
+	expectedPhoto := services.PhotoDTO{
+		BuildingID:   12,
+		FileID:       "large",
+		FileUniqueID: "large-unique",
+		Attribution:  "Test User",
+		UploaderID:   5,
+		ChatID:       99,
+	}
+	tests := []struct {
+		name          string
+		adminIDs      []int64
+		replyTo       *frontend.Message
+		approved      bool
+		serviceError  error
+		expectedText  string
+		expectedError error
+	}{
+		{
+			"pending",
+			nil,
+			buildingCard,
+			false,
+			nil,
+			"Thank you! Moderators will review your photo.",
+			nil,
+		},
+		{
+			"admin",
+			[]int64{5},
+			buildingCard,
+			true,
+			nil,
+			"The photo has been added.",
+			nil,
+		},
+		{
+			"duplicate",
+			nil,
+			buildingCard,
+			false,
+			services.ErrDuplicatePhoto,
+			"This photo is already attached to the building.",
+			nil,
+		},
+		{
+			"service error",
+			nil,
+			buildingCard,
+			false,
+			serviceError,
+			"Internal error",
+			serviceError,
+		},
+		{
+			"not a reply to a building",
+			nil,
+			&frontend.Message{Text: "some text"},
+			false,
+			nil,
+			"To add a photo, reply to a building card with the photo.",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			photoMock := services.NewPhotos_mock(t)
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+			if tt.replyTo == buildingCard {
+				photoMock.EXPECT().AddPhoto(ctx, expectedPhoto, tt.approved).
+					Return(&expectedPhoto, tt.serviceError)
+			}
+			uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: tt.expectedText}).
+				Return(nil)
+			h := HandlerContainer{
+				userService:     userMock,
+				ui:              uiMock,
+				photoService:    photoMock,
+				runtimeSettings: NewSettings(RuntimeSettings{AdminIDs: tt.adminIDs}),
+			}
+			message := frontend.Message{
+				Chat:    frontend.Chat{ID: 99},
+				From:    &frontend.User{ID: 5, FirstName: "Test", LastName: "User"},
+				Photo:   photo,
+				ReplyTo: tt.replyTo,
+			}
+			err := h.savePhoto(ctx, message)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestHandlerContainer_savePhoto_noPhoto(t *testing.T) {
+	h := HandlerContainer{}
+	message := frontend.Message{
+		Chat: frontend.Chat{ID: 99},
+		From: &frontend.User{ID: 5},
+	}
+	err := h.savePhoto(context.Background(), message)
+	require.ErrorIs(t, err, ErrNoPhoto)
+}
+
+func TestHandlerContainer_moderatePhoto(t *testing.T) {
+	ctx := context.Background()
+	click := frontend.ButtonClick{
+		ID:   "123",
+		From: &frontend.User{ID: 1},
+		Message: frontend.Message{
+			ID:   44,
+			Chat: frontend.Chat{ID: 99},
+			Text: "Photo #7",
+		},
+		Data: `{"name":"moderatePhoto","id":"7"}`,
+	}
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	photoMock := services.NewPhotos_mock(t)
+	photoMock.EXPECT().RejectPhoto(ctx, int64(7)).Return(
+		&services.PhotoDTO{ID: 7, UploaderID: 5, ChatID: 33},
+		nil,
+	)
+	photoMock.EXPECT().GetPendingPhotos(ctx, 1, 0).Return(nil, nil)
+	russian := services.Russian
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(&russian, nil)
+	editedMessage := frontend.PhotoView{Caption: "Photo #7\n\nDecision: rejected"}
+	uiMock.EXPECT().Edit(ctx, int64(99), 44, editedMessage).Return(nil)
+	uiMock.EXPECT().
+		Send(ctx, int64(33), frontend.TextView{Text: "Ваша фотография отклонена."}).
+		Return(nil)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "No pending photos."}).
+		Return(nil)
+	uiMock.EXPECT().Answer(ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		ui:              uiMock,
+		userService:     userMock,
+		photoService:    photoMock,
+		runtimeSettings: NewSettings(RuntimeSettings{AdminIDs: []int64{1}}),
+	}
+	err := h.moderatePhoto(ctx, click)
+	require.NoError(t, err)
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	c "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+var routeButtonLabels = map[services.Language]string{
+	services.Finnish: "Suunnittele kävelyreitti",
+	services.English: "Plan a walking route",
+	services.Russian: "Построить пешеходный маршрут",
+}
+var routeHeaderTemplates = map[services.Language]string{
+	services.Finnish: "Kävelyreitti: %.1f km, noin %v min.",
+	services.English: "Walking route: %.1f km, about %v min.",
+	services.Russian: "Пешеходный маршрут: %.1f км, около %v мин.",
+}
+var skippedBuildingsTemplates = map[services.Language]string{
+	services.Finnish: "Rakennuksia ilman koordinaatteja: %v.",
+	services.English: "Buildings without coordinates: %v.",
+	services.Russian: "Здания без координат: %v.",
+}
+var noRouteTexts = map[services.Language]string{
+	services.Finnish: "En tiedä näiden rakennusten sijaintia.",
+	services.English: "I do not know the location of these buildings.",
+	services.Russian: "Я не знаю, где находятся эти здания.",
+}
+var routeSelectionTexts = map[services.Language]string{
+	services.Finnish: "Valitse reitin rakennukset:",
+	services.English: "Choose buildings for a route:",
+	services.Russian: "Выберите здания для маршрута:",
+}
+var routePlanButtonLabels = map[services.Language]string{
+	services.Finnish: "Näytä reitti",
+	services.English: "Show the route",
+	services.Russian: "Показать маршрут",
+}
+var noRouteStopsTexts = map[services.Language]string{
+	services.Finnish: "Valitse vähintään yksi rakennus.",
+	services.English: "Choose at least one building.",
+	services.Russian: "Выберите хотя бы одно здание.",
+}
+
+const (
+	selectedStopMark   = "✅ "
+	unselectedStopMark = "⬜ "
+)
+
+// getRouteButtonRow returns a button that offers to choose buildings
+// of a message for a route. A route starts at a start point if it is set.
+func getRouteButtonRow(
+	ctx c.Context,
+	language services.Language,
+	start *services.Coordinates,
+) ([]frontend.Button, error) {
+	button := RouteButton{Button: Button{getLocalized(routeButtonLabels, language), ROUTE_BUTTON}}
+	if start != nil {
+		// the precision is about one metre and callback data stays short
+		button.Latitude = math.Round(start.Latitude*1e5) / 1e5
+		button.Longitude = math.Round(start.Longitude*1e5) / 1e5
+	}
+	buttonCallbackData, err := json.Marshal(button)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not create a button %v", button),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	return []frontend.Button{
+		frontend.NewDataButton(button.label, string(buttonCallbackData)),
+	}, nil
+}
+
+// getMessageBuildingIDs extracts building IDs from building buttons
+// because callback data can not contain more than 64 bytes.
+func getMessageBuildingIDs(message frontend.Message) []int64 {
+	var buildingIDs []int64
+	for _, building := range getMessageBuildingButtons(message) {
+		buildingID, err := strconv.ParseInt(building.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		buildingIDs = append(buildingIDs, buildingID)
+	}
+	return buildingIDs
+}
+
+// getMessageBuildingButtons returns building buttons of a message
+// with their labels.
+func getMessageBuildingButtons(message frontend.Message) []BuildingButton {
+	var buildings []BuildingButton
+	for _, row := range message.Buttons {
+		for _, button := range row {
+			if button.Data == "" {
+				continue
+			}
+			var buildingButton BuildingButton
+			err := json.Unmarshal([]byte(button.Data), &buildingButton)
+			if err != nil || buildingButton.Name != BUILDING_BUTTON {
+				continue
+			}
+			buildingButton.label = button.Label
+			buildings = append(buildings, buildingButton)
+		}
+	}
+	return buildings
+}
+
+// getRouteStopRow returns a button which adds a building to a route
+// or removes it from a route.
+func getRouteStopRow(ctx c.Context, stop RouteStopButton) ([]frontend.Button, error) {
+	buttonCallbackData, err := json.Marshal(stop)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not create a button %v", stop),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	label := unselectedStopMark + stop.label
+	if stop.Selected {
+		label = selectedStopMark + stop.label
+	}
+	return []frontend.Button{frontend.NewDataButton(label, string(buttonCallbackData))}, nil
+}
+
+// parseRouteStop returns a route stop button and its label without a mark.
+func parseRouteStop(button frontend.Button) (RouteStopButton, bool) {
+	var stop RouteStopButton
+	if button.Data == "" {
+		return stop, false
+	}
+	err := json.Unmarshal([]byte(button.Data), &stop)
+	if err != nil || stop.Name != ROUTE_STOP_BUTTON {
+		return stop, false
+	}
+	stop.label = strings.TrimPrefix(button.Label, selectedStopMark)
+	stop.label = strings.TrimPrefix(stop.label, unselectedStopMark)
+	return stop, true
+}
+
+func parseRouteClick(ctx c.Context, click frontend.ButtonClick) (RouteButton, error) {
+	var button RouteButton
+	if err := json.Unmarshal([]byte(click.Data), &button); err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			click.Message.ID,
+			click.Message.Chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return button, fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	return button, nil
+}
+
+// route asks which buildings of a message a route should visit,
+// all of them are selected at first.
+func (h HandlerContainer) route(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	button, err := parseRouteClick(ctx, click)
+	if err != nil {
+		return err
+	}
+	buildings := getMessageBuildingButtons(message)
+	if len(buildings) == 0 {
+		logMsg := fmt.Sprintf(
+			"a message %v in the chat %v has no buildings for a route",
+			message.ID,
+			chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg)
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	language := h.getChatLanguage(ctx, chat, click.From)
+	keyboardRows := [][]frontend.Button{}
+	for _, building := range buildings {
+		stop := RouteStopButton{
+			Button:   Button{building.label, ROUTE_STOP_BUTTON},
+			ID:       building.ID,
+			Selected: true,
+		}
+		row, err := getRouteStopRow(ctx, stop)
+		if err != nil {
+			sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		keyboardRows = append(keyboardRows, row)
+	}
+	button.Button = Button{getLocalized(routePlanButtonLabels, language), ROUTE_PLAN_BUTTON}
+	buttonCallbackData, err := json.Marshal(button)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not create a button %v", button),
+			slog.Any(logger.ErrorKey, err),
+		)
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	keyboardRows = append(
+		keyboardRows,
+		[]frontend.Button{frontend.NewDataButton(button.label, string(buttonCallbackData))},
+	)
+	msg := frontend.ButtonListView{
+		Text: getLocalized(routeSelectionTexts, language),
+		Rows: keyboardRows,
+	}
+	err = h.ui.Send(ctx, chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send route stops to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+// routeStop selects a building for a route or drops it.
+func (h HandlerContainer) routeStop(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	clicked, ok := parseRouteStop(frontend.Button{Data: click.Data})
+	if !ok {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			message.ID,
+			message.Chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg)
+		return fmt.Errorf("%v: %w", logMsg, ErrUnexpectedCallback)
+	}
+	keyboardRows := make([][]frontend.Button, len(message.Buttons))
+	for i, row := range message.Buttons {
+		keyboardRows[i] = row
+		if len(row) == 0 {
+			continue
+		}
+		stop, ok := parseRouteStop(row[0])
+		if !ok || stop.ID != clicked.ID {
+			continue
+		}
+		stop.Selected = !clicked.Selected
+		newRow, err := getRouteStopRow(ctx, stop)
+		if err != nil {
+			return err
+		}
+		keyboardRows[i] = newRow
+	}
+	msg := frontend.ButtonListView{Text: message.Text, Rows: keyboardRows}
+	err := h.ui.Edit(ctx, message.Chat.ID, message.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not edit a message %v: %v", message.Chat.ID, message.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+// planRoute plans a route over selected buildings of a message.
+func (h HandlerContainer) planRoute(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	message := click.Message
+	chat := message.Chat
+	button, err := parseRouteClick(ctx, click)
+	if err != nil {
+		return err
+	}
+	var buildingIDs []int64
+	for _, row := range message.Buttons {
+		for _, item := range row {
+			stop, ok := parseRouteStop(item)
+			if !ok || !stop.Selected {
+				continue
+			}
+			buildingID, err := strconv.ParseInt(stop.ID, 10, 64)
+			if err != nil {
+				continue
+			}
+			buildingIDs = append(buildingIDs, buildingID)
+		}
+	}
+	language := h.getChatLanguage(ctx, chat, click.From)
+	if len(buildingIDs) == 0 {
+		return h.SendMessage(ctx, chat.ID, getLocalized(noRouteStopsTexts, language), "")
+	}
+	var start *services.Coordinates
+	if button.Latitude != 0 || button.Longitude != 0 {
+		start = &services.Coordinates{
+			Latitude:  button.Latitude,
+			Longitude: button.Longitude,
+		}
+	}
+	route, err := h.routeService.PlanRoute(ctx, buildingIDs, start)
+	if errors.Is(err, services.ErrNoRoute) {
+		return h.SendMessage(ctx, chat.ID, getLocalized(noRouteTexts, language), "")
+	}
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	text := fmt.Sprintf(
+		getLocalized(routeHeaderTemplates, language),
+		route.DistanceMeters/1000,
+		route.WalkingTime.Minutes(),
+	)
+	if route.SkippedBuildings > 0 {
+		text += "\n" + fmt.Sprintf(
+			getLocalized(skippedBuildingsTemplates, language),
+			route.SkippedBuildings,
+		)
+	}
+	keyboardRows, err := getBuildingButtonRows(ctx, language, route.Buildings)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	for i, row := range keyboardRows {
+		row[0].Label = fmt.Sprintf("%d. %s", i+1, row[0].Label)
+	}
+	exportRow, err := getExportButtonRow(ctx)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	keyboardRows = append(keyboardRows, exportRow)
+	msg := frontend.ButtonListView{Text: text, Rows: keyboardRows}
+	err = h.ui.Send(ctx, chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send a route to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
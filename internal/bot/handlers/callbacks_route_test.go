@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func getRouteClick(data string) frontend.ButtonClick {
+	return frontend.ButtonClick{
+		ID:   "123",
+		From: &frontend.User{ID: 5},
+		Message: frontend.Message{
+			Chat: frontend.Chat{ID: 99},
+			Buttons: [][]frontend.Button{
+				{
+					frontend.NewDataButton(
+						"test 1 - name 1",
+						`{"name":"building","id":"1"}`,
+					),
+				},
+				{
+					frontend.NewDataButton(
+						"test 2 - name 2",
+						`{"name":"building","id":"2"}`,
+					),
+				},
+				{frontend.NewDataButton("Plan a walking route", data)},
+			},
+		},
+		Data: data,
+	}
+}
+
+// getRouteStopsClick clicks a button of a message with route stops
+// where the second building is not selected.
+func getRouteStopsClick(data string) frontend.ButtonClick {
+	return frontend.ButtonClick{
+		ID:   "123",
+		From: &frontend.User{ID: 5},
+		Message: frontend.Message{
+			ID:   7,
+			Chat: frontend.Chat{ID: 99},
+			Text: "Choose buildings for a route:",
+			Buttons: [][]frontend.Button{
+				{
+					frontend.NewDataButton(
+						"✅ test 1 - name 1",
+						`{"name":"stop","id":"1","on":true}`,
+					),
+				},
+				{
+					frontend.NewDataButton(
+						"⬜ test 2 - name 2",
+						`{"name":"stop","id":"2"}`,
+					),
+				},
+				{
+					frontend.NewDataButton(
+						"✅ test 3 - name 3",
+						`{"name":"stop","id":"3","on":true}`,
+					),
+				},
+				{frontend.NewDataButton("Show the route", data)},
+			},
+		},
+		Data: data,
+	}
+}
+
+func TestHandlerContainer_route(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		expectedData string
+	}{
+		{"from the first building", `{"name":"route"}`, `{"name":"routePlan"}`},
+		{
+			"from a user location",
+			`{"name":"route","lat":60.17,"lon":24.94}`,
+			`{"name":"routePlan","lat":60.17,"lon":24.94}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			click := getRouteClick(tt.data)
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+			expectedMessage := frontend.ButtonListView{
+				Text: "Choose buildings for a route:",
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"✅ test 1 - name 1",
+							`{"name":"stop","id":"1","on":true}`,
+						),
+					},
+					{
+						frontend.NewDataButton(
+							"✅ test 2 - name 2",
+							`{"name":"stop","id":"2","on":true}`,
+						),
+					},
+					{frontend.NewDataButton("Show the route", tt.expectedData)},
+				},
+			}
+			uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil).
+				On("Answer", ctx, click, "").Return(nil)
+			h := HandlerContainer{ui: uiMock, userService: userMock}
+			err := h.route(ctx, click)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_routeStop(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedRow []frontend.Button
+		rowIndex    int
+	}{
+		{
+			"drop a building",
+			`{"name":"stop","id":"1","on":true}`,
+			[]frontend.Button{
+				frontend.NewDataButton("⬜ test 1 - name 1", `{"name":"stop","id":"1"}`),
+			},
+			0,
+		},
+		{
+			"select a building",
+			`{"name":"stop","id":"2"}`,
+			[]frontend.Button{
+				frontend.NewDataButton(
+					"✅ test 2 - name 2",
+					`{"name":"stop","id":"2","on":true}`,
+				),
+			},
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			click := getRouteStopsClick(`{"name":"routePlan"}`)
+			click.Data = tt.data
+			uiMock := frontend.NewFrontend_mock(t)
+			expectedRows := [][]frontend.Button{}
+			for _, row := range click.Message.Buttons {
+				expectedRows = append(expectedRows, row)
+			}
+			expectedRows[tt.rowIndex] = tt.expectedRow
+			expectedMessage := frontend.ButtonListView{
+				Text: "Choose buildings for a route:",
+				Rows: expectedRows,
+			}
+			uiMock.EXPECT().Edit(ctx, int64(99), 7, expectedMessage).Return(nil).
+				On("Answer", ctx, click, "").Return(nil)
+			h := HandlerContainer{ui: uiMock}
+			err := h.routeStop(ctx, click)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_planRoute(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectedStart *services.Coordinates
+	}{
+		{"from the first building", `{"name":"routePlan"}`, nil},
+		{
+			"from a user location",
+			`{"name":"routePlan","lat":60.17,"lon":24.94}`,
+			&services.Coordinates{Latitude: 60.17, Longitude: 24.94},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			click := getRouteStopsClick(tt.data)
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			routeMock := services.NewRoutes_mock(t)
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+			routeMock.EXPECT().PlanRoute(ctx, []int64{1, 3}, tt.expectedStart).Return(
+				&services.RouteDTO{
+					Buildings: []services.BuildingDTO{
+						{ID: 3, Address: "test 3", NameEn: utils.GetPointer("name 3")},
+						{ID: 1, Address: "test 1", NameEn: utils.GetPointer("name 1")},
+					},
+					DistanceMeters:   1234,
+					WalkingTime:      15 * time.Minute,
+					SkippedBuildings: 1,
+				},
+				nil,
+			)
+			expectedMessage := frontend.ButtonListView{
+				Text: "Walking route: 1.2 km, about 15 min.\nBuildings without coordinates: 1.",
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"1. test 3 - name 3",
+							`{"name":"building","id":"3"}`,
+						),
+					},
+					{
+						frontend.NewDataButton(
+							"2. test 1 - name 1",
+							`{"name":"building","id":"1"}`,
+						),
+					},
+					testExportRow,
+				},
+			}
+			uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil).
+				On("Answer", ctx, click, "").Return(nil)
+			h := HandlerContainer{
+				ui:           uiMock,
+				userService:  userMock,
+				routeService: routeMock,
+			}
+			err := h.planRoute(ctx, click)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_planRoute_noRoute(t *testing.T) {
+	ctx := context.Background()
+	click := getRouteStopsClick(`{"name":"routePlan"}`)
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	routeMock := services.NewRoutes_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	routeMock.EXPECT().PlanRoute(ctx, []int64{1, 3}, (*services.Coordinates)(nil)).
+		Return(nil, services.ErrNoRoute)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "I do not know the location of these buildings."}).
+		Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		ui:           uiMock,
+		userService:  userMock,
+		routeService: routeMock,
+	}
+	err := h.planRoute(ctx, click)
+	require.NoError(t, err)
+}
+
+func TestHandlerContainer_planRoute_noStops(t *testing.T) {
+	ctx := context.Background()
+	click := getRouteStopsClick(`{"name":"routePlan"}`)
+	click.Message.Buttons = click.Message.Buttons[1:2]
+	uiMock := frontend.NewFrontend_mock(t)
+	userMock := services.NewUsers_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "Choose at least one building."}).
+		Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{ui: uiMock, userService: userMock}
+	err := h.planRoute(ctx, click)
+	require.NoError(t, err)
+}
@@ -8,110 +8,139 @@ import (
 	"log/slog"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
 	unexpectedTextTmpl = "an unexpected message text for a button " +
 		"'next': '%s': message_id: '%v': chat id: '%v'"
-	defaultLimit = 10
 )
 
 func NewCommandContainer(
-	bot InternalBot,
+	ui frontend.Frontend,
 	service services.BuildingService,
 	userService services.UserService,
+	correctionService services.CorrectionService,
+	photoService services.PhotoService,
+	chatService services.ChatService,
+	routeService services.RouteService,
+	popularityService services.PopularityService,
 	metricsContainer *metrics.Metrics,
+	settings *Settings,
+	botName string,
+	handlerTimeout time.Duration,
 ) HandlerContainer {
-	handlersPerButton := map[string]internalButtonHandler{
-		NEXT_BUTTON:     HandlerContainer.next,
-		LANGUAGE_BUTTON: HandlerContainer.language,
-		BUILDING_BUTTON: HandlerContainer.building,
+	router := NewRouter(
+		Trace(),
+		Recover(ui),
+		Log(),
+		RateLimit(ui, settings, time.Minute),
+		Authorize(ui, settings),
+		Measure(metricsContainer),
+		Timeout(handlerTimeout),
+	)
+	buttonRoutes := []struct {
+		route   Route
+		handler internalButtonHandler
+	}{
+		{Route{Name: ADDRESS_PAGE_BUTTON}, HandlerContainer.addressPage},
+		{Route{Name: NEAREST_PAGE_BUTTON}, HandlerContainer.nearestPage},
+		{Route{Name: LANGUAGE_BUTTON}, HandlerContainer.language},
+		{Route{Name: BUILDING_BUTTON}, HandlerContainer.building},
+		{Route{Name: REPORT_BUTTON}, HandlerContainer.report},
+		{Route{Name: FIELD_BUTTON}, HandlerContainer.correctionField},
+		{Route{Name: MODERATION_BUTTON, AdminOnly: true}, HandlerContainer.moderate},
+		{Route{Name: PHOTO_MODERATION_BUTTON, AdminOnly: true}, HandlerContainer.moderatePhoto},
+		{Route{Name: ROUTE_BUTTON}, HandlerContainer.route},
+		{Route{Name: ROUTE_STOP_BUTTON}, HandlerContainer.routeStop},
+		{Route{Name: ROUTE_PLAN_BUTTON}, HandlerContainer.planRoute},
+		{Route{Name: EXPORT_BUTTON}, HandlerContainer.export},
+	}
+	for _, button := range buttonRoutes {
+		router.HandleButton(button.route, button.handler)
 	}
 	availableCommands := []string{}
-	for command := range handlersPerCommand {
+	for command, handler := range handlersPerCommand {
 		availableCommands = append(availableCommands, "/"+command)
+		router.HandleCommand(Route{Name: command}, handler.Function)
 	}
 	slices.Sort(availableCommands)
 	commandsForHelp := strings.Join(availableCommands, ", ")
-	allHandlers := make(map[string]CommandHandler)
-	for command, handler := range handlersPerCommand {
-		allHandlers[command] = handler
-	}
-	allHandlers["nearestAddresses"] = CommandHandler{
-		HandlerContainer.getNearestAddresses,
-		"get the nearest addresses",
-	}
+	router.HandleCommand(Route{Name: "nearestAddresses"}, HandlerContainer.getNearestAddresses)
+	router.HandleCommand(Route{Name: "correction"}, HandlerContainer.saveCorrection)
+	router.HandleCommand(Route{Name: "moderation", AdminOnly: true}, HandlerContainer.moderation)
+	router.HandleCommand(Route{Name: "photo"}, HandlerContainer.savePhoto)
+	router.HandleCommand(Route{Name: COMMON_MESSAGE}, HandlerContainer.searchAddress)
 	return HandlerContainer{
 		service,
 		userService,
-		bot,
+		ui,
 		handlersPerCommand,
-		handlersPerButton,
 		commandsForHelp,
 		metricsContainer,
-		allHandlers,
+		correctionService,
+		settings,
+		photoService,
+		chatService,
+		handlersPerGroupCommand,
+		botName,
+		routeService,
+		router,
+		popularityService,
+		new(sync.WaitGroup),
 	}
 }
 
-func (h HandlerContainer) GetCommandHandler(command string) (func(c.Context, *tgbotapi.Message) error, bool) {
-	handler, ok := h.allHandlers[command]
-	if !ok {
-		return nil, false
-	}
-	metricWrapper := func(ctx c.Context, message *tgbotapi.Message) error {
-		now := time.Now()
-		err := handler.Function(h, ctx, message)
-		h.metrics.CommandDuration.With(
-			prometheus.Labels{"command_name": command},
-		).Observe(time.Since(now).Seconds())
-		if err != nil {
-			h.metrics.HandlerErrors.With(
-				prometheus.Labels{"handler_name": command},
-			).Inc()
-		}
-		return err
-	}
-	return metricWrapper, ok
+func (h HandlerContainer) GetCommandHandler(command string) (func(c.Context, frontend.Message) error, bool) {
+	return h.router.getCommandHandler(h, command)
 }
 
 func (h HandlerContainer) GetButtonHandler(buttonName string) (ButtonHandler, bool) {
-	handler, ok := h.handlersPerButton[buttonName]
-	if !ok {
-		return nil, false
+	return h.router.getButtonHandler(h, buttonName)
+}
+
+// HandleMessage passes a message to a handler of its kind. A message
+// without a known command is an address to search.
+func (h HandlerContainer) HandleMessage(ctx c.Context, message frontend.Message) error {
+	kind := message.Kind()
+	handlerName := message.Command
+	switch {
+	case kind == frontend.PhotoEvent:
+		handlerName = "photo"
+	case IsCorrectionReply(message):
+		handlerName = "correction"
+	case kind == frontend.LocationEvent:
+		handlerName = "nearestAddresses"
 	}
-	metricWrapper := func(ctx c.Context, query *tgbotapi.CallbackQuery) error {
-		now := time.Now()
-		err := handler(h, ctx, query)
-		h.metrics.ButtonDuration.With(
-			prometheus.Labels{"button_name": buttonName},
-		).Observe(time.Since(now).Seconds())
-		if err != nil {
-			h.metrics.HandlerErrors.With(
-				prometheus.Labels{"handler_name": buttonName},
-			).Inc()
-		}
-		return err
+	handler, ok := h.GetCommandHandler(handlerName)
+	if ok {
+		ctx = logger.WithAttrs(ctx, slog.String(logger.CommandKey, handlerName))
+		return handler(ctx, message)
 	}
-	return metricWrapper, ok
+	ctx = logger.WithAttrs(ctx, slog.String(logger.CommandKey, COMMON_MESSAGE))
+	return h.ProcessCommonMessage(ctx, message)
+}
+
+func (h HandlerContainer) ProcessCommonMessage(ctx c.Context, message frontend.Message) error {
+	handler, _ := h.router.getCommandHandler(h, COMMON_MESSAGE)
+	return handler(ctx, message)
 }
 
-func (h HandlerContainer) ProcessCommonMessage(ctx c.Context, message *tgbotapi.Message) error {
-	now := time.Now()
+func (h HandlerContainer) searchAddress(ctx c.Context, message frontend.Message) error {
 	filteredText := strings.Trim(message.Text, " ")
 	if filteredText == "" {
 		return h.SendMessage(
 			ctx,
 			message.Chat.ID,
 			"Please enter any address.",
-			tgbotapi.ModeHTML,
+			frontend.HTML,
 		)
 	}
 	if utf8.RuneCountInString(filteredText) >= MAX_MESSAGE_LENGTH {
@@ -122,25 +151,30 @@ func (h HandlerContainer) ProcessCommonMessage(ctx c.Context, message *tgbotapi.
 				"Please enter an address with less than %v characters.",
 				MAX_MESSAGE_LENGTH,
 			),
-			tgbotapi.ModeHTML,
+			frontend.HTML,
 		)
 	}
-	err := h.returnAddresses(ctx, message.Chat.ID, message.From, filteredText, defaultLimit, 0)
-	h.metrics.CommandDuration.With(
-		prometheus.Labels{"command_name": "common_message"},
-	).Observe(time.Since(now).Seconds())
-	if err != nil {
-		h.metrics.HandlerErrors.With(
-			prometheus.Labels{"handler_name": "common_message"},
-		).Inc()
+	if message.From != nil {
+		userID := message.From.ID
+		h.recordPopularity(ctx, func(ctx c.Context) error {
+			return h.popularityService.RecordSearch(
+				ctx,
+				userID,
+				metrics.AddressSearch,
+				filteredText,
+			)
+		})
 	}
-	return err
+	return h.returnAddresses(ctx, message.Chat, message.From, filteredText)
 }
 
-func (h HandlerContainer) SendMessage(ctx c.Context, chatId int64, msgText string, parseMode string) error {
-	msg := tgbotapi.NewMessage(chatId, msgText)
-	msg.ParseMode = parseMode
-	_, err := h.bot.Send(msg)
+func (h HandlerContainer) SendMessage(
+	ctx c.Context,
+	chatId int64,
+	msgText string,
+	format frontend.Format,
+) error {
+	err := h.ui.Send(ctx, chatId, frontend.TextView{Text: msgText, Format: format})
 	if err != nil {
 		slog.WarnContext(
 			ctx,
@@ -151,24 +185,19 @@ func (h HandlerContainer) SendMessage(ctx c.Context, chatId int64, msgText strin
 	return err
 }
 
-func (h HandlerContainer) start(ctx c.Context, message *tgbotapi.Message) error {
-	if message.Chat == nil {
-		return ErrNoChat
+func (h HandlerContainer) start(ctx c.Context, message frontend.Message) error {
+	if handled, err := h.handleStartPayload(ctx, message); handled {
+		return err
 	}
 	chatID := message.Chat.ID
 	startMsg := "Hello! I'm a bot that provides information about Helsinki buildings."
-	msg := tgbotapi.NewMessage(
-		chatID,
-		startMsg+"\n\n"+helpMessage,
-	)
-	locationButton := tgbotapi.NewKeyboardButtonLocation(
-		"Share my location and get the nearest buildings",
-	)
-	keyboardMarkup := tgbotapi.NewOneTimeReplyKeyboard(
-		[]tgbotapi.KeyboardButton{locationButton},
-	)
-	msg.ReplyMarkup = keyboardMarkup
-	_, err := h.bot.Send(msg)
+	msg := frontend.TextView{Text: startMsg + "\n\n" + helpMessage}
+	// Telegram allows to request a location only in private chats
+	if message.Chat.IsGroup {
+		return h.SendMessage(ctx, chatID, msg.Text, "")
+	}
+	msg.LocationRequest = "Share my location and get the nearest buildings"
+	err := h.ui.Send(ctx, chatID, msg)
 	if err != nil {
 		slog.WarnContext(
 			ctx,
@@ -179,22 +208,23 @@ func (h HandlerContainer) start(ctx c.Context, message *tgbotapi.Message) error
 	return err
 }
 
-func (h HandlerContainer) help(ctx c.Context, message *tgbotapi.Message) error {
+func (h HandlerContainer) help(ctx c.Context, message frontend.Message) error {
 	return h.SendMessage(ctx, message.Chat.ID, helpMessage, "")
 }
 
-func (h HandlerContainer) settings(ctx c.Context, message *tgbotapi.Message) error {
-	if message.Chat == nil {
-		return ErrNoChat
-	}
+func (h HandlerContainer) settings(ctx c.Context, message frontend.Message) error {
 	chatID := message.Chat.ID
 
-	msg := tgbotapi.NewMessage(chatID, "Choose a preferable language:")
-	buttons := []tgbotapi.InlineKeyboardButton{}
+	buttons := []frontend.Button{}
+	// only a member who opens group settings can change them
+	var ownerID int64
+	if message.Chat.IsGroup && message.From != nil {
+		ownerID = message.From.ID
+	}
 	languageButtons := []LanguageButton{
-		{Button{"Finnish", LANGUAGE_BUTTON}, "fi"},
-		{Button{"English", LANGUAGE_BUTTON}, "en"},
-		{Button{"Russian", LANGUAGE_BUTTON}, "ru"},
+		{Button{"Finnish", LANGUAGE_BUTTON}, "fi", ownerID},
+		{Button{"English", LANGUAGE_BUTTON}, "en", ownerID},
+		{Button{"Russian", LANGUAGE_BUTTON}, "ru", ownerID},
 	}
 	for _, button := range languageButtons {
 		buttonCallbackData, err := json.Marshal(button)
@@ -209,17 +239,17 @@ func (h HandlerContainer) settings(ctx c.Context, message *tgbotapi.Message) err
 		}
 		buttons = append(
 			buttons,
-			tgbotapi.NewInlineKeyboardButtonData(
+			frontend.NewDataButton(
 				button.label,
 				string(buttonCallbackData),
 			),
 		)
 	}
-	settingsMenuMarkup := tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(buttons...),
-	)
-	msg.ReplyMarkup = settingsMenuMarkup
-	_, err := h.bot.Send(msg)
+	msg := frontend.ButtonListView{
+		Text: "Choose a preferable language:",
+		Rows: [][]frontend.Button{buttons},
+	}
+	err := h.ui.Send(ctx, chatID, msg)
 	if err != nil {
 		slog.WarnContext(
 			ctx,
@@ -230,29 +260,58 @@ func (h HandlerContainer) settings(ctx c.Context, message *tgbotapi.Message) err
 	return err
 }
 
-func (h HandlerContainer) getAllAdresses(ctx c.Context, message *tgbotapi.Message) error {
-	return h.returnAddresses(ctx, message.Chat.ID, message.From, "", defaultLimit, 0)
+func (h HandlerContainer) getAllAdresses(ctx c.Context, message frontend.Message) error {
+	return h.returnAddresses(ctx, message.Chat, message.From, "")
 }
 
 func (h HandlerContainer) returnAddresses(
 	ctx c.Context,
-	chatID int64,
-	user *tgbotapi.User,
+	chat frontend.Chat,
+	user *frontend.User,
 	address string,
-	limit,
-	offset int,
 ) error {
+	msg, err := h.getAddressPage(ctx, chat, user, address, 1, h.runtimeSettings.Get().PageSize)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	err = h.ui.Send(ctx, chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send an inline keyboard to: %v", chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+// getAddressPage returns a page of buildings which addresses start
+// with an address. Pages start from one.
+func (h HandlerContainer) getAddressPage(
+	ctx c.Context,
+	chat frontend.Chat,
+	user *frontend.User,
+	address string,
+	page,
+	limit int,
+) (frontend.ButtonListView, error) {
 	buildings, err := h.buildingService.GetBuildings(
 		ctx,
 		address,
 		limit,
-		offset,
+		getPageOffset(page, limit),
 	)
 	if err != nil {
-		sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
-		return errors.Join(sendErr, err)
+		return frontend.ButtonListView{}, err
+	}
+	total, err := getTotal(page, limit, len(buildings), func() (int, error) {
+		return h.buildingService.CountBuildings(ctx, address)
+	})
+	if err != nil {
+		return frontend.ButtonListView{}, err
 	}
-	language := h.getPreferredLanguage(ctx, user)
+	language := h.getChatLanguage(ctx, chat, user)
 	headerTemplate := headerTemplateEnglish
 	switch language {
 	case services.Finnish:
@@ -260,73 +319,35 @@ func (h HandlerContainer) returnAddresses(
 	case services.Russian:
 		headerTemplate = headerTemplateRussian
 	}
-	title := fmt.Sprintf(headerTemplate, address)
-
-	msg := tgbotapi.NewMessage(chatID, title)
-	if len(buildings) == 0 {
-		noBuildingsText := "No buildings were found."
-		switch language {
-		case services.Finnish:
-			noBuildingsText = "Rakennuksia ei löytynyt."
-		case services.Russian:
-			noBuildingsText = "Здания не найдены."
-		}
-		msg.Text += "\n" + noBuildingsText
-		_, err = h.bot.Send(msg)
-		return err
+	msg := frontend.ButtonListView{Text: fmt.Sprintf(headerTemplate, address)}
+	if total == 0 {
+		h.observeEmptySearch(metrics.AddressSearch)
+		msg.Text += "\n" + getLocalized(noBuildingsTexts, language)
+		return msg, nil
+	}
+	pageCount := getPageCount(total, limit)
+	if pageCount > 1 {
+		msg.Text += "\n" + getPageText(page, pageCount, language)
 	}
 	keyboardRows, err := getBuildingButtonRows(ctx, language, buildings)
 	if err != nil {
-		slog.ErrorContext(
-			ctx,
-			fmt.Sprintf("can not create building rows: '%v'", address),
-			slog.Any(logger.ErrorKey, err),
-		)
-		return err
+		return frontend.ButtonListView{}, err
 	}
-	if len(buildings) < limit {
-		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
-		_, err = h.bot.Send(msg)
+	if len(buildings) > 0 {
+		exportRow, err := getExportButtonRow(ctx)
 		if err != nil {
-			slog.WarnContext(ctx, err.Error())
+			return frontend.ButtonListView{}, err
 		}
-		return err
+		keyboardRows = append(keyboardRows, exportRow)
 	}
-
-	nextButtonTemplate := "Next %v buildings"
-	switch language {
-	case services.Finnish:
-		nextButtonTemplate = "Seuraavat %v rakennusta"
-	case services.Russian:
-		nextButtonTemplate = "Следующие %v зданий"
-	}
-	button := NextButton{
-		Button{fmt.Sprintf(nextButtonTemplate, limit), NEXT_BUTTON},
-		limit,
-		offset + len(buildings),
-	}
-	buttonCallbackData, err := json.Marshal(button)
+	pageButton := PageButton{Button: Button{Name: ADDRESS_PAGE_BUTTON}, Page: page}
+	pageRow, err := getPageRow(ctx, pageButton, pageCount, language)
 	if err != nil {
-		slog.ErrorContext(
-			ctx,
-			fmt.Sprintf("can not create a button %v", button),
-			slog.Any(logger.ErrorKey, err),
-		)
-		return err
+		return frontend.ButtonListView{}, err
 	}
-	buttonData := tgbotapi.NewInlineKeyboardButtonData(
-		button.label,
-		string(buttonCallbackData),
-	)
-	keyboardRows = append(keyboardRows, tgbotapi.NewInlineKeyboardRow(buttonData))
-	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
-	_, err = h.bot.Send(msg)
-	if err != nil {
-		slog.WarnContext(
-			ctx,
-			fmt.Sprintf("can not send an inline keyboard to: %v", chatID),
-			slog.Any(logger.ErrorKey, err),
-		)
+	if len(pageRow) > 0 {
+		keyboardRows = append(keyboardRows, pageRow)
 	}
-	return err
+	msg.Rows = keyboardRows
+	return msg, nil
 }
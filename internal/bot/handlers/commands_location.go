@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 const (
-	DEFAULT_DISTANCE                  = 100
 	noNearestBuildingsEnglishTemplate = "Unfortunately, I'm not aware of any buildings located within %v metres of your location."
 	noNearestBuildingsFinnishTemplate = "Valitettavasti minulla ei ole tietoa yhdestäkään rakennuksesta %v metrin säteellä sinusta."
 	noNearestBuildingsRussianTemplate = "К сожалению, у меня нет информации ни об одном здании в радиусе %v метров от вас."
@@ -21,28 +22,158 @@ const (
 	nearestBuildingsRussianTemplate   = "Список зданий, расположенных в радиусе %v метров от вас:"
 )
 
-func (h HandlerContainer) getNearestAddresses(ctx c.Context, message *tgbotapi.Message) error {
-	if message.Chat == nil {
-		return ErrNoChat
+var compassArrows = []string{"↑", "↗", "→", "↘", "↓", "↙", "←", "↖"}
+var metreTemplates = map[services.Language]string{
+	services.Finnish: "%.0f m",
+	services.English: "%.0f m",
+	services.Russian: "%.0f м",
+}
+var kilometreTemplates = map[services.Language]string{
+	services.Finnish: "%.1f km",
+	services.English: "%.1f km",
+	services.Russian: "%.1f км",
+}
+var walkingTimeTemplates = map[services.Language]string{
+	services.Finnish: "Kävelyaika: enintään %v min.",
+	services.English: "Walking time: up to %v min.",
+	services.Russian: "Время в пути пешком: до %v мин.",
+}
+
+// getCompassArrow returns one of eight arrows for a bearing in degrees.
+func getCompassArrow(bearing float64) string {
+	index := int(math.Round(bearing/45)) % len(compassArrows)
+	return compassArrows[index]
+}
+
+func getDistanceText(distanceMeters float64, language services.Language) string {
+	if distanceMeters < 1000 {
+		// a location of a phone is not more precise
+		rounded := math.Round(distanceMeters/10) * 10
+		return fmt.Sprintf(getLocalized(metreTemplates, language), rounded)
+	}
+	return fmt.Sprintf(getLocalized(kilometreTemplates, language), distanceMeters/1000)
+}
+
+// getDirectionLabel returns a distance and a compass direction from a user
+// to a building, for example "120 m ↗".
+func getDirectionLabel(
+	building services.BuildingDTO,
+	start services.Coordinates,
+	language services.Language,
+) string {
+	if building.Latitude == nil || building.Longitude == nil {
+		return ""
 	}
+	location := services.Coordinates{
+		Latitude:  *building.Latitude,
+		Longitude: *building.Longitude,
+	}
+	distance := services.GetDistance(start, location)
+	if building.DistanceMeters != nil {
+		distance = *building.DistanceMeters
+	}
+	arrow := getCompassArrow(services.GetBearing(start, location))
+	return getDistanceText(distance, language) + " " + arrow
+}
+
+// getWalkingTimeLine returns a walking time to the farthest building.
+func getWalkingTimeLine(
+	buildings []services.BuildingDTO,
+	language services.Language,
+) string {
+	var maxDistance *float64
+	for _, building := range buildings {
+		distance := building.DistanceMeters
+		if distance != nil && (maxDistance == nil || *distance > *maxDistance) {
+			maxDistance = distance
+		}
+	}
+	if maxDistance == nil {
+		return ""
+	}
+	minutes := max(1, int(services.GetWalkingTime(*maxDistance).Minutes()))
+	return fmt.Sprintf(getLocalized(walkingTimeTemplates, language), minutes)
+}
+
+func (h HandlerContainer) getNearestAddresses(ctx c.Context, message frontend.Message) error {
 	location := message.Location
 	if location == nil {
 		return ErrNoLocation
 	}
+	start := services.Coordinates{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+	msg, err := h.getNearestPage(ctx, message.Chat, message.From, start, 1)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	err = h.ui.Send(ctx, message.Chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send nearest addresses to: %v", message.Chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func (h HandlerContainer) nearestPage(ctx c.Context, click frontend.ButtonClick) error {
+	defer h.getCallbackAnswerFunc(ctx, click)()
+	button, err := parsePageClick(ctx, click)
+	if err != nil {
+		return err
+	}
+	message := click.Message
+	start := services.Coordinates{
+		Latitude:  button.Latitude,
+		Longitude: button.Longitude,
+	}
+	page, err := h.getNearestPage(ctx, message.Chat, click.From, start, button.Page)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	return h.editListPage(ctx, message, page)
+}
+
+// getNearestPage returns a page of buildings around a start point.
+// Pages start from one.
+func (h HandlerContainer) getNearestPage(
+	ctx c.Context,
+	chat frontend.Chat,
+	user *frontend.User,
+	start services.Coordinates,
+	page int,
+) (frontend.ButtonListView, error) {
+	settings := h.runtimeSettings.Get()
 	buildings, err := h.buildingService.GetNearestBuildings(
 		ctx,
-		DEFAULT_DISTANCE,
-		location.Latitude,
-		location.Longitude,
-		defaultLimit,
-		0,
+		settings.SearchRadius,
+		start.Latitude,
+		start.Longitude,
+		settings.PageSize,
+		getPageOffset(page, settings.PageSize),
 	)
 	if err != nil {
-		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
-		return errors.Join(sendErr, err)
+		return frontend.ButtonListView{}, err
+	}
+	total, err := getTotal(page, settings.PageSize, len(buildings), func() (int, error) {
+		return h.buildingService.CountNearestBuildings(
+			ctx,
+			settings.SearchRadius,
+			start.Latitude,
+			start.Longitude,
+		)
+	})
+	if err != nil {
+		return frontend.ButtonListView{}, err
 	}
-	language := h.getPreferredLanguage(ctx, message.From)
-	if len(buildings) == 0 {
+	language := h.getChatLanguage(ctx, chat, user)
+	if total == 0 {
+		h.observeEmptySearch(metrics.NearestSearch)
 		responseTemplate := noNearestBuildingsEnglishTemplate
 		switch language {
 		case services.Finnish:
@@ -50,8 +181,8 @@ func (h HandlerContainer) getNearestAddresses(ctx c.Context, message *tgbotapi.M
 		case services.Russian:
 			responseTemplate = noNearestBuildingsRussianTemplate
 		}
-		msg := fmt.Sprintf(responseTemplate, DEFAULT_DISTANCE)
-		return h.SendMessage(ctx, message.Chat.ID, msg, "")
+		text := fmt.Sprintf(responseTemplate, settings.SearchRadius)
+		return frontend.ButtonListView{Text: text}, nil
 	}
 	titleTemplate := nearestBuildingsEnglishTemplate
 	switch language {
@@ -60,20 +191,53 @@ func (h HandlerContainer) getNearestAddresses(ctx c.Context, message *tgbotapi.M
 	case services.Russian:
 		titleTemplate = nearestBuildingsRussianTemplate
 	}
-	title := fmt.Sprintf(titleTemplate, DEFAULT_DISTANCE)
-	msg := tgbotapi.NewMessage(message.Chat.ID, title)
+	title := fmt.Sprintf(titleTemplate, settings.SearchRadius)
+	if walkingTime := getWalkingTimeLine(buildings, language); walkingTime != "" {
+		title += "\n" + walkingTime
+	}
+	pageCount := getPageCount(total, settings.PageSize)
+	if pageCount > 1 {
+		title += "\n" + getPageText(page, pageCount, language)
+	}
+	msg := frontend.ButtonListView{Text: title}
 	keyboardRows, err := getBuildingButtonRows(ctx, language, buildings)
 	if err != nil {
-		return err
+		return frontend.ButtonListView{}, err
+	}
+	for i, row := range keyboardRows {
+		prefix := getDirectionLabel(buildings[i], start, language)
+		if prefix != "" {
+			row[0].Label = prefix + " " + row[0].Label
+		}
+	}
+	if len(buildings) > 1 {
+		routeRow, err := getRouteButtonRow(ctx, language, &start)
+		if err != nil {
+			return frontend.ButtonListView{}, err
+		}
+		keyboardRows = append(keyboardRows, routeRow)
+	}
+	if len(buildings) > 0 {
+		exportRow, err := getExportButtonRow(ctx)
+		if err != nil {
+			return frontend.ButtonListView{}, err
+		}
+		keyboardRows = append(keyboardRows, exportRow)
 	}
-	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(keyboardRows...)
-	_, err = h.bot.Send(msg)
+	pageButton := PageButton{
+		Button: Button{Name: NEAREST_PAGE_BUTTON},
+		Page:   page,
+		// the precision is about one metre and callback data stays short
+		Latitude:  math.Round(start.Latitude*1e5) / 1e5,
+		Longitude: math.Round(start.Longitude*1e5) / 1e5,
+	}
+	pageRow, err := getPageRow(ctx, pageButton, pageCount, language)
 	if err != nil {
-		slog.WarnContext(
-			ctx,
-			fmt.Sprintf("can not send nearest addresses to: %v", message.Chat.ID),
-			slog.Any(logger.ErrorKey, err),
-		)
+		return frontend.ButtonListView{}, err
 	}
-	return err
+	if len(pageRow) > 0 {
+		keyboardRows = append(keyboardRows, pageRow)
+	}
+	msg.Rows = keyboardRows
+	return msg, nil
 }
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
 	"github.com/AndreyAD1/helsinki-guide/internal/utils"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -17,9 +18,8 @@ import (
 func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 	type fields struct {
 		buildingService    *services.Buildings_mock
-		bot                *InternalBot_mock
+		ui                 *frontend.Frontend_mock
 		HandlersPerCommand map[string]CommandHandler
-		handlersPerButton  map[string]internalButtonHandler
 		commandsForHelp    string
 		metrics            *metrics.Metrics
 	}
@@ -35,41 +35,38 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 		args             args
 		buildingPreviews []services.BuildingDTO
 		buildingError    error
-		expectedMsg      tgbotapi.MessageConfig
+		expectedMsg      frontend.View
 		expectedError    error
 	}{
 		{
 			"building error",
 			fields{
 				services.NewBuildings_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
 				nil,
 			},
 			args{chatID: 123, latitude: 3, longitude: 3},
 			[]services.BuildingDTO{},
 			serviceError,
-			tgbotapi.NewMessage(123, "Internal error"),
+			frontend.TextView{Text: "Internal error"},
 			serviceError,
 		},
 		{
 			"no buildings",
 			fields{
 				services.NewBuildings_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
 			args{chatID: 123, latitude: 3, longitude: 3},
 			[]services.BuildingDTO{},
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsEnglishTemplate, testSearchRadius),
 			},
 			nil,
 		},
@@ -77,9 +74,8 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 			"one building",
 			fields{
 				services.NewBuildings_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
 				nil,
 			},
@@ -92,21 +88,12 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 				},
 			},
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID:           123,
-					ReplyToMessageID: 0,
-					ReplyMarkup: tgbotapi.InlineKeyboardMarkup{
-						InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{{
-							tgbotapi.InlineKeyboardButton{
-								Text:         "test 1 - test name 1",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"999"}`),
-							},
-						}},
-					},
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(nearestBuildingsEnglishTemplate, testSearchRadius),
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("test 1 - test name 1", `{"name":"building","id":"999"}`)},
+					testExportRow,
 				},
-				Text:                  fmt.Sprintf(nearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
-				DisableWebPagePreview: false,
 			},
 			nil,
 		},
@@ -114,9 +101,8 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 			"two buildings",
 			fields{
 				services.NewBuildings_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
 				nil,
 			},
@@ -126,25 +112,53 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 				{Address: "test 2", NameEn: utils.GetPointer("test name 2"), ID: 999},
 			},
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID:           123,
-					ReplyToMessageID: 0,
-					ReplyMarkup: tgbotapi.InlineKeyboardMarkup{
-						InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{{
-							tgbotapi.InlineKeyboardButton{
-								Text:         "test 1 - test name 1",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"1000"}`),
-							}},
-							{tgbotapi.InlineKeyboardButton{
-								Text:         "test 2 - test name 2",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"999"}`),
-							}},
-						},
-					},
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(nearestBuildingsEnglishTemplate, testSearchRadius),
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("test 1 - test name 1", `{"name":"building","id":"1000"}`)},
+					{frontend.NewDataButton("test 2 - test name 2", `{"name":"building","id":"999"}`)},
+					{frontend.NewDataButton("Plan a walking route", `{"name":"route","lat":3}`)},
+					testExportRow,
+				},
+			},
+			nil,
+		},
+		{
+			"buildings with distances",
+			fields{
+				services.NewBuildings_mock(t),
+				frontend.NewFrontend_mock(t),
+				map[string]CommandHandler{},
+				"",
+				nil,
+			},
+			args{chatID: 123, latitude: 60.17, longitude: 24.94},
+			[]services.BuildingDTO{
+				{
+					Address:        "test 1",
+					NameEn:         utils.GetPointer("test name 1"),
+					ID:             1000,
+					Latitude:       utils.GetPointer(60.171),
+					Longitude:      utils.GetPointer(24.942),
+					DistanceMeters: utils.GetPointer(156.3),
+				},
+				{
+					Address:        "test 2",
+					NameEn:         utils.GetPointer("test name 2"),
+					ID:             999,
+					DistanceMeters: utils.GetPointer(170.0),
+				},
+			},
+			nil,
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(nearestBuildingsEnglishTemplate, testSearchRadius) +
+					"\nWalking time: up to 2 min.",
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("160 m ↗ test 1 - test name 1", `{"name":"building","id":"1000"}`)},
+					{frontend.NewDataButton("test 2 - test name 2", `{"name":"building","id":"999"}`)},
+					{frontend.NewDataButton("Plan a walking route", `{"name":"route","lat":60.17,"lon":24.94}`)},
+					testExportRow,
 				},
-				Text:                  fmt.Sprintf(nearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
-				DisableWebPagePreview: false,
 			},
 			nil,
 		},
@@ -154,33 +168,33 @@ func TestHandlerContainer_getNearestAddresses(t *testing.T) {
 			ctx := context.Background()
 			tt.fields.buildingService.EXPECT().GetNearestBuildings(
 				ctx,
-				DEFAULT_DISTANCE,
+				testSearchRadius,
 				tt.args.latitude,
 				tt.args.longitude,
-				defaultLimit,
+				testPageSize,
 				0,
 			).Return(tt.buildingPreviews, tt.buildingError)
 
-			tt.fields.bot.EXPECT().
-				Send(tt.expectedMsg).Return(tgbotapi.Message{}, nil)
+			tt.fields.ui.EXPECT().
+				Send(ctx, tt.args.chatID, tt.expectedMsg).Return(nil)
 			h := HandlerContainer{
 				buildingService:    tt.fields.buildingService,
-				bot:                tt.fields.bot,
+				ui:                 tt.fields.ui,
 				HandlersPerCommand: tt.fields.HandlersPerCommand,
-				handlersPerButton:  tt.fields.handlersPerButton,
 				commandsForHelp:    tt.fields.commandsForHelp,
 				metrics:            tt.fields.metrics,
+				runtimeSettings:    newTestSettings(),
 			}
-			message := tgbotapi.Message{
-				Chat: &tgbotapi.Chat{ID: tt.args.chatID},
+			message := frontend.Message{
+				Chat: frontend.Chat{ID: tt.args.chatID},
 			}
 			if tt.args.latitude != 0 {
-				message.Location = &tgbotapi.Location{
+				message.Location = &frontend.Location{
 					Latitude:  tt.args.latitude,
 					Longitude: tt.args.longitude,
 				}
 			}
-			err := h.getNearestAddresses(ctx, &message)
+			err := h.getNearestAddresses(ctx, message)
 			require.ErrorIs(t, err, tt.expectedError)
 		})
 	}
@@ -197,7 +211,7 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 		buildings      []services.BuildingDTO
 		storedLanguage *services.Language
 		userError      error
-		expectedMsg    tgbotapi.MessageConfig
+		expectedMsg    frontend.View
 	}{
 		{
 			"no buildings - English - no configured language",
@@ -205,9 +219,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsEnglishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -216,9 +229,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsEnglishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -227,9 +239,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsRussianTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsRussianTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -238,9 +249,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			&services.English,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsEnglishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -249,9 +259,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			&services.Finnish,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsFinnishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsFinnishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -260,9 +269,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			&services.Russian,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsRussianTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsRussianTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -271,9 +279,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			&services.English,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsEnglishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsEnglishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -282,9 +289,8 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			[]services.BuildingDTO{},
 			&services.English,
 			fmt.Errorf("test error"),
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{ChatID: 123},
-				Text:     fmt.Sprintf(noNearestBuildingsFinnishTemplate, DEFAULT_DISTANCE),
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(noNearestBuildingsFinnishTemplate, testSearchRadius),
 			},
 		},
 		{
@@ -296,25 +302,14 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			},
 			&services.Finnish,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID:           123,
-					ReplyToMessageID: 0,
-					ReplyMarkup: tgbotapi.InlineKeyboardMarkup{
-						InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{{
-							tgbotapi.InlineKeyboardButton{
-								Text:         "test 1 - nimi 1",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"1000"}`),
-							}},
-							{tgbotapi.InlineKeyboardButton{
-								Text:         "test 2 - nimi 2",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"999"}`),
-							}},
-						},
-					},
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(nearestBuildingsFinnishTemplate, testSearchRadius),
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("test 1 - nimi 1", `{"name":"building","id":"1000"}`)},
+					{frontend.NewDataButton("test 2 - nimi 2", `{"name":"building","id":"999"}`)},
+					{frontend.NewDataButton("Suunnittele kävelyreitti", `{"name":"route","lat":60,"lon":30}`)},
+					testExportRow,
 				},
-				Text:                  fmt.Sprintf(nearestBuildingsFinnishTemplate, DEFAULT_DISTANCE),
-				DisableWebPagePreview: false,
 			},
 		},
 		{
@@ -326,25 +321,14 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			},
 			&services.Russian,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID:           123,
-					ReplyToMessageID: 0,
-					ReplyMarkup: tgbotapi.InlineKeyboardMarkup{
-						InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{{
-							tgbotapi.InlineKeyboardButton{
-								Text:         "test 1 - имя 1",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"1000"}`),
-							}},
-							{tgbotapi.InlineKeyboardButton{
-								Text:         "test 2 - имя 2",
-								CallbackData: utils.GetPointer(`{"name":"building","id":"999"}`),
-							}},
-						},
-					},
+			frontend.ButtonListView{
+				Text: fmt.Sprintf(nearestBuildingsRussianTemplate, testSearchRadius),
+				Rows: [][]frontend.Button{
+					{frontend.NewDataButton("test 1 - имя 1", `{"name":"building","id":"1000"}`)},
+					{frontend.NewDataButton("test 2 - имя 2", `{"name":"building","id":"999"}`)},
+					{frontend.NewDataButton("Построить пешеходный маршрут", `{"name":"route","lat":60,"lon":30}`)},
+					testExportRow,
 				},
-				Text:                  fmt.Sprintf(nearestBuildingsRussianTemplate, DEFAULT_DISTANCE),
-				DisableWebPagePreview: false,
 			},
 		},
 	}
@@ -352,7 +336,7 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buildingService := services.NewBuildings_mock(t)
 			userService := services.NewUsers_mock(t)
-			bot := NewInternalBot_mock(t)
+			uiMock := frontend.NewFrontend_mock(t)
 
 			ctx := context.Background()
 			buildingService.EXPECT().GetNearestBuildings(
@@ -363,7 +347,7 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 				mock.Anything,
 				mock.Anything,
 			).Return(tt.buildings, nil)
-			bot.EXPECT().Send(tt.expectedMsg).Return(tgbotapi.Message{}, nil)
+			uiMock.EXPECT().Send(ctx, int64(123), tt.expectedMsg).Return(nil)
 
 			userService.EXPECT().GetPreferredLanguage(ctx, tt.args.userID).
 				Return(tt.storedLanguage, tt.userError)
@@ -371,22 +355,62 @@ func TestHandlerContainer_getNearestAddresses_languages(t *testing.T) {
 			h := HandlerContainer{
 				buildingService:    buildingService,
 				userService:        userService,
-				bot:                bot,
+				ui:                 uiMock,
 				HandlersPerCommand: map[string]CommandHandler{},
-				handlersPerButton:  map[string]internalButtonHandler{},
 				commandsForHelp:    "",
-				metrics:            nil,
+				metrics:            metrics.NewMetrics(prometheus.NewRegistry()),
+				runtimeSettings:    newTestSettings(),
 			}
-			message := tgbotapi.Message{
-				Chat: &tgbotapi.Chat{ID: int64(123)},
-				From: &tgbotapi.User{ID: tt.args.userID, LanguageCode: tt.args.userLanguage},
-				Location: &tgbotapi.Location{
+			message := frontend.Message{
+				Chat: frontend.Chat{ID: int64(123)},
+				From: &frontend.User{ID: tt.args.userID, LanguageCode: tt.args.userLanguage},
+				Location: &frontend.Location{
 					Latitude:  float64(60),
 					Longitude: float64(30),
 				},
 			}
-			err := h.getNearestAddresses(ctx, &message)
+			err := h.getNearestAddresses(ctx, message)
 			require.NoError(t, err)
 		})
 	}
 }
+
+func TestGetCompassArrow(t *testing.T) {
+	tests := []struct {
+		bearing  float64
+		expected string
+	}{
+		{0, "↑"},
+		{22, "↑"},
+		{23, "↗"},
+		{90, "→"},
+		{180, "↓"},
+		{225, "↙"},
+		{300, "↖"},
+		{350, "↑"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.bearing), func(t *testing.T) {
+			require.Equal(t, tt.expected, getCompassArrow(tt.bearing))
+		})
+	}
+}
+
+func TestGetDistanceText(t *testing.T) {
+	tests := []struct {
+		distance float64
+		language services.Language
+		expected string
+	}{
+		{4, services.English, "0 m"},
+		{124, services.English, "120 m"},
+		{125, services.Russian, "130 м"},
+		{1240, services.Finnish, "1.2 km"},
+		{2500, services.Russian, "2.5 км"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			require.Equal(t, tt.expected, getDistanceText(tt.distance, tt.language))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	c "context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+const (
+	MAX_POPULAR_BUILDINGS = 10
+	// popularityWriteTimeout limits a write which outlives a handler
+	popularityWriteTimeout = 5 * time.Second
+)
+
+var popularHeaders = map[services.Language]string{
+	services.Finnish: "Viikon katsotuimmat rakennukset:",
+	services.English: "The most viewed buildings of the week:",
+	services.Russian: "Самые популярные здания недели:",
+}
+var popularNeighbourhoodTemplates = map[services.Language]string{
+	services.Finnish: "Viikon katsotuimmat rakennukset, kaupunginosa %s:",
+	services.English: "The most viewed buildings of the week in %s:",
+	services.Russian: "Самые популярные здания недели, район %s:",
+}
+
+// popular returns the most viewed buildings of the week. A command argument
+// is an optional neighbourhood name.
+func (h HandlerContainer) popular(ctx c.Context, message frontend.Message) error {
+	chatID := message.Chat.ID
+	neighbourhood := strings.TrimSpace(message.Text)
+	if utf8.RuneCountInString(neighbourhood) >= MAX_MESSAGE_LENGTH {
+		return h.SendMessage(
+			ctx,
+			chatID,
+			fmt.Sprintf(
+				"Please enter a neighbourhood with less than %v characters.",
+				MAX_MESSAGE_LENGTH,
+			),
+			"",
+		)
+	}
+	buildings, err := h.popularityService.GetPopularBuildings(
+		ctx,
+		neighbourhood,
+		MAX_POPULAR_BUILDINGS,
+	)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+		return errors.Join(sendErr, err)
+	}
+	language := h.getChatLanguage(ctx, message.Chat, message.From)
+	header := getLocalized(popularHeaders, language)
+	if neighbourhood != "" {
+		header = fmt.Sprintf(
+			getLocalized(popularNeighbourhoodTemplates, language),
+			neighbourhood,
+		)
+	}
+	msg := frontend.ButtonListView{Text: header}
+	if len(buildings) == 0 {
+		msg.Text += "\n" + getLocalized(noBuildingsTexts, language)
+	} else {
+		keyboardRows, err := getBuildingButtonRows(ctx, language, buildings)
+		if err != nil {
+			sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		exportRow, err := getExportButtonRow(ctx)
+		if err != nil {
+			sendErr := h.SendMessage(ctx, chatID, "Internal error", "")
+			return errors.Join(sendErr, err)
+		}
+		keyboardRows = append(keyboardRows, exportRow)
+		msg.Rows = keyboardRows
+	}
+	err = h.ui.Send(ctx, chatID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send popular buildings to: %v", chatID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+// recordPopularity saves a popularity event in the background so that
+// a reply does not wait for it. A failed write is only logged.
+func (h HandlerContainer) recordPopularity(ctx c.Context, record func(c.Context) error) {
+	h.analytics.Add(1)
+	go func() {
+		defer h.analytics.Done()
+		ctx, cancel := c.WithTimeout(c.WithoutCancel(ctx), popularityWriteTimeout)
+		defer cancel()
+		if err := record(ctx); err != nil {
+			slog.WarnContext(
+				ctx,
+				"can not record a popularity event",
+				slog.Any(logger.ErrorKey, err),
+			)
+		}
+	}()
+}
+
+// Wait waits for popularity writes started by handlers.
+func (h HandlerContainer) Wait() {
+	h.analytics.Wait()
+}
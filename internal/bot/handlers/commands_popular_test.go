@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func getPopularMessage(arguments string) frontend.Message {
+	return frontend.Message{
+		Chat:    frontend.Chat{ID: 99},
+		From:    &frontend.User{ID: 5},
+		Text:    arguments,
+		Command: "popular",
+	}
+}
+
+func TestHandlerContainer_popular(t *testing.T) {
+	buildings := []services.BuildingDTO{
+		{ID: 1, Address: "test street 1", NameEn: utils.GetPointer("test building")},
+	}
+	buildingRows := [][]frontend.Button{
+		{
+			frontend.NewDataButton(
+				"test street 1 - test building",
+				`{"name":"building","id":"1"}`,
+			),
+		},
+		testExportRow,
+	}
+	tests := []struct {
+		name              string
+		arguments         string
+		neighbourhood     string
+		preferredLanguage *services.Language
+		buildings         []services.BuildingDTO
+		expectedText      string
+		expectedRows      [][]frontend.Button
+	}{
+		{
+			"all neighbourhoods",
+			"",
+			"",
+			nil,
+			buildings,
+			"The most viewed buildings of the week:",
+			buildingRows,
+		},
+		{
+			"a neighbourhood",
+			"  Lauttasaari ",
+			"Lauttasaari",
+			nil,
+			buildings,
+			"The most viewed buildings of the week in Lauttasaari:",
+			buildingRows,
+		},
+		{
+			"no views",
+			"Munkkiniemi",
+			"Munkkiniemi",
+			&services.Finnish,
+			[]services.BuildingDTO{},
+			"Viikon katsotuimmat rakennukset, kaupunginosa Munkkiniemi:\n" +
+				"Rakennuksia ei löytynyt.",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			userMock := services.NewUsers_mock(t)
+			popularityMock := services.NewPopularity_mock(t)
+			popularityMock.EXPECT().
+				GetPopularBuildings(ctx, tt.neighbourhood, MAX_POPULAR_BUILDINGS).
+				Return(tt.buildings, nil)
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).
+				Return(tt.preferredLanguage, nil)
+			expectedMessage := frontend.ButtonListView{
+				Text: tt.expectedText,
+				Rows: tt.expectedRows,
+			}
+			uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil)
+			h := HandlerContainer{
+				userService:       userMock,
+				ui:                uiMock,
+				popularityService: popularityMock,
+			}
+			err := h.popular(ctx, getPopularMessage(tt.arguments))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_popular_errors(t *testing.T) {
+	ctx := context.Background()
+	serviceErr := errors.New("test error")
+	uiMock := frontend.NewFrontend_mock(t)
+	popularityMock := services.NewPopularity_mock(t)
+	popularityMock.EXPECT().GetPopularBuildings(ctx, "", MAX_POPULAR_BUILDINGS).
+		Return(nil, serviceErr)
+	uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: "Internal error"}).
+		Return(nil)
+	uiMock.EXPECT().
+		Send(ctx, int64(99), frontend.TextView{Text: "Please enter a neighbourhood with less than 50 characters."}).
+		Return(nil)
+	h := HandlerContainer{ui: uiMock, popularityService: popularityMock}
+
+	err := h.popular(ctx, getPopularMessage(""))
+	require.ErrorIs(t, err, serviceErr)
+	err = h.popular(ctx, getPopularMessage(strings.Repeat("a", 50)))
+	require.NoError(t, err)
+}
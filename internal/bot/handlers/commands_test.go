@@ -3,21 +3,24 @@ package handlers
 import (
 	c "context"
 	"errors"
+	"sync"
 	"testing"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
 	"github.com/AndreyAD1/helsinki-guide/internal/utils"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func TestHandlerContainer_returnAddresses(t *testing.T) {
+func TestHandlerContainer_getAddressPage(t *testing.T) {
 	type fields struct {
 		buildingService    *services.Buildings_mock
 		userService        *services.Users_mock
-		bot                *InternalBot_mock
+		ui                 *frontend.Frontend_mock
 		HandlersPerCommand map[string]CommandHandler
-		handlersPerButton  map[string]internalButtonHandler
 		commandsForHelp    string
 		metrics            *metrics.Metrics
 	}
@@ -25,329 +28,334 @@ func TestHandlerContainer_returnAddresses(t *testing.T) {
 		ctx     c.Context
 		chatID  int64
 		address string
+		page    int
 		limit   int
-		offset  int
-		user    *tgbotapi.User
+		user    *frontend.User
 	}
 	tests := []struct {
 		name           string
 		fields         fields
 		args           args
 		buildings      []services.BuildingDTO
+		total          int
 		buildingError  error
 		storedLanguage *services.Language
 		userError      error
-		expectedMsg    tgbotapi.MessageConfig
+		expectedMsg    frontend.ButtonListView
 	}{
 		{
 			"a building error",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123},
+			args{chatID: 123, page: 1, limit: 1},
 			[]services.BuildingDTO{},
+			0,
 			errors.New("some error"),
 			nil,
 			nil,
-			tgbotapi.NewMessage(123, "Internal error"),
+			frontend.ButtonListView{},
 		},
 		{
 			"no buildings - no address - no language",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 1, user: &tgbotapi.User{ID: int64(3), LanguageCode: "en"}},
+			args{chatID: 123, page: 1, limit: 1, user: &frontend.User{ID: int64(3), LanguageCode: "en"}},
 			[]services.BuildingDTO{},
+			0,
 			nil,
 			nil,
 			nil,
-			tgbotapi.NewMessage(123, `Search address: 
+			frontend.ButtonListView{Text: `Search address: 
 Available building addresses and names:
-No buildings were found.`),
+No buildings were found.`},
 		},
 		{
 			"no buildings - no address - default Finnish",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 1, user: &tgbotapi.User{ID: int64(3), LanguageCode: "fi"}},
+			args{chatID: 123, page: 1, limit: 1, user: &frontend.User{ID: int64(3), LanguageCode: "fi"}},
 			[]services.BuildingDTO{},
+			0,
 			nil,
 			nil,
 			nil,
-			tgbotapi.NewMessage(123, `Osoite: 
+			frontend.ButtonListView{Text: `Osoite: 
 Tuntemani rakennukset:
-Rakennuksia ei löytynyt.`),
+Rakennuksia ei löytynyt.`},
 		},
 		{
 			"no buildings - no address - configured Russian",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 1, user: &tgbotapi.User{ID: int64(3), LanguageCode: "fr"}},
+			args{chatID: 123, page: 1, limit: 1, user: &frontend.User{ID: int64(3), LanguageCode: "fr"}},
 			[]services.BuildingDTO{},
+			0,
 			nil,
 			&services.Russian,
 			nil,
-			tgbotapi.NewMessage(123, `Адрес: 
+			frontend.ButtonListView{Text: `Адрес: 
 Известные мне здания:
-Здания не найдены.`),
+Здания не найдены.`},
 		},
 		{
-			"several buildings and address, no offset",
+			"one page",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 3, offset: 0, address: "test"},
+			args{chatID: 123, page: 1, limit: 3, address: "test"},
 			[]services.BuildingDTO{
 				{ID: 1, Address: "test 1", NameEn: utils.GetPointer("test name 1")},
 				{ID: 2, Address: "test 2", NameEn: utils.GetPointer("test name 2")},
 			},
+			2,
 			nil,
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID: 123,
-					ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup(
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 1 - test name 1",
-								`{"name":"building","id":"1"}`,
-							),
+			frontend.ButtonListView{
+				Text: `Search address: test
+Available building addresses and names:`,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test 1 - test name 1",
+							`{"name":"building","id":"1"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 2 - test name 2",
-								`{"name":"building","id":"2"}`,
-							),
+					},
+					{
+						frontend.NewDataButton(
+							"test 2 - test name 2",
+							`{"name":"building","id":"2"}`,
 						),
-					),
+					},
+					testExportRow,
 				},
-				Text: `Search address: test
-Available building addresses and names:`,
 			},
 		},
 		{
-			"several buildings and address, offset",
+			"the last page",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 3, offset: 1, address: "test"},
+			args{chatID: 123, page: 2, limit: 3, address: "test"},
 			[]services.BuildingDTO{
 				{ID: 2, Address: "test 1", NameEn: utils.GetPointer("test name 1")},
 				{ID: 3, Address: "test 2", NameEn: utils.GetPointer("test name 2")},
 			},
+			5,
 			nil,
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID: 123,
-					ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup(
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 1 - test name 1",
-								`{"name":"building","id":"2"}`,
-							),
+			frontend.ButtonListView{
+				Text: `Search address: test
+Available building addresses and names:
+Page 2 of 2.`,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test 1 - test name 1",
+							`{"name":"building","id":"2"}`,
+						),
+					},
+					{
+						frontend.NewDataButton(
+							"test 2 - test name 2",
+							`{"name":"building","id":"3"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 2 - test name 2",
-								`{"name":"building","id":"3"}`,
-							),
+					},
+					testExportRow,
+					{
+						frontend.NewDataButton(
+							"« Previous",
+							`{"name":"addresses","page":1}`,
 						),
-					),
+					},
 				},
-				Text: `Search address: test
-Available building addresses and names:`,
 			},
 		},
 		{
-			"several buildings and address, offset, button",
+			"the first page",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
-			args{chatID: 123, limit: 2, offset: 1, address: "test"},
+			args{chatID: 123, page: 1, limit: 2, address: "test"},
 			[]services.BuildingDTO{
 				{ID: 1, Address: "test 1", NameEn: utils.GetPointer("test name 1")},
 				{ID: 2, Address: "test 2", NameEn: utils.GetPointer("test name 2")},
 			},
+			5,
 			nil,
 			nil,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID: 123,
-					ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup(
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 1 - test name 1",
-								`{"name":"building","id":"1"}`,
-							),
+			frontend.ButtonListView{
+				Text: `Search address: test
+Available building addresses and names:
+Page 1 of 3.`,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test 1 - test name 1",
+							`{"name":"building","id":"1"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 2 - test name 2",
-								`{"name":"building","id":"2"}`,
-							),
+					},
+					{
+						frontend.NewDataButton(
+							"test 2 - test name 2",
+							`{"name":"building","id":"2"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"Next 2 buildings",
-								`{"name":"next","limit":2,"offset":3}`,
-							),
+					},
+					testExportRow,
+					{
+						frontend.NewDataButton(
+							"Next »",
+							`{"name":"addresses","page":2}`,
 						),
-					),
+					},
 				},
-				Text: `Search address: test
-Available building addresses and names:`,
 			},
 		},
 		{
-			"several buildings and address, offset, button - Finnish",
+			"the first page - Finnish",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
 			args{
 				chatID:  123,
+				page:    1,
 				limit:   2,
-				offset:  1,
 				address: "test",
-				user:    &tgbotapi.User{ID: int64(4), LanguageCode: "ch"}},
+				user:    &frontend.User{ID: int64(4), LanguageCode: "ch"}},
 			[]services.BuildingDTO{
 				{ID: 1, Address: "test 1", NameFi: utils.GetPointer("nimi 1"), NameEn: utils.GetPointer("test name 1")},
 				{ID: 2, Address: "test 2", NameFi: utils.GetPointer("nimi 2"), NameEn: utils.GetPointer("test name 2")},
 			},
+			5,
 			nil,
 			&services.Finnish,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID: 123,
-					ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup(
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 1 - nimi 1",
-								`{"name":"building","id":"1"}`,
-							),
+			frontend.ButtonListView{
+				Text: `Osoite: test
+Tuntemani rakennukset:
+Sivu 1/3.`,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test 1 - nimi 1",
+							`{"name":"building","id":"1"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 2 - nimi 2",
-								`{"name":"building","id":"2"}`,
-							),
+					},
+					{
+						frontend.NewDataButton(
+							"test 2 - nimi 2",
+							`{"name":"building","id":"2"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"Seuraavat 2 rakennusta",
-								`{"name":"next","limit":2,"offset":3}`,
-							),
+					},
+					testExportRow,
+					{
+						frontend.NewDataButton(
+							"Seuraava »",
+							`{"name":"addresses","page":2}`,
 						),
-					),
+					},
 				},
-				Text: `Osoite: test
-Tuntemani rakennukset:`,
 			},
 		},
 		{
-			"several buildings and address, offset, button - Russian",
+			"a middle page - Russian",
 			fields{
 				services.NewBuildings_mock(t),
 				services.NewUsers_mock(t),
-				NewInternalBot_mock(t),
+				frontend.NewFrontend_mock(t),
 				map[string]CommandHandler{},
-				map[string]internalButtonHandler{},
 				"",
-				nil,
+				metrics.NewMetrics(prometheus.NewRegistry()),
 			},
 			args{
 				chatID:  123,
+				page:    2,
 				limit:   2,
-				offset:  1,
 				address: "test",
-				user:    &tgbotapi.User{ID: int64(4), LanguageCode: "en"}},
+				user:    &frontend.User{ID: int64(4), LanguageCode: "en"}},
 			[]services.BuildingDTO{
 				{ID: 1, Address: "test 1", NameRu: utils.GetPointer("имя 1"), NameEn: utils.GetPointer("test name 1")},
 				{ID: 2, Address: "test 2", NameRu: utils.GetPointer("имя 2"), NameEn: utils.GetPointer("test name 2")},
 			},
+			6,
 			nil,
 			&services.Russian,
 			nil,
-			tgbotapi.MessageConfig{
-				BaseChat: tgbotapi.BaseChat{
-					ChatID: 123,
-					ReplyMarkup: tgbotapi.NewInlineKeyboardMarkup(
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 1 - имя 1",
-								`{"name":"building","id":"1"}`,
-							),
+			frontend.ButtonListView{
+				Text: `Адрес: test
+Известные мне здания:
+Страница 2 из 3.`,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test 1 - имя 1",
+							`{"name":"building","id":"1"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"test 2 - имя 2",
-								`{"name":"building","id":"2"}`,
-							),
+					},
+					{
+						frontend.NewDataButton(
+							"test 2 - имя 2",
+							`{"name":"building","id":"2"}`,
 						),
-						tgbotapi.NewInlineKeyboardRow(
-							tgbotapi.NewInlineKeyboardButtonData(
-								"Следующие 2 зданий",
-								`{"name":"next","limit":2,"offset":3}`,
-							),
+					},
+					testExportRow,
+					{
+						frontend.NewDataButton(
+							"« Назад",
+							`{"name":"addresses","page":1}`,
 						),
-					),
+						frontend.NewDataButton(
+							"Далее »",
+							`{"name":"addresses","page":3}`,
+						),
+					},
 				},
-				Text: `Адрес: test
-Известные мне здания:`,
 			},
 		},
 	}
@@ -357,10 +365,12 @@ Tuntemani rakennukset:`,
 				tt.args.ctx,
 				tt.args.address,
 				tt.args.limit,
-				tt.args.offset,
+				(tt.args.page-1)*tt.args.limit,
 			).Return(tt.buildings, tt.buildingError)
-			tt.fields.bot.EXPECT().
-				Send(tt.expectedMsg).Return(tgbotapi.Message{}, nil)
+			tt.fields.buildingService.EXPECT().
+				CountBuildings(tt.args.ctx, tt.args.address).
+				Return(tt.total, nil).
+				Maybe()
 			if tt.args.user != nil {
 				tt.fields.userService.EXPECT().
 					GetPreferredLanguage(tt.args.ctx, tt.args.user.ID).
@@ -369,20 +379,73 @@ Tuntemani rakennukset:`,
 			h := HandlerContainer{
 				buildingService:    tt.fields.buildingService,
 				userService:        tt.fields.userService,
-				bot:                tt.fields.bot,
+				ui:                 tt.fields.ui,
 				HandlersPerCommand: tt.fields.HandlersPerCommand,
-				handlersPerButton:  tt.fields.handlersPerButton,
 				commandsForHelp:    tt.fields.commandsForHelp,
 				metrics:            tt.fields.metrics,
 			}
-			h.returnAddresses(
+			got, err := h.getAddressPage(
 				tt.args.ctx,
-				tt.args.chatID,
+				frontend.Chat{ID: tt.args.chatID},
 				tt.args.user,
 				tt.args.address,
+				tt.args.page,
 				tt.args.limit,
-				tt.args.offset,
 			)
+			require.ErrorIs(t, err, tt.buildingError)
+			require.Equal(t, tt.expectedMsg, got)
 		})
 	}
 }
+
+func TestHandlerContainer_returnAddresses(t *testing.T) {
+	ctx := c.Background()
+	buildingService := services.NewBuildings_mock(t)
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingError := errors.New("some error")
+	buildingService.EXPECT().
+		GetBuildings(ctx, "test", testPageSize, 0).
+		Return(nil, buildingError)
+	uiMock.EXPECT().
+		Send(ctx, int64(123), frontend.TextView{Text: "Internal error"}).
+		Return(nil)
+	h := HandlerContainer{
+		buildingService: buildingService,
+		ui:              uiMock,
+		runtimeSettings: newTestSettings(),
+	}
+	err := h.returnAddresses(ctx, frontend.Chat{ID: 123}, nil, "test")
+	require.ErrorIs(t, err, buildingError)
+}
+
+func TestHandlerContainer_searchAddress_recordSearch(t *testing.T) {
+	ctx := c.Background()
+	buildingService := services.NewBuildings_mock(t)
+	popularityService := services.NewPopularity_mock(t)
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingError := errors.New("some error")
+	popularityService.EXPECT().
+		RecordSearch(mock.Anything, int64(5), metrics.AddressSearch, "test").
+		Return(errors.New("a popularity error"))
+	buildingService.EXPECT().
+		GetBuildings(ctx, "test", testPageSize, 0).
+		Return(nil, buildingError)
+	uiMock.EXPECT().
+		Send(ctx, int64(123), frontend.TextView{Text: "Internal error"}).
+		Return(nil)
+	h := HandlerContainer{
+		buildingService:   buildingService,
+		ui:                uiMock,
+		popularityService: popularityService,
+		runtimeSettings:   newTestSettings(),
+		analytics:         new(sync.WaitGroup),
+	}
+	message := frontend.Message{
+		Chat: frontend.Chat{ID: 123},
+		From: &frontend.User{ID: 5},
+		Text: " test ",
+	}
+	err := h.searchAddress(ctx, message)
+	require.ErrorIs(t, err, buildingError)
+	h.Wait()
+}
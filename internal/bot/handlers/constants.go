@@ -12,12 +12,22 @@ I am aware of buildings located in these Helsinki neighbourhoods: Munkkiniemi, M
 Available commands:
 /start - I will send a greeting message.
 /addresses - I will return all addresses I know.
+/popular - I will return the most viewed buildings of the week. Add a neighbourhood name to narrow the list, for example, /popular Lauttasaari.
 /settings - I will return a menu so that you can manage your preferences.
 /help - I will show this message.`
-	BUILDING_BUTTON    = "building"
-	NEXT_BUTTON        = "next"
-	LANGUAGE_BUTTON    = "language"
-	MAX_MESSAGE_LENGTH = 50
+	BUILDING_BUTTON         = "building"
+	ADDRESS_PAGE_BUTTON     = "addresses"
+	NEAREST_PAGE_BUTTON     = "nearest"
+	LANGUAGE_BUTTON         = "language"
+	REPORT_BUTTON           = "report"
+	FIELD_BUTTON            = "field"
+	MODERATION_BUTTON       = "moderate"
+	PHOTO_MODERATION_BUTTON = "moderatePhoto"
+	ROUTE_BUTTON            = "route"
+	ROUTE_STOP_BUTTON       = "stop"
+	ROUTE_PLAN_BUTTON       = "routePlan"
+	EXPORT_BUTTON           = "export"
+	MAX_MESSAGE_LENGTH      = 50
 )
 
 var handlersPerCommand = map[string]CommandHandler{
@@ -25,6 +35,13 @@ var handlersPerCommand = map[string]CommandHandler{
 	"help":      {HandlerContainer.help, "Get help"},
 	"settings":  {HandlerContainer.settings, "Configure settings"},
 	"addresses": {HandlerContainer.getAllAdresses, "Get all available addresses"},
+	"popular":   {HandlerContainer.popular, "Get the most viewed buildings of the week"},
+}
+var handlersPerGroupCommand = map[string]CommandHandler{
+	"help":      {HandlerContainer.help, "Get help"},
+	"settings":  {HandlerContainer.settings, "Configure chat settings"},
+	"addresses": {HandlerContainer.getAllAdresses, "Get all available addresses"},
+	"popular":   {HandlerContainer.popular, "Get the most viewed buildings of the week"},
 }
 var languageCodes = map[string]string{
 	"fi": "Finnish",
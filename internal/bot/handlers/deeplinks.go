@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	c "context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+const (
+	BUILDING_PAYLOAD      = "b_"
+	NEIGHBOURHOOD_PAYLOAD = "n_"
+	AUTHOR_PAYLOAD        = "a_"
+	deepLinkTemplate      = "https://t.me/%s?start=%s"
+	shareURLTemplate      = "https://t.me/share/url?url=%s"
+	// an inline keyboard can not contain more than 100 buttons
+	MAX_LINKED_BUILDINGS = 50
+)
+
+var shareButtonLabels = map[services.Language]string{
+	services.Finnish: "Jaa",
+	services.English: "Share",
+	services.Russian: "Поделиться",
+}
+var neighbourhoodHeaders = map[services.Language]string{
+	services.Finnish: "Kaupunginosan rakennukset:",
+	services.English: "Buildings in the neighbourhood:",
+	services.Russian: "Здания в районе:",
+}
+var authorHeaders = map[services.Language]string{
+	services.Finnish: "Suunnittelijan rakennukset:",
+	services.English: "Buildings by the author:",
+	services.Russian: "Здания архитектора:",
+}
+var noBuildingsTexts = map[services.Language]string{
+	services.Finnish: "Rakennuksia ei löytynyt.",
+	services.English: "No buildings were found.",
+	services.Russian: "Здания не найдены.",
+}
+
+// GetDeepLink returns a link that opens a private chat with the bot
+// and sends the command /start with a payload.
+func GetDeepLink(botName, payload string) string {
+	return fmt.Sprintf(deepLinkTemplate, botName, payload)
+}
+
+func (h HandlerContainer) getBuildingCardRow(
+	ctx c.Context,
+	language services.Language,
+	buildingID int64,
+) ([]frontend.Button, error) {
+	row, err := getReportButtonRow(ctx, language, buildingID)
+	if err != nil {
+		return nil, err
+	}
+	if h.botName == "" {
+		return row, nil
+	}
+	link := GetDeepLink(
+		h.botName,
+		BUILDING_PAYLOAD+strconv.FormatInt(buildingID, 10),
+	)
+	shareButton := frontend.NewURLButton(
+		getLocalized(shareButtonLabels, language),
+		fmt.Sprintf(shareURLTemplate, url.QueryEscape(link)),
+	)
+	return append(row, shareButton), nil
+}
+
+// handleStartPayload opens a card or a list a deep link refers to.
+// The function reports false if a payload is unknown.
+func (h HandlerContainer) handleStartPayload(
+	ctx c.Context,
+	message frontend.Message,
+) (bool, error) {
+	payload := strings.TrimSpace(message.Text)
+	var prefix string
+	for _, knownPrefix := range []string{
+		BUILDING_PAYLOAD,
+		NEIGHBOURHOOD_PAYLOAD,
+		AUTHOR_PAYLOAD,
+	} {
+		if strings.HasPrefix(payload, knownPrefix) {
+			prefix = knownPrefix
+			break
+		}
+	}
+	if prefix == "" {
+		return false, nil
+	}
+	itemID, err := strconv.ParseInt(strings.TrimPrefix(payload, prefix), 10, 64)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("unexpected start payload '%v'", payload))
+		return false, nil
+	}
+	if prefix == BUILDING_PAYLOAD {
+		err := h.sendBuildingCard(ctx, message.Chat, message.From, itemID)
+		if errors.Is(err, ErrNoBuilding) {
+			return true, nil
+		}
+		return true, err
+	}
+	getBuildings, headers := h.buildingService.GetBuildingsByAuthor, authorHeaders
+	searchType := metrics.AuthorSearch
+	if prefix == NEIGHBOURHOOD_PAYLOAD {
+		getBuildings = h.buildingService.GetBuildingsByNeighbourhood
+		headers = neighbourhoodHeaders
+		searchType = metrics.NeighbourhoodSearch
+	}
+	buildings, err := getBuildings(ctx, itemID, MAX_LINKED_BUILDINGS, 0)
+	if err != nil {
+		sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+		return true, errors.Join(sendErr, err)
+	}
+	language := h.getChatLanguage(ctx, message.Chat, message.From)
+	msg := frontend.ButtonListView{Text: getLocalized(headers, language)}
+	if len(buildings) == 0 {
+		h.observeEmptySearch(searchType)
+		msg.Text += "\n" + getLocalized(noBuildingsTexts, language)
+	} else {
+		keyboardRows, err := getBuildingButtonRows(ctx, language, buildings)
+		if err != nil {
+			sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+			return true, errors.Join(sendErr, err)
+		}
+		if len(buildings) > 1 {
+			routeRow, err := getRouteButtonRow(ctx, language, nil)
+			if err != nil {
+				sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+				return true, errors.Join(sendErr, err)
+			}
+			keyboardRows = append(keyboardRows, routeRow)
+		}
+		exportRow, err := getExportButtonRow(ctx)
+		if err != nil {
+			sendErr := h.SendMessage(ctx, message.Chat.ID, "Internal error", "")
+			return true, errors.Join(sendErr, err)
+		}
+		keyboardRows = append(keyboardRows, exportRow)
+		msg.Rows = keyboardRows
+	}
+	err = h.ui.Send(ctx, message.Chat.ID, msg)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not send buildings for '%v' to: %v", payload, message.Chat.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return true, err
+}
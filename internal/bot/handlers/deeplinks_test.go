@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func getStartMessage(payload string) frontend.Message {
+	return frontend.Message{
+		Chat:    frontend.Chat{ID: 99},
+		From:    &frontend.User{ID: 5},
+		Text:    payload,
+		Command: "start",
+	}
+}
+
+func TestHandlerContainer_start_buildingPayload(t *testing.T) {
+	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	userMock := services.NewUsers_mock(t)
+	photoMock := services.NewPhotos_mock(t)
+	buildingMock.EXPECT().GetBuildingByID(ctx, int64(12)).Return(
+		&services.BuildingDTO{ID: 12, Address: "test address"},
+		nil,
+	)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	photoMock.EXPECT().GetBuildingPhotos(ctx, int64(12)).Return(nil, nil)
+	text, err := SerializeIntoMessage(
+		services.BuildingDTO{ID: 12, Address: "test address"},
+		services.English,
+	)
+	require.NoError(t, err)
+	expectedMessage := frontend.ButtonListView{
+		Text:   text,
+		Format: frontend.HTML,
+		Rows: [][]frontend.Button{
+			{
+				frontend.NewDataButton(
+					"Report a mistake",
+					`{"name":"report","id":"12"}`,
+				),
+				frontend.NewURLButton(
+					"Share",
+					"https://t.me/share/url?url=https%3A%2F%2Ft.me%2FHelsinkiGuide_bot%3Fstart%3Db_12",
+				),
+			},
+		},
+	}
+	uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil)
+	popularityMock := services.NewPopularity_mock(t)
+	popularityMock.EXPECT().RecordView(mock.Anything, int64(5), int64(12)).Return(nil)
+	h := HandlerContainer{
+		buildingService:   buildingMock,
+		userService:       userMock,
+		photoService:      photoMock,
+		ui:                uiMock,
+		botName:           "HelsinkiGuide_bot",
+		metrics:           metrics.NewMetrics(prometheus.NewRegistry()),
+		popularityService: popularityMock,
+		analytics:         new(sync.WaitGroup),
+	}
+	err = h.start(ctx, getStartMessage("b_12"))
+	require.NoError(t, err)
+	h.Wait()
+	require.Equal(t, float64(1), testutil.ToFloat64(h.metrics.BuildingViews))
+}
+
+func TestHandlerContainer_start_listPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      string
+		expectedText string
+	}{
+		{"neighbourhood", "n_3", "Buildings in the neighbourhood:"},
+		{"author", "a_3", "Buildings by the author:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			buildingMock := services.NewBuildings_mock(t)
+			userMock := services.NewUsers_mock(t)
+			buildings := []services.BuildingDTO{
+				{ID: 1, Address: "test street 1", NameEn: utils.GetPointer("test building")},
+			}
+			if tt.payload[0] == 'n' {
+				buildingMock.EXPECT().
+					GetBuildingsByNeighbourhood(ctx, int64(3), MAX_LINKED_BUILDINGS, 0).
+					Return(buildings, nil)
+			} else {
+				buildingMock.EXPECT().
+					GetBuildingsByAuthor(ctx, int64(3), MAX_LINKED_BUILDINGS, 0).
+					Return(buildings, nil)
+			}
+			userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+			expectedMessage := frontend.ButtonListView{
+				Text: tt.expectedText,
+				Rows: [][]frontend.Button{
+					{
+						frontend.NewDataButton(
+							"test street 1 - test building",
+							`{"name":"building","id":"1"}`,
+						),
+					},
+					testExportRow,
+				},
+			}
+			uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil)
+			h := HandlerContainer{
+				buildingService: buildingMock,
+				userService:     userMock,
+				ui:              uiMock,
+			}
+			err := h.start(ctx, getStartMessage(tt.payload))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHandlerContainer_start_emptyListPayload(t *testing.T) {
+	ctx := context.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	buildingMock := services.NewBuildings_mock(t)
+	userMock := services.NewUsers_mock(t)
+	buildingMock.EXPECT().
+		GetBuildingsByNeighbourhood(ctx, int64(3), MAX_LINKED_BUILDINGS, 0).
+		Return(nil, nil)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil)
+	expectedMessage := frontend.ButtonListView{
+		Text: "Buildings in the neighbourhood:\n" + noBuildingsTexts[services.English],
+	}
+	uiMock.EXPECT().Send(ctx, int64(99), expectedMessage).Return(nil)
+	h := HandlerContainer{
+		buildingService: buildingMock,
+		userService:     userMock,
+		ui:              uiMock,
+		metrics:         metrics.NewMetrics(prometheus.NewRegistry()),
+	}
+	err := h.start(ctx, getStartMessage("n_3"))
+	require.NoError(t, err)
+	emptySearches := h.metrics.EmptySearches.WithLabelValues(metrics.NeighbourhoodSearch)
+	require.Equal(t, float64(1), testutil.ToFloat64(emptySearches))
+}
+
+func TestHandlerContainer_handleStartPayload_unknown(t *testing.T) {
+	h := HandlerContainer{}
+	for _, payload := range []string{"", "x_1", "b_abc"} {
+		handled, err := h.handleStartPayload(context.Background(), getStartMessage(payload))
+		require.NoError(t, err)
+		require.False(t, handled, payload)
+	}
+}
@@ -3,7 +3,13 @@ package handlers
 import "errors"
 
 var (
-	ErrNoChat             = errors.New("a message contains no chat")
 	ErrNoLocation         = errors.New("a message contains no location")
 	ErrUnexpectedCallback = errors.New("a callback contains unexpected info")
+	ErrNoCorrectionReply  = errors.New("a message is not a reply to a correction request")
+	ErrNotAdmin           = errors.New("a user is not an administrator")
+	ErrNoPhoto            = errors.New("a message contains no photo")
+	ErrNoBuilding         = errors.New("a building does not exist")
+	ErrPanic              = errors.New("a handler panicked")
+	ErrRateLimited        = errors.New("a user exceeded a rate limit")
+	ErrNotSettingsOwner   = errors.New("a user did not open group settings")
 )
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	c "context"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+// getChatLanguage returns a language of a group chat if chat members
+// have chosen it. Otherwise, the function returns a user language.
+func (h HandlerContainer) getChatLanguage(
+	ctx c.Context,
+	chat frontend.Chat,
+	user *frontend.User,
+) services.Language {
+	if chat.IsGroup {
+		chatLanguage, err := h.chatService.GetPreferredLanguage(ctx, chat.ID)
+		if err == nil && chatLanguage != nil {
+			return *chatLanguage
+		}
+	}
+	return h.getPreferredLanguage(ctx, user)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerContainer_getChatLanguage(t *testing.T) {
+	ctx := context.Background()
+	russian := services.Russian
+	chatMock := services.NewChats_mock(t)
+	chatMock.EXPECT().GetPreferredLanguage(ctx, int64(-100)).Return(&russian, nil).
+		Once().
+		On("GetPreferredLanguage", ctx, int64(-200)).Return(nil, nil).
+		Once()
+	userMock := services.NewUsers_mock(t)
+	userMock.EXPECT().GetPreferredLanguage(ctx, int64(5)).Return(nil, nil).Times(2)
+	h := HandlerContainer{userService: userMock, chatService: chatMock}
+	user := &frontend.User{ID: 5, LanguageCode: "fi"}
+
+	language := h.getChatLanguage(ctx, frontend.Chat{ID: -100, IsGroup: true}, user)
+	require.Equal(t, services.Russian, language)
+	language = h.getChatLanguage(ctx, frontend.Chat{ID: -200, IsGroup: true}, user)
+	require.Equal(t, services.Finnish, language)
+	language = h.getChatLanguage(ctx, frontend.Chat{ID: 5}, user)
+	require.Equal(t, services.Finnish, language)
+}
+
+func TestHandlerContainer_language_group(t *testing.T) {
+	ctx := context.Background()
+	click := frontend.ButtonClick{
+		ID:   "123",
+		From: &frontend.User{ID: 5},
+		Message: frontend.Message{
+			ID:   44,
+			Chat: frontend.Chat{ID: -100, IsGroup: true},
+		},
+		Data: `{"name":"language","value":"fi","user":5}`,
+	}
+	chatMock := services.NewChats_mock(t)
+	chatMock.EXPECT().SetLanguage(ctx, int64(-100), services.Finnish).Return(nil)
+	uiMock := frontend.NewFrontend_mock(t)
+	editedMessage := frontend.ButtonListView{
+		Text: "I will return the building information in Finnish.",
+	}
+	uiMock.EXPECT().Edit(ctx, int64(-100), 44, editedMessage).Return(nil).
+		On("Answer", ctx, click, "").Return(nil)
+	h := HandlerContainer{
+		ui:          uiMock,
+		userService: services.NewUsers_mock(t),
+		chatService: chatMock,
+		metrics:     metrics.NewMetrics(prometheus.NewRegistry()),
+	}
+	err := h.language(ctx, click)
+	require.NoError(t, err)
+	changes := h.metrics.SettingsChanges.WithLabelValues("chat_language", "fi")
+	require.Equal(t, float64(1), testutil.ToFloat64(changes))
+}
+
+func TestHandlerContainer_language_groupNotOwner(t *testing.T) {
+	for _, data := range []string{
+		`{"name":"language","value":"fi","user":6}`,
+		`{"name":"language","value":"fi"}`,
+	} {
+		t.Run(data, func(t *testing.T) {
+			ctx := context.Background()
+			click := frontend.ButtonClick{
+				ID:   "123",
+				From: &frontend.User{ID: 5},
+				Message: frontend.Message{
+					ID:   44,
+					Chat: frontend.Chat{ID: -100, IsGroup: true},
+				},
+				Data: data,
+			}
+			uiMock := frontend.NewFrontend_mock(t)
+			uiMock.EXPECT().Answer(ctx, click, notSettingsOwnerText).Return(nil)
+			h := HandlerContainer{
+				ui:          uiMock,
+				chatService: services.NewChats_mock(t),
+				metrics:     metrics.NewMetrics(prometheus.NewRegistry()),
+			}
+			err := h.language(ctx, click)
+			require.ErrorIs(t, err, ErrNotSettingsOwner)
+		})
+	}
+}
+
+func TestHandlerContainer_settings_group(t *testing.T) {
+	ctx := context.Background()
+	message := frontend.Message{
+		Chat: frontend.Chat{ID: -100, IsGroup: true},
+		From: &frontend.User{ID: 5},
+	}
+	uiMock := frontend.NewFrontend_mock(t)
+	expected := frontend.ButtonListView{
+		Text: "Choose a preferable language:",
+		Rows: [][]frontend.Button{{
+			frontend.NewDataButton("Finnish", `{"name":"language","value":"fi","user":5}`),
+			frontend.NewDataButton("English", `{"name":"language","value":"en","user":5}`),
+			frontend.NewDataButton("Russian", `{"name":"language","value":"ru","user":5}`),
+		}},
+	}
+	uiMock.EXPECT().Send(ctx, int64(-100), expected).Return(nil)
+	h := HandlerContainer{ui: uiMock}
+	require.NoError(t, h.settings(ctx, message))
+}
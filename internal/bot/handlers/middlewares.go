@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	c "context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/middlewares"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	rateLimitText = "Too many requests. Please try again later."
+	notAdminText  = "This command is available only to administrators."
+)
+
+// Recover turns a panic of a handler into an error and
+// tells a user about the error.
+func Recover(ui frontend.Frontend) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) (err error) {
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				slog.ErrorContext(
+					ctx,
+					fmt.Sprintf("catch a panic in the handler %v", request.Route.Name),
+					slog.Any("panic", p),
+					slog.String("stack", string(debug.Stack())),
+				)
+				err = fmt.Errorf("%w: %v", ErrPanic, p)
+				chat := request.Chat()
+				if chat == nil {
+					return
+				}
+				sendErr := ui.Send(ctx, chat.ID, frontend.TextView{Text: "Internal error"})
+				err = errors.Join(sendErr, err)
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// Trace starts a span for every handler.
+func Trace() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			ctx, span := tracing.Start(
+				ctx,
+				"handler "+request.Route.Name,
+				attribute.String("handler.kind", string(request.Route.Kind)),
+			)
+			err := next(ctx, request)
+			tracing.End(span, err)
+			return err
+		}
+	}
+}
+
+// Log writes a record about every handled request. A context of a request
+// already contains a chat and a user.
+func Log() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			start := time.Now()
+			err := next(ctx, request)
+			attributes := []any{
+				slog.String("handler", request.Route.Name),
+				slog.String("kind", string(request.Route.Kind)),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attributes = append(attributes, slog.Any(logger.ErrorKey, err))
+				slog.WarnContext(ctx, "a request failed", attributes...)
+				return err
+			}
+			slog.InfoContext(ctx, "handle a request", attributes...)
+			return nil
+		}
+	}
+}
+
+// Measure records a duration and errors of every handler and
+// counts active users.
+func Measure(m *metrics.Metrics) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			start := time.Now()
+			err := next(ctx, request)
+			name := request.Route.Name
+			seconds := time.Since(start).Seconds()
+			if request.Route.Kind == ButtonRoute {
+				m.ButtonDuration.With(prometheus.Labels{"button_name": name}).Observe(seconds)
+			} else {
+				m.CommandDuration.With(prometheus.Labels{"command_name": name}).Observe(seconds)
+			}
+			if err != nil {
+				m.HandlerErrors.With(prometheus.Labels{"handler_name": name}).Inc()
+			}
+			if user := request.User(); user != nil {
+				m.ActiveUsers.Observe(user.ID)
+			}
+			return err
+		}
+	}
+}
+
+// Timeout limits a duration of every handler. A zero timeout means no limit.
+func Timeout(timeout time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		if timeout <= 0 {
+			return next
+		}
+		return func(ctx c.Context, request Request) error {
+			ctx, cancel := c.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := next(ctx, request)
+			if errors.Is(ctx.Err(), c.DeadlineExceeded) {
+				slog.WarnContext(
+					ctx,
+					fmt.Sprintf("the handler %v exceeded the timeout %v", request.Route.Name, timeout),
+				)
+			}
+			return err
+		}
+	}
+}
+
+// RateLimit allows a user to send no more than a current rate limit
+// of requests within a period. A zero limit means no limit.
+func RateLimit(ui frontend.Frontend, settings *Settings, period time.Duration) Middleware {
+	limiter := middlewares.NewRateLimiter[int64](period, time.Now)
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			limit := settings.Get().RateLimit
+			user := request.User()
+			if limit <= 0 || user == nil || limiter.Allow(user.ID, limit) {
+				return next(ctx, request)
+			}
+			slog.WarnContext(ctx, fmt.Sprintf("the user %v exceeded the rate limit", user.ID))
+			return errors.Join(reject(ctx, ui, request, rateLimitText), ErrRateLimited)
+		}
+	}
+}
+
+// Authorize allows only current administrators to use admin handlers.
+func Authorize(ui frontend.Frontend, settings *Settings) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			if !request.Route.AdminOnly || isAdmin(settings.Get().AdminIDs, request.User()) {
+				return next(ctx, request)
+			}
+			slog.WarnContext(
+				ctx,
+				fmt.Sprintf("an unauthorized request to %v: %v", request.Route.Name, request.User()),
+			)
+			return errors.Join(reject(ctx, ui, request, notAdminText), ErrNotAdmin)
+		}
+	}
+}
+
+// reject answers a click or a message without calling a handler.
+func reject(ctx c.Context, ui frontend.Frontend, request Request, text string) error {
+	var err error
+	if request.Click != nil {
+		// an unanswered click leaves a button in a loading state
+		err = ui.Answer(ctx, *request.Click, text)
+	} else if chat := request.Chat(); chat != nil {
+		err = ui.Send(ctx, chat.ID, frontend.TextView{Text: text})
+	}
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not reject a request to %v", request.Route.Name),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
+
+func isAdmin(adminIDs []int64, user *frontend.User) bool {
+	if user == nil {
+		return false
+	}
+	return slices.Contains(adminIDs, user.ID)
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	c "context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing/tracingtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func getTestMessageRequest(name string, userID int64) Request {
+	return Request{
+		Route: Route{Name: name, Kind: CommandRoute},
+		Message: &frontend.Message{
+			Chat: frontend.Chat{ID: 99},
+			From: &frontend.User{ID: userID},
+		},
+	}
+}
+
+func getTestClickRequest(name string, userID int64) Request {
+	return Request{
+		Route: Route{Name: name, Kind: ButtonRoute},
+		Click: &frontend.ButtonClick{
+			ID:      "123",
+			From:    &frontend.User{ID: userID},
+			Message: frontend.Message{Chat: frontend.Chat{ID: 99}},
+		},
+	}
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      Request
+		expectedSend bool
+	}{
+		{"a message", getTestMessageRequest("test", 5), true},
+		{"a click", getTestClickRequest("test", 5), true},
+		{"no chat", Request{Route: Route{Name: "test"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := c.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			if tt.expectedSend {
+				uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: "Internal error"}).
+					Return(nil)
+			}
+			handler := Recover(uiMock)(func(c.Context, Request) error {
+				panic("test panic")
+			})
+			err := handler(ctx, tt.request)
+			require.ErrorIs(t, err, ErrPanic)
+		})
+	}
+}
+
+func TestRecover_noPanic(t *testing.T) {
+	expectedErr := errors.New("test error")
+	handler := Recover(frontend.NewFrontend_mock(t))(func(c.Context, Request) error {
+		return expectedErr
+	})
+	err := handler(c.Background(), getTestMessageRequest("test", 5))
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        Request
+		adminOnly      bool
+		expectedCalled bool
+		expectedErr    error
+	}{
+		{"a public command", getTestMessageRequest("test", 5), false, true, nil},
+		{"an admin command", getTestMessageRequest("test", 1), true, true, nil},
+		{"an admin button", getTestClickRequest("test", 1), true, true, nil},
+		{"a message from a user", getTestMessageRequest("test", 5), true, false, ErrNotAdmin},
+		{"a click from a user", getTestClickRequest("test", 5), true, false, ErrNotAdmin},
+		{
+			"no user",
+			Request{Message: &frontend.Message{Chat: frontend.Chat{ID: 99}}},
+			true,
+			false,
+			ErrNotAdmin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := c.Background()
+			uiMock := frontend.NewFrontend_mock(t)
+			if !tt.expectedCalled && tt.request.Click != nil {
+				uiMock.EXPECT().Answer(ctx, *tt.request.Click, notAdminText).Return(nil)
+			}
+			if !tt.expectedCalled && tt.request.Message != nil {
+				uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: notAdminText}).
+					Return(nil)
+			}
+			called := false
+			handler := Authorize(uiMock, NewSettings(RuntimeSettings{AdminIDs: []int64{1}}))(func(c.Context, Request) error {
+				called = true
+				return nil
+			})
+			tt.request.Route.AdminOnly = tt.adminOnly
+			err := handler(ctx, tt.request)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Equal(t, tt.expectedCalled, called)
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ctx := c.Background()
+	uiMock := frontend.NewFrontend_mock(t)
+	uiMock.EXPECT().Send(ctx, int64(99), frontend.TextView{Text: rateLimitText}).
+		Return(nil).Once()
+	uiMock.EXPECT().Answer(ctx, *getTestClickRequest("test", 5).Click, rateLimitText).
+		Return(nil).Once()
+	calls := 0
+	settings := NewSettings(RuntimeSettings{RateLimit: 2})
+	handler := RateLimit(uiMock, settings, time.Minute)(func(c.Context, Request) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, handler(ctx, getTestMessageRequest("test", 5)))
+	require.NoError(t, handler(ctx, getTestClickRequest("test", 5)))
+	err := handler(ctx, getTestMessageRequest("test", 5))
+	require.ErrorIs(t, err, ErrRateLimited)
+	err = handler(ctx, getTestClickRequest("test", 5))
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.NoError(t, handler(ctx, getTestMessageRequest("test", 6)))
+	require.Equal(t, 3, calls)
+
+	// a reloaded limit applies to the next request
+	settings.Set(RuntimeSettings{RateLimit: 0})
+	require.NoError(t, handler(ctx, getTestMessageRequest("test", 5)))
+	require.Equal(t, 4, calls)
+}
+
+func TestRateLimit_noLimit(t *testing.T) {
+	settings := NewSettings(RuntimeSettings{})
+	handler := RateLimit(frontend.NewFrontend_mock(t), settings, time.Minute)(func(c.Context, Request) error {
+		return nil
+	})
+	for i := 0; i < 100; i++ {
+		require.NoError(t, handler(c.Background(), getTestMessageRequest("test", 5)))
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name             string
+		timeout          time.Duration
+		expectedDeadline bool
+	}{
+		{"a timeout", time.Minute, true},
+		{"no timeout", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Timeout(tt.timeout)(func(ctx c.Context, _ Request) error {
+				_, ok := ctx.Deadline()
+				require.Equal(t, tt.expectedDeadline, ok)
+				return nil
+			})
+			err := handler(c.Background(), getTestMessageRequest("test", 5))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTimeout_exceeded(t *testing.T) {
+	handler := Timeout(time.Millisecond)(func(ctx c.Context, _ Request) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	err := handler(c.Background(), getTestMessageRequest("test", 5))
+	require.ErrorIs(t, err, c.DeadlineExceeded)
+}
+
+func TestMeasure(t *testing.T) {
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	handlerErr := errors.New("test error")
+	handler := Measure(m)(func(c.Context, Request) error { return handlerErr })
+	ctx := c.Background()
+	err := handler(ctx, getTestMessageRequest("settings", 5))
+	require.ErrorIs(t, err, handlerErr)
+	err = handler(ctx, getTestClickRequest("language", 5))
+	require.ErrorIs(t, err, handlerErr)
+	err = handler(ctx, getTestClickRequest("language", 6))
+	require.ErrorIs(t, err, handlerErr)
+	require.Equal(t, 1, testutil.CollectAndCount(m.CommandDuration))
+	require.Equal(t, 1, testutil.CollectAndCount(m.ButtonDuration))
+	activeUsers := `
+# HELP helsinki_guide_active_users number of users who sent updates within a window
+# TYPE helsinki_guide_active_users gauge
+helsinki_guide_active_users{window="1d"} 2
+helsinki_guide_active_users{window="1h"} 2
+`
+	err = testutil.CollectAndCompare(m.ActiveUsers, strings.NewReader(activeUsers))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		float64(1),
+		testutil.ToFloat64(m.HandlerErrors.WithLabelValues("settings")),
+	)
+	require.Equal(
+		t,
+		float64(2),
+		testutil.ToFloat64(m.HandlerErrors.WithLabelValues("language")),
+	)
+}
+
+func TestTrace(t *testing.T) {
+	exporter := tracingtest.SetExporter(t)
+	uiMock := frontend.NewFrontend_mock(t)
+	uiMock.EXPECT().Send(mock.Anything, int64(99), frontend.TextView{Text: "test"}).
+		RunAndReturn(func(ctx c.Context, _ int64, _ frontend.View) error {
+			_, span := tracing.Start(ctx, "frontend Send")
+			span.End()
+			return nil
+		})
+	handlerErr := errors.New("test error")
+	router := NewRouter(Trace())
+	router.HandleCommand(
+		Route{Name: "test"},
+		func(h HandlerContainer, ctx c.Context, message frontend.Message) error {
+			err := h.ui.Send(ctx, message.Chat.ID, frontend.TextView{Text: "test"})
+			return errors.Join(err, handlerErr)
+		},
+	)
+	h := HandlerContainer{ui: uiMock, router: router, runtimeSettings: newTestSettings()}
+	handler, ok := h.GetCommandHandler("test")
+	require.True(t, ok)
+
+	err := handler(c.Background(), frontend.Message{Chat: frontend.Chat{ID: 99}})
+	require.ErrorIs(t, err, handlerErr)
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"frontend Send", "handler test"}, tracingtest.GetSpanNames(exporter))
+	require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	require.Equal(t, codes.Unset, spans[0].Status.Code)
+	require.Equal(t, codes.Error, spans[1].Status.Code)
+	require.Contains(
+		t,
+		spans[1].Attributes,
+		attribute.String("handler.kind", string(CommandRoute)),
+	)
+}
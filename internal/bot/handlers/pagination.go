@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	c "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+)
+
+var previousPageLabels = map[services.Language]string{
+	services.Finnish: "« Edellinen",
+	services.English: "« Previous",
+	services.Russian: "« Назад",
+}
+var nextPageLabels = map[services.Language]string{
+	services.Finnish: "Seuraava »",
+	services.English: "Next »",
+	services.Russian: "Далее »",
+}
+var pageTemplates = map[services.Language]string{
+	services.Finnish: "Sivu %v/%v.",
+	services.English: "Page %v of %v.",
+	services.Russian: "Страница %v из %v.",
+}
+
+func getPageCount(total, limit int) int {
+	return max(1, (total+limit-1)/limit)
+}
+
+func getPageOffset(page, limit int) int {
+	return (page - 1) * limit
+}
+
+// getTotal avoids a count query if all items fit the first page.
+func getTotal(page, limit, found int, count func() (int, error)) (int, error) {
+	if page == 1 && found < limit {
+		return found, nil
+	}
+	return count()
+}
+
+func getPageText(page, pageCount int, language services.Language) string {
+	return fmt.Sprintf(getLocalized(pageTemplates, language), page, pageCount)
+}
+
+// getPageRow returns Previous and Next buttons for a current page of a list.
+// A button template contains a list name and list parameters.
+func getPageRow(
+	ctx c.Context,
+	template PageButton,
+	pageCount int,
+	language services.Language,
+) ([]frontend.Button, error) {
+	row := []frontend.Button{}
+	if template.Page > 1 {
+		button := template
+		button.label = getLocalized(previousPageLabels, language)
+		button.Page--
+		buttonData, err := getPageButtonData(ctx, button)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, buttonData)
+	}
+	if template.Page < pageCount {
+		button := template
+		button.label = getLocalized(nextPageLabels, language)
+		button.Page++
+		buttonData, err := getPageButtonData(ctx, button)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, buttonData)
+	}
+	return row, nil
+}
+
+func getPageButtonData(
+	ctx c.Context,
+	button PageButton,
+) (frontend.Button, error) {
+	buttonCallbackData, err := json.Marshal(button)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not create a button %v", button),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return frontend.Button{}, err
+	}
+	return frontend.NewDataButton(
+		button.label,
+		string(buttonCallbackData),
+	), nil
+}
+
+// getMessagePageButton returns a page button of a list message,
+// a list of one page has none.
+func getMessagePageButton(message frontend.Message) (PageButton, bool) {
+	for _, row := range message.Buttons {
+		for _, button := range row {
+			if button.Data == "" {
+				continue
+			}
+			var pageButton PageButton
+			err := json.Unmarshal([]byte(button.Data), &pageButton)
+			if err != nil {
+				continue
+			}
+			if pageButton.Name == ADDRESS_PAGE_BUTTON || pageButton.Name == NEAREST_PAGE_BUTTON {
+				return pageButton, true
+			}
+		}
+	}
+	return PageButton{}, false
+}
+
+// parsePageClick validates a click on a page button.
+func parsePageClick(ctx c.Context, click frontend.ButtonClick) (PageButton, error) {
+	var button PageButton
+	err := json.Unmarshal([]byte(click.Data), &button)
+	if err == nil && button.Page < 1 {
+		err = fmt.Errorf("invalid page %v", button.Page)
+	}
+	if err != nil {
+		logMsg := fmt.Sprintf(
+			"unexpected callback data %v from a message %v and the chat %v",
+			click.Data,
+			click.Message.ID,
+			click.Message.Chat.ID,
+		)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return button, errors.Join(err, ErrUnexpectedCallback)
+	}
+	return button, nil
+}
+
+// editListPage replaces a list message with another page of the list
+// so that browsing a list does not flood a chat.
+func (h HandlerContainer) editListPage(
+	ctx c.Context,
+	message frontend.Message,
+	page frontend.ButtonListView,
+) error {
+	err := h.ui.Edit(ctx, message.Chat.ID, message.ID, page)
+	if err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not edit a message %v: %v", message.Chat.ID, message.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+	}
+	return err
+}
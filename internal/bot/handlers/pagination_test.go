@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	c "context"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPageCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		limit    int
+		expected int
+	}{
+		{"no items", 0, 10, 1},
+		{"one page", 10, 10, 1},
+		{"an incomplete page", 11, 10, 2},
+		{"several pages", 30, 10, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, getPageCount(tt.total, tt.limit))
+		})
+	}
+}
+
+func TestGetTotal(t *testing.T) {
+	count := func() (int, error) { return 42, nil }
+	total, err := getTotal(1, 10, 3, count)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+
+	total, err = getTotal(1, 10, 10, count)
+	require.NoError(t, err)
+	require.Equal(t, 42, total)
+
+	total, err = getTotal(2, 10, 3, count)
+	require.NoError(t, err)
+	require.Equal(t, 42, total)
+}
+
+func TestGetPageRow(t *testing.T) {
+	template := PageButton{
+		Button:    Button{Name: NEAREST_PAGE_BUTTON},
+		Latitude:  60.17,
+		Longitude: 24.94,
+	}
+	tests := []struct {
+		name      string
+		page      int
+		pageCount int
+		expected  []frontend.Button
+	}{
+		{"one page", 1, 1, []frontend.Button{}},
+		{
+			"the first page",
+			1,
+			3,
+			[]frontend.Button{
+				frontend.NewDataButton(
+					"Next »",
+					`{"name":"nearest","page":2,"lat":60.17,"lon":24.94}`,
+				),
+			},
+		},
+		{
+			"a middle page",
+			2,
+			3,
+			[]frontend.Button{
+				frontend.NewDataButton(
+					"« Previous",
+					`{"name":"nearest","page":1,"lat":60.17,"lon":24.94}`,
+				),
+				frontend.NewDataButton(
+					"Next »",
+					`{"name":"nearest","page":3,"lat":60.17,"lon":24.94}`,
+				),
+			},
+		},
+		{
+			"the last page",
+			3,
+			3,
+			[]frontend.Button{
+				frontend.NewDataButton(
+					"« Previous",
+					`{"name":"nearest","page":2,"lat":60.17,"lon":24.94}`,
+				),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			button := template
+			button.Page = tt.page
+			got, err := getPageRow(c.Background(), button, tt.pageCount, services.English)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+			for _, pageButton := range got {
+				require.LessOrEqual(t, len(pageButton.Data), 64)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	c "context"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+)
+
+type RouteKind string
+
+const (
+	CommandRoute RouteKind = "command"
+	ButtonRoute  RouteKind = "button"
+	// COMMON_MESSAGE is a route of messages which are not commands.
+	COMMON_MESSAGE = "common_message"
+)
+
+// Route describes a handler so that middlewares can treat handlers differently.
+type Route struct {
+	Name      string
+	Kind      RouteKind
+	AdminOnly bool
+}
+
+// Request is a message or a button click which a handler processes.
+type Request struct {
+	Route   Route
+	Message *frontend.Message
+	Click   *frontend.ButtonClick
+}
+
+func (r Request) Chat() *frontend.Chat {
+	if r.Message != nil {
+		return &r.Message.Chat
+	}
+	if r.Click != nil {
+		return &r.Click.Message.Chat
+	}
+	return nil
+}
+
+func (r Request) User() *frontend.User {
+	if r.Message != nil {
+		return r.Message.From
+	}
+	if r.Click != nil {
+		return r.Click.From
+	}
+	return nil
+}
+
+type HandlerFunc func(c.Context, Request) error
+type Middleware func(next HandlerFunc) HandlerFunc
+
+type commandFunc func(HandlerContainer, c.Context, frontend.Message) error
+type routeHandler struct {
+	route   Route
+	command commandFunc
+	button  internalButtonHandler
+}
+
+// Router keeps all command, button and message handlers and applies
+// the same middlewares to each of them.
+type Router struct {
+	commands    map[string]routeHandler
+	buttons     map[string]routeHandler
+	middlewares []Middleware
+}
+
+// NewRouter returns a router. The first middleware is the outermost one.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		map[string]routeHandler{},
+		map[string]routeHandler{},
+		middlewares,
+	}
+}
+
+func (r *Router) HandleCommand(route Route, handler commandFunc) {
+	route.Kind = CommandRoute
+	r.commands[route.Name] = routeHandler{route: route, command: handler}
+}
+
+func (r *Router) HandleButton(route Route, handler internalButtonHandler) {
+	route.Kind = ButtonRoute
+	r.buttons[route.Name] = routeHandler{route: route, button: handler}
+}
+
+func (r *Router) getCommandHandler(
+	h HandlerContainer,
+	name string,
+) (func(c.Context, frontend.Message) error, bool) {
+	handler, ok := r.commands[name]
+	if !ok {
+		return nil, false
+	}
+	chain := r.chain(func(ctx c.Context, request Request) error {
+		return handler.command(h, ctx, *request.Message)
+	})
+	return func(ctx c.Context, message frontend.Message) error {
+		return chain(ctx, Request{Route: handler.route, Message: &message})
+	}, true
+}
+
+func (r *Router) getButtonHandler(h HandlerContainer, name string) (ButtonHandler, bool) {
+	handler, ok := r.buttons[name]
+	if !ok {
+		return nil, false
+	}
+	chain := r.chain(func(ctx c.Context, request Request) error {
+		return handler.button(h, ctx, *request.Click)
+	})
+	return func(ctx c.Context, click frontend.ButtonClick) error {
+		return chain(ctx, Request{Route: handler.route, Click: &click})
+	}, true
+}
+
+func (r *Router) chain(handler HandlerFunc) HandlerFunc {
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		handler = r.middlewares[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	c "context"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_middlewareOrder(t *testing.T) {
+	calls := []string{}
+	getMiddleware := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx c.Context, request Request) error {
+				calls = append(calls, name)
+				return next(ctx, request)
+			}
+		}
+	}
+	router := NewRouter(getMiddleware("first"), getMiddleware("second"))
+	router.HandleCommand(
+		Route{Name: "test"},
+		func(_ HandlerContainer, _ c.Context, message frontend.Message) error {
+			calls = append(calls, message.Text)
+			return nil
+		},
+	)
+	router.HandleButton(
+		Route{Name: "test"},
+		func(_ HandlerContainer, _ c.Context, click frontend.ButtonClick) error {
+			calls = append(calls, click.Data)
+			return nil
+		},
+	)
+	h := HandlerContainer{router: router, runtimeSettings: newTestSettings()}
+
+	command, ok := h.GetCommandHandler("test")
+	require.True(t, ok)
+	require.NoError(t, command(c.Background(), frontend.Message{Text: "command"}))
+	button, ok := h.GetButtonHandler("test")
+	require.True(t, ok)
+	require.NoError(t, button(c.Background(), frontend.ButtonClick{Data: "button"}))
+	expected := []string{"first", "second", "command", "first", "second", "button"}
+	require.Equal(t, expected, calls)
+
+	_, ok = h.GetCommandHandler("unknown")
+	require.False(t, ok)
+	_, ok = h.GetButtonHandler("unknown")
+	require.False(t, ok)
+}
+
+func TestRouter_route(t *testing.T) {
+	var actual Request
+	router := NewRouter(func(next HandlerFunc) HandlerFunc {
+		return func(ctx c.Context, request Request) error {
+			actual = request
+			return next(ctx, request)
+		}
+	})
+	noop := func(HandlerContainer, c.Context, frontend.ButtonClick) error { return nil }
+	router.HandleButton(Route{Name: "moderate", AdminOnly: true}, noop)
+	h := HandlerContainer{router: router, runtimeSettings: newTestSettings()}
+	click := frontend.ButtonClick{ID: "123"}
+
+	button, ok := h.GetButtonHandler("moderate")
+	require.True(t, ok)
+	require.NoError(t, button(c.Background(), click))
+	expected := Request{
+		Route: Route{Name: "moderate", Kind: ButtonRoute, AdminOnly: true},
+		Click: &click,
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestNewCommandContainer_adminRoutes(t *testing.T) {
+	uiMock := frontend.NewFrontend_mock(t)
+	h := NewCommandContainer(
+		uiMock,
+		services.BuildingService{},
+		services.UserService{},
+		services.CorrectionService{},
+		services.PhotoService{},
+		services.ChatService{},
+		services.RouteService{},
+		services.PopularityService{},
+		metrics.NewMetrics(prometheus.NewRegistry()),
+		NewSettings(RuntimeSettings{AdminIDs: []int64{1}}),
+		"test_bot",
+		0,
+	)
+	ctx := c.Background()
+	message := frontend.Message{
+		Chat: frontend.Chat{ID: 99},
+		From: &frontend.User{ID: 5},
+	}
+	click := frontend.ButtonClick{
+		ID:      "123",
+		From:    &frontend.User{ID: 5},
+		Message: message,
+	}
+	// middlewares put a span into a context
+	uiMock.EXPECT().
+		Send(mock.Anything, int64(99), frontend.TextView{Text: notAdminText}).
+		Return(nil)
+	uiMock.EXPECT().Answer(mock.Anything, click, notAdminText).Return(nil).Twice()
+
+	command, ok := h.GetCommandHandler("moderation")
+	require.True(t, ok)
+	require.ErrorIs(t, command(ctx, message), ErrNotAdmin)
+	for _, name := range []string{MODERATION_BUTTON, PHOTO_MODERATION_BUTTON} {
+		button, ok := h.GetButtonHandler(name)
+		require.True(t, ok)
+		require.ErrorIs(t, button(ctx, click), ErrNotAdmin)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import "sync/atomic"
+
+// RuntimeSettings can change without a restart of the bot.
+type RuntimeSettings struct {
+	// RateLimit is a number of requests per minute a user can send
+	RateLimit int
+	AdminIDs  []int64
+	// SearchRadius is a distance to the nearest buildings in metres
+	SearchRadius int
+	// PageSize is a number of buildings on a page of a list
+	PageSize int
+}
+
+// Settings shares current runtime settings between handlers and middlewares.
+type Settings struct {
+	current atomic.Pointer[RuntimeSettings]
+}
+
+func NewSettings(settings RuntimeSettings) *Settings {
+	s := &Settings{}
+	s.Set(settings)
+	return s
+}
+
+func (s *Settings) Get() RuntimeSettings {
+	return *s.current.Load()
+}
+
+// Set replaces all settings at once, so a handler never sees
+// a half of an update.
+func (s *Settings) Set(settings RuntimeSettings) {
+	s.current.Store(&settings)
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSearchRadius = 100
+	testPageSize     = 10
+)
+
+func newTestSettings() *Settings {
+	return NewSettings(RuntimeSettings{SearchRadius: testSearchRadius, PageSize: testPageSize})
+}
+
+func TestSettings(t *testing.T) {
+	settings := NewSettings(RuntimeSettings{RateLimit: 1, AdminIDs: []int64{1}})
+	require.Equal(t, RuntimeSettings{RateLimit: 1, AdminIDs: []int64{1}}, settings.Get())
+	settings.Set(RuntimeSettings{PageSize: 5})
+	require.Equal(t, RuntimeSettings{PageSize: 5}, settings.Get())
+}
@@ -2,74 +2,81 @@ package handlers
 
 import (
 	c "context"
+	"sync"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
-	"github.com/AndreyAD1/helsinki-guide/internal/bot/middlewares"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/AndreyAD1/helsinki-guide/internal/export"
 )
 
 type CommandHandler struct {
-	Function    func(HandlerContainer, c.Context, *tgbotapi.Message) error
+	Function    func(HandlerContainer, c.Context, frontend.Message) error
 	Description string
 }
-type internalButtonHandler func(HandlerContainer, c.Context, *tgbotapi.CallbackQuery) error
-type ButtonHandler func(c.Context, *tgbotapi.CallbackQuery) error
+type internalButtonHandler func(HandlerContainer, c.Context, frontend.ButtonClick) error
+type ButtonHandler func(c.Context, frontend.ButtonClick) error
 type HandlerContainer struct {
 	buildingService    services.Buildings
 	userService        services.Users
-	bot                InternalBot
+	ui                 frontend.Frontend
 	HandlersPerCommand map[string]CommandHandler
-	handlersPerButton  map[string]internalButtonHandler
 	commandsForHelp    string
 	metrics            *metrics.Metrics
-	allHandlers        map[string]CommandHandler
+	correctionService  services.Corrections
+	runtimeSettings    *Settings
+	photoService       services.Photos
+	chatService        services.Chats
+	// HandlersPerGroupCommand contains commands that make sense in group chats
+	HandlersPerGroupCommand map[string]CommandHandler
+	botName                 string
+	routeService            services.Routes
+	router                  *Router
+	popularityService       services.Popularity
+	// analytics tracks popularity writes which do not delay replies
+	analytics *sync.WaitGroup
 }
 type Button struct {
 	label string
 	Name  string `json:"name"`
 }
-type NextButton struct {
+type PageButton struct {
 	Button
-	Limit  int `json:"limit,omitempty"`
-	Offset int `json:"offset,omitempty"`
+	Page      int     `json:"page"`
+	Latitude  float64 `json:"lat,omitempty"`
+	Longitude float64 `json:"lon,omitempty"`
 }
 type LanguageButton struct {
 	Button
 	Language string `json:"value"`
+	// OwnerID is a group member who can change a group language
+	OwnerID int64 `json:"user,omitempty"`
 }
 type BuildingButton struct {
 	Button
 	ID string `json:"id"`
 }
-type BotWithMetrics struct {
-	clientName string
-	*tgbotapi.BotAPI
-	m *metrics.Metrics
+type FieldButton struct {
+	Button
+	ID    string `json:"id"`
+	Field string `json:"field"`
 }
-
-func NewBotWithMetrics(bot *tgbotapi.BotAPI, m *metrics.Metrics) *BotWithMetrics {
-	return &BotWithMetrics{"Telegram", bot, m}
+type ModerationButton struct {
+	Button
+	ID     string `json:"id"`
+	Accept bool   `json:"accept,omitempty"`
 }
-
-func (b *BotWithMetrics) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
-	result, err := middlewares.Duration(
-		func() (interface{}, error) { return b.BotAPI.Send(c) },
-		b.m,
-		b.clientName,
-		"Send",
-	)
-	message := result.(tgbotapi.Message)
-	return message, err
+type RouteButton struct {
+	Button
+	Latitude  float64 `json:"lat,omitempty"`
+	Longitude float64 `json:"lon,omitempty"`
 }
-
-func (b *BotWithMetrics) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
-	result, err := middlewares.Duration(
-		func() (interface{}, error) { return b.BotAPI.Request(c) },
-		b.m,
-		b.clientName,
-		"Request",
-	)
-	response := result.(*tgbotapi.APIResponse)
-	return response, err
+type RouteStopButton struct {
+	Button
+	ID       string `json:"id"`
+	Selected bool   `json:"on,omitempty"`
+}
+type ExportButton struct {
+	Button
+	Format export.Format `json:"format"`
 }
@@ -10,10 +10,11 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/frontend"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/services"
 	s "github.com/AndreyAD1/helsinki-guide/internal/bot/services"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var tagPerLanguage = map[s.Language]string{
@@ -106,24 +107,28 @@ func SerializeIntoMessage(object any, outputLanguage s.Language) (string, error)
 	return strings.Join(result, "\n"), nil
 }
 
+func getBuildingName(building s.BuildingDTO, language s.Language) string {
+	buildingName := building.NameEn
+	switch language {
+	case s.Finnish:
+		buildingName = building.NameFi
+	case s.Russian:
+		buildingName = building.NameRu
+	}
+	if buildingName == nil {
+		return noDataPerLanguages[language]
+	}
+	return *buildingName
+}
+
 func getBuildingButtonRows(
 	ctx context.Context,
 	language services.Language,
 	buildings []services.BuildingDTO,
-) ([][]tgbotapi.InlineKeyboardButton, error) {
-	keyboardRows := [][]tgbotapi.InlineKeyboardButton{}
+) ([][]frontend.Button, error) {
+	keyboardRows := [][]frontend.Button{}
 	for _, building := range buildings {
-		buildingName := building.NameEn
-		switch language {
-		case s.Finnish:
-			buildingName = building.NameFi
-		case s.Russian:
-			buildingName = building.NameRu
-		}
-		name := noDataPerLanguages[language]
-		if buildingName != nil {
-			name = *buildingName
-		}
+		name := getBuildingName(building, language)
 		label := fmt.Sprintf(buttonTemplate, building.Address, name)
 		button := BuildingButton{
 			Button{label, BUILDING_BUTTON},
@@ -136,14 +141,19 @@ func getBuildingButtonRows(
 				fmt.Sprintf("can not create a button %v", button),
 				slog.Any(logger.ErrorKey, err),
 			)
-			return [][]tgbotapi.InlineKeyboardButton{}, err
+			return [][]frontend.Button{}, err
 		}
-		buttonData := tgbotapi.NewInlineKeyboardButtonData(
+		buttonData := frontend.NewDataButton(
 			button.label,
 			string(buttonCallbackData),
 		)
-		buttonRow := tgbotapi.NewInlineKeyboardRow(buttonData)
-		keyboardRows = append(keyboardRows, buttonRow)
+		keyboardRows = append(keyboardRows, []frontend.Button{buttonData})
 	}
 	return keyboardRows, nil
 }
+
+// observeEmptySearch counts a search without results. A search type
+// is a label instead of a search pattern to keep the label cardinality bounded.
+func (h HandlerContainer) observeEmptySearch(searchType string) {
+	h.metrics.EmptySearches.With(prometheus.Labels{"search_type": searchType}).Inc()
+}
@@ -0,0 +1,128 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+)
+
+const (
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+type Check struct {
+	Name     string
+	Function func(context.Context) error
+}
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// GetLivenessHandler reports that a process is able to serve requests.
+func GetLivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(r.Context(), w, Report{Status: StatusOK})
+	})
+}
+
+// GetReadinessHandler runs all checks concurrently and responds
+// with 503 if any check fails or exceeds a timeout.
+func GetReadinessHandler(timeout time.Duration, checks ...Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		writeReport(r.Context(), w, runChecks(ctx, checks))
+	})
+}
+
+func runChecks(ctx context.Context, checks []Check) Report {
+	report := Report{StatusOK, make(map[string]CheckResult, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := CheckResult{Status: StatusOK}
+			if err := runCheck(ctx, check); err != nil {
+				slog.WarnContext(
+					ctx,
+					fmt.Sprintf("the readiness check %v failed", check.Name),
+					slog.Any(logger.ErrorKey, err),
+				)
+				result = CheckResult{StatusFailed, err.Error()}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFailed
+			}
+		}(check)
+	}
+	wg.Wait()
+	return report
+}
+
+// runCheck stops waiting for a check which ignores a context.
+func runCheck(ctx context.Context, check Check) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- check.Function(ctx)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func writeReport(ctx context.Context, w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.WarnContext(ctx, "can not write a health report", slog.Any(logger.ErrorKey, err))
+	}
+}
+
+// NewMigrationCheck compares a schema version with the version
+// which the binary expects.
+func NewMigrationCheck(
+	getVersion func(context.Context) (int64, bool, error),
+	expectedVersion int64,
+) Check {
+	return Check{"migrations", func(ctx context.Context) error {
+		version, dirty, err := getVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("the schema version %v is dirty", version)
+		}
+		if version != expectedVersion {
+			return fmt.Errorf(
+				"the schema version %v differs from the expected version %v",
+				version,
+				expectedVersion,
+			)
+		}
+		return nil
+	}}
+}
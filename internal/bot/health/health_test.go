@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getVersionFunc(version int64, dirty bool, err error) func(context.Context) (int64, bool, error) {
+	return func(context.Context) (int64, bool, error) {
+		return version, dirty, err
+	}
+}
+
+func TestGetLivenessHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	GetLivenessHandler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+}
+
+func TestGetReadinessHandler(t *testing.T) {
+	passed := Check{"database", func(context.Context) error { return nil }}
+	failed := Check{"telegram", func(context.Context) error {
+		return errors.New("connection refused")
+	}}
+	hanging := Check{"telegram", func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+	tests := []struct {
+		name         string
+		checks       []Check
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			"all checks pass",
+			[]Check{passed, NewMigrationCheck(getVersionFunc(3, false, nil), 3)},
+			http.StatusOK,
+			`{"status":"ok","checks":{
+				"database":{"status":"ok"},
+				"migrations":{"status":"ok"}
+			}}`,
+		},
+		{
+			"a check fails",
+			[]Check{passed, failed},
+			http.StatusServiceUnavailable,
+			`{"status":"failed","checks":{
+				"database":{"status":"ok"},
+				"telegram":{"status":"failed","error":"connection refused"}
+			}}`,
+		},
+		{
+			"a check exceeds a timeout",
+			[]Check{hanging},
+			http.StatusServiceUnavailable,
+			`{"status":"failed","checks":{
+				"telegram":{"status":"failed","error":"context deadline exceeded"}
+			}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			handler := GetReadinessHandler(50*time.Millisecond, tt.checks...)
+			handler.ServeHTTP(recorder, request)
+			require.Equal(t, tt.expectedCode, recorder.Code)
+			require.JSONEq(t, tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestNewMigrationCheck(t *testing.T) {
+	versionErr := errors.New("relation does not exist")
+	tests := []struct {
+		name          string
+		getVersion    func(context.Context) (int64, bool, error)
+		expectedError string
+	}{
+		{"expected version", getVersionFunc(5, false, nil), ""},
+		{
+			"old version",
+			getVersionFunc(4, false, nil),
+			"the schema version 4 differs from the expected version 5",
+		},
+		{"dirty version", getVersionFunc(5, true, nil), "the schema version 5 is dirty"},
+		{"no table", getVersionFunc(0, false, versionErr), versionErr.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewMigrationCheck(tt.getVersion, 5)
+			require.Equal(t, "migrations", check.Name)
+			err := check.Function(context.Background())
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE corrections;
+DROP TYPE correction_status;
+COMMIT;
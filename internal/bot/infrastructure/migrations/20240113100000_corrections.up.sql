@@ -0,0 +1,23 @@
+BEGIN;
+CREATE TYPE correction_status AS ENUM('pending', 'accepted', 'rejected');
+
+CREATE TABLE corrections (
+    id SERIAL PRIMARY KEY,
+    building_id integer NOT NULL,
+    field varchar NOT NULL CHECK (field <> ''),
+    language language NOT NULL,
+    value varchar NOT NULL CHECK (value <> ''),
+    reporter_id bigint NOT NULL,
+    chat_id bigint NOT NULL,
+    status correction_status NOT NULL DEFAULT 'pending',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+
+ALTER TABLE corrections ADD CONSTRAINT building_correction 
+FOREIGN KEY (building_id) REFERENCES buildings (id);
+
+CREATE INDEX correction_status_index ON corrections (status, created_at);
+
+COMMIT;
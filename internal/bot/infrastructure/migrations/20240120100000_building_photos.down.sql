@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE building_photos;
+DROP TYPE photo_status;
+COMMIT;
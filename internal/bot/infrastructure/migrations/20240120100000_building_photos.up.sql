@@ -0,0 +1,24 @@
+BEGIN;
+CREATE TYPE photo_status AS ENUM('pending', 'accepted', 'rejected');
+
+CREATE TABLE building_photos (
+    id SERIAL PRIMARY KEY,
+    building_id integer NOT NULL,
+    file_id varchar NOT NULL CHECK (file_id <> ''),
+    file_unique_id varchar NOT NULL CHECK (file_unique_id <> ''),
+    attribution varchar NOT NULL CHECK (attribution <> ''),
+    uploader_id bigint NOT NULL,
+    chat_id bigint NOT NULL,
+    status photo_status NOT NULL DEFAULT 'pending',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    CONSTRAINT building_photo_unique UNIQUE (building_id, file_unique_id)
+);
+
+ALTER TABLE building_photos ADD CONSTRAINT building_photo 
+FOREIGN KEY (building_id) REFERENCES buildings (id);
+
+CREATE INDEX building_photo_index ON building_photos (building_id, status);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+CREATE TABLE chats (
+    id SERIAL PRIMARY KEY,
+    telegram_id bigint UNIQUE NOT NULL,
+    language language,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE update_offsets;
+DROP TABLE processed_updates;
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+CREATE TABLE processed_updates (
+    update_id bigint PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE update_offsets (
+    id smallint PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    update_offset bigint NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
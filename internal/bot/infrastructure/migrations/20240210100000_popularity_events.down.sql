@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE popularity_events;
+DROP TYPE popularity_event_type;
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+CREATE TYPE popularity_event_type AS ENUM('view', 'search');
+
+CREATE TABLE popularity_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type popularity_event_type NOT NULL,
+    user_hash varchar NOT NULL CHECK (user_hash <> ''),
+    building_id integer,
+    search_type varchar,
+    search_query varchar,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE popularity_events ADD CONSTRAINT popularity_event_building
+FOREIGN KEY (building_id) REFERENCES buildings (id) ON DELETE CASCADE;
+
+CREATE INDEX popularity_event_created_index ON popularity_events (created_at);
+CREATE INDEX popularity_event_view_index ON popularity_events (building_id, created_at)
+WHERE event_type = 'view';
+
+COMMIT;
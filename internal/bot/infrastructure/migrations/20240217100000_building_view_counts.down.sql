@@ -0,0 +1,6 @@
+BEGIN;
+DROP TRIGGER building_view_removed ON popularity_events;
+DROP TRIGGER building_view_inserted ON popularity_events;
+DROP FUNCTION count_building_views();
+DROP TABLE building_view_counts;
+COMMIT;
@@ -0,0 +1,37 @@
+BEGIN;
+-- views per building among retained popularity events, a search ranks
+-- buildings by this counter instead of counting events
+CREATE TABLE building_view_counts (
+    building_id integer PRIMARY KEY REFERENCES buildings (id) ON DELETE CASCADE,
+    views bigint NOT NULL DEFAULT 0 CHECK (views >= 0)
+);
+
+INSERT INTO building_view_counts (building_id, views)
+SELECT building_id, count(*) FROM popularity_events
+WHERE event_type = 'view' AND building_id IS NOT NULL
+GROUP BY building_id;
+
+CREATE FUNCTION count_building_views() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO building_view_counts (building_id, views)
+        VALUES (NEW.building_id, 1)
+        ON CONFLICT (building_id)
+        DO UPDATE SET views = building_view_counts.views + 1;
+        RETURN NEW;
+    END IF;
+    UPDATE building_view_counts SET views = views - 1
+    WHERE building_id = OLD.building_id AND views > 0;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER building_view_inserted AFTER INSERT ON popularity_events
+FOR EACH ROW WHEN (NEW.event_type = 'view' AND NEW.building_id IS NOT NULL)
+EXECUTE FUNCTION count_building_views();
+
+CREATE TRIGGER building_view_removed AFTER DELETE ON popularity_events
+FOR EACH ROW WHEN (OLD.event_type = 'view' AND OLD.building_id IS NOT NULL)
+EXECUTE FUNCTION count_building_views();
+
+COMMIT;
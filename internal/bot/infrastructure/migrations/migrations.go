@@ -0,0 +1,37 @@
+// Package migrations contains the database schema which the bot expects.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+var upMigration = regexp.MustCompile(`^(\d+)_\w+\.up\.sql$`)
+
+// LatestVersion returns the version of the last migration
+// in the golang-migrate format.
+func LatestVersion() (int64, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return 0, fmt.Errorf("can not read migrations: %w", err)
+	}
+	var latest int64
+	for _, entry := range entries {
+		match := upMigration.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration name %v: %w", entry.Name(), err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}
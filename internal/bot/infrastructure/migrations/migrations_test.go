@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestVersion(t *testing.T) {
+	version, err := LatestVersion()
+	require.NoError(t, err)
+	latest, err := fs.Glob(files, fmt.Sprintf("%v_*.up.sql", version))
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	all, err := filepath.Glob("*.up.sql")
+	require.NoError(t, err)
+	require.Equal(t, all[len(all)-1], latest[0])
+}
@@ -1,5 +1,7 @@
 package repositories
 
+import "slices"
+
 type ActorSpecificationByBuilding struct {
 	buildingID int64
 }
@@ -21,6 +23,26 @@ func ActorByBuildingIsEqual(buildingID int64) func(s *ActorSpecificationByBuildi
 	}
 }
 
+type ActorSpecificationByIDs struct {
+	ids []int64
+}
+
+func NewActorSpecificationByIDs(ids []int64) *ActorSpecificationByIDs {
+	return &ActorSpecificationByIDs{ids}
+}
+
+func (a *ActorSpecificationByIDs) ToSQL() (string, map[string]any) {
+	query := `SELECT id, name, title_fi, title_en, title_ru, created_at,
+	updated_at, deleted_at FROM actors WHERE id = ANY(@ids);`
+	return query, map[string]any{"ids": a.ids}
+}
+
+func ActorByIDsIsEqual(ids []int64) func(s *ActorSpecificationByIDs) bool {
+	return func(s *ActorSpecificationByIDs) bool {
+		return slices.Equal(ids, s.ids)
+	}
+}
+
 type ActorSpecificationByName struct {
 	actor Actor
 }
@@ -81,7 +81,11 @@ func (a *actorStorage) Update(ctx context.Context, actor Actor) (*Actor, error)
 
 func (a *actorStorage) Query(ctx context.Context, spec Specification) ([]Actor, error) {
 	query, queryArgs := spec.ToSQL()
-	slog.DebugContext(ctx, fmt.Sprintf("send the query %v: %v", query, queryArgs))
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
 	rows, err := a.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
 	if err != nil {
 		logMsg := fmt.Sprintf("a query error: '%v'", query)
@@ -102,12 +106,13 @@ func (a *actorStorage) Query(ctx context.Context, spec Specification) ([]Actor,
 			&actor.UpdatedAt,
 			&actor.deletedAt,
 		); err != nil {
-			msg := fmt.Sprintf(
-				"can not scan an actor from a query result: %v: %v",
-				query,
-				queryArgs,
+			msg := fmt.Sprintf("can not scan an actor from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
 			)
-			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
 			return nil, err
 		}
 		actors = append(actors, actor)
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const buildingChangesChannel = "building_changes"
+
+// BuildingChangeStorage delivers notifications about changed buildings
+// between processes which share a database, for example, from
+// the populate command to a running bot.
+type BuildingChangeStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewBuildingChangeRepo(dbPool *pgxpool.Pool) *BuildingChangeStorage {
+	return &BuildingChangeStorage{dbPool}
+}
+
+// Notify tells all listeners that buildings have changed.
+func (s *BuildingChangeStorage) Notify(ctx context.Context) error {
+	_, err := s.dbPool.Exec(ctx, `SELECT pg_notify($1, '');`, buildingChangesChannel)
+	if err != nil {
+		slog.WarnContext(ctx, "can not notify about building changes", slog.Any(logger.ErrorKey, err))
+	}
+	return err
+}
+
+// Listen calls onChange on every notification until a context is done
+// or a connection fails. It holds one connection of a pool.
+func (s *BuildingChangeStorage) Listen(ctx context.Context, onChange func()) error {
+	connection, err := s.dbPool.Acquire(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "can not acquire a connection", slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	defer connection.Release()
+	if _, err := connection.Exec(ctx, "LISTEN "+buildingChangesChannel+";"); err != nil {
+		slog.WarnContext(ctx, "can not listen for building changes", slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	for {
+		_, err := connection.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onChange()
+	}
+}
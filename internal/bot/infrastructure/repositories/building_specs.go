@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 type BuildingSpecificationAll struct {
@@ -48,6 +50,30 @@ func BuildingByIDIsEqual(id int64) func(s *BuildingSpecificationByID) bool {
 	}
 }
 
+// BuildingSpecificationByIDForUpdate locks a building
+// until the end of a transaction.
+type BuildingSpecificationByIDForUpdate struct {
+	id int64
+}
+
+func NewBuildingSpecificationByIDForUpdate(id int64) *BuildingSpecificationByIDForUpdate {
+	return &BuildingSpecificationByIDForUpdate{id}
+}
+
+func (b *BuildingSpecificationByIDForUpdate) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM buildings
+	JOIN addresses ON buildings.address_id = addresses.id
+	WHERE buildings.id = @id AND buildings.deleted_at IS NULL
+	FOR UPDATE OF buildings;`
+	return queryTemplate, map[string]any{"id": b.id}
+}
+
+func BuildingByIDForUpdateIsEqual(id int64) func(s *BuildingSpecificationByIDForUpdate) bool {
+	return func(s *BuildingSpecificationByIDForUpdate) bool {
+		return id == s.id
+	}
+}
+
 type BuildingSpecificationByAlikeAddress struct {
 	addressPrefix string
 	limit         int
@@ -62,21 +88,33 @@ func NewBuildingSpecificationByAlikeAddress(
 	return &BuildingSpecificationByAlikeAddress{prefix, limit, offset}
 }
 
-func (b *BuildingSpecificationByAlikeAddress) ToSQL() (string, map[string]any) {
-	queryTemplate := selectAllBuildingFields + ` FROM 
+const alikeAddressCondition = ` FROM 
 	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
 	JOIN addresses ON 
 	buildings.address_id = addresses.id WHERE lower(street_address) 
-	LIKE @search_pattern
-	ORDER BY lower(street_address) LIMIT @limit OFFSET @offset;`
+	LIKE @search_pattern`
+
+// buildingViews is a number of retained building views which a trigger
+// maintains. Popular buildings go first among buildings with the same address.
+const buildingViews = `COALESCE((SELECT views FROM building_view_counts
+	WHERE building_view_counts.building_id = buildings.id), 0)`
+
+func (b *BuildingSpecificationByAlikeAddress) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + alikeAddressCondition + `
+	ORDER BY lower(street_address), ` + buildingViews + ` DESC, buildings.id
+	LIMIT @limit OFFSET @offset;`
 	queryArgs := map[string]any{
-		"search_pattern": strings.ToLower(b.addressPrefix) + "%",
+		"search_pattern": getSearchPattern(b.addressPrefix),
 		"limit":          b.limit,
 		"offset":         b.offset,
 	}
 	return queryTemplate, queryArgs
 }
 
+func getSearchPattern(addressPrefix string) string {
+	return strings.ToLower(addressPrefix) + "%"
+}
+
 func AlikeAddressSpecIsEqual(addressPrefix string, limit, offset int) func(s *BuildingSpecificationByAlikeAddress) bool {
 	return func(s *BuildingSpecificationByAlikeAddress) bool {
 		addressMatch := s.addressPrefix == addressPrefix
@@ -86,6 +124,26 @@ func AlikeAddressSpecIsEqual(addressPrefix string, limit, offset int) func(s *Bu
 	}
 }
 
+type BuildingCountSpecificationByAlikeAddress struct {
+	addressPrefix string
+}
+
+func NewBuildingCountSpecificationByAlikeAddress(prefix string) Specification {
+	return &BuildingCountSpecificationByAlikeAddress{prefix}
+}
+
+func (b *BuildingCountSpecificationByAlikeAddress) ToSQL() (string, map[string]any) {
+	queryTemplate := `SELECT count(*)` + alikeAddressCondition + ";"
+	queryArgs := map[string]any{"search_pattern": getSearchPattern(b.addressPrefix)}
+	return queryTemplate, queryArgs
+}
+
+func AlikeAddressCountSpecIsEqual(addressPrefix string) func(s *BuildingCountSpecificationByAlikeAddress) bool {
+	return func(s *BuildingCountSpecificationByAlikeAddress) bool {
+		return s.addressPrefix == addressPrefix
+	}
+}
+
 type BuildingSpecificationByAddress struct {
 	address string
 }
@@ -129,9 +187,12 @@ func NewBuildingSpecificationNearest(
 	return &BuildingSpecificationNearest{distanceMeters, lat, lon, limit, offset}
 }
 
-func (b *BuildingSpecificationNearest) ToSQL() (string, map[string]any) {
-	queryTemplate := selectAllBuildingFields + ` FROM 
-	buildings JOIN addresses ON buildings.address_id = addresses.id 
+// distanceField is a column which BuildingStorage.Query reads
+// as a distance to a point of interest.
+const distanceField = "distance_meters"
+
+const nearestCondition = `
+	FROM buildings JOIN addresses ON buildings.address_id = addresses.id 
 	WHERE 
 	buildings.deleted_at IS NULL
 	AND
@@ -141,12 +202,15 @@ func (b *BuildingSpecificationNearest) ToSQL() (string, map[string]any) {
 	earth_distance(
 		ll_to_earth(@latitude, @longitude), 
 		ll_to_earth(latitude_wgs84, longitude_wgs84)
-	) <= @distance
-	ORDER BY 
-			earth_distance(
-				ll_to_earth(@latitude, @longitude),
-				ll_to_earth(latitude_wgs84, longitude_wgs84)
-			)
+	) <= @distance`
+
+func (b *BuildingSpecificationNearest) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + `,
+	earth_distance(
+		ll_to_earth(@latitude, @longitude),
+		ll_to_earth(latitude_wgs84, longitude_wgs84)
+	) AS ` + distanceField + nearestCondition + `
+	ORDER BY ` + distanceField + `
 	LIMIT @limit OFFSET @offset;`
 	args := map[string]any{
 		"distance":  b.distanceMeters,
@@ -174,3 +238,274 @@ func NearestSpecIsEqual(
 		return distanceMatch && latMatch && lonMatch && limitMatch && offsetMatch
 	}
 }
+
+type BuildingSpecificationByBBox struct {
+	minLatitude  float64
+	minLongitude float64
+	maxLatitude  float64
+	maxLongitude float64
+	limit        int
+}
+
+// NewBuildingSpecificationByBBox selects buildings inside a bounding box
+// which does not cross the antimeridian. A zero limit selects all buildings
+// of a box.
+func NewBuildingSpecificationByBBox(
+	minLatitude,
+	minLongitude,
+	maxLatitude,
+	maxLongitude float64,
+	limit int,
+) Specification {
+	return &BuildingSpecificationByBBox{
+		minLatitude,
+		minLongitude,
+		maxLatitude,
+		maxLongitude,
+		limit,
+	}
+}
+
+// ToSQL uses the geolocation index to find buildings around the center
+// of a box and then drops buildings outside the box. A radius is the distance
+// to the farthest corner of a box, a metre covers rounding errors.
+func (b *BuildingSpecificationByBBox) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM 
+	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
+	JOIN addresses ON buildings.address_id = addresses.id 
+	WHERE
+	earth_box(ll_to_earth(@latitude, @longitude), 1 + GREATEST(
+		earth_distance(
+			ll_to_earth(@latitude, @longitude),
+			ll_to_earth(@min_latitude, @min_longitude)
+		),
+		earth_distance(
+			ll_to_earth(@latitude, @longitude),
+			ll_to_earth(@min_latitude, @max_longitude)
+		),
+		earth_distance(
+			ll_to_earth(@latitude, @longitude),
+			ll_to_earth(@max_latitude, @min_longitude)
+		),
+		earth_distance(
+			ll_to_earth(@latitude, @longitude),
+			ll_to_earth(@max_latitude, @max_longitude)
+		)
+	)) @> ll_to_earth(latitude_wgs84, longitude_wgs84)
+	AND latitude_wgs84 BETWEEN @min_latitude AND @max_latitude
+	AND longitude_wgs84 BETWEEN @min_longitude AND @max_longitude
+	ORDER BY buildings.id LIMIT @limit;`
+	queryArgs := map[string]any{
+		"latitude":      (b.minLatitude + b.maxLatitude) / 2,
+		"longitude":     (b.minLongitude + b.maxLongitude) / 2,
+		"min_latitude":  b.minLatitude,
+		"min_longitude": b.minLongitude,
+		"max_latitude":  b.maxLatitude,
+		"max_longitude": b.maxLongitude,
+		"limit":         b.limit,
+	}
+	if b.limit == 0 {
+		// LIMIT NULL is no limit
+		queryArgs["limit"] = nil
+	}
+	return queryTemplate, queryArgs
+}
+
+func BuildingByBBoxIsEqual(
+	minLatitude,
+	minLongitude,
+	maxLatitude,
+	maxLongitude float64,
+	limit int,
+) func(s *BuildingSpecificationByBBox) bool {
+	return func(s *BuildingSpecificationByBBox) bool {
+		return s.minLatitude == minLatitude &&
+			s.minLongitude == minLongitude &&
+			s.maxLatitude == maxLatitude &&
+			s.maxLongitude == maxLongitude &&
+			s.limit == limit
+	}
+}
+
+type BuildingCountSpecificationNearest struct {
+	distanceMeters int
+	latitude       string
+	longitude      string
+}
+
+func NewBuildingCountSpecificationNearest(
+	distanceMeters int,
+	latitude,
+	longitude float64,
+) Specification {
+	lat := fmt.Sprintf("%.5f", latitude)
+	lon := fmt.Sprintf("%.5f", longitude)
+	return &BuildingCountSpecificationNearest{distanceMeters, lat, lon}
+}
+
+func (b *BuildingCountSpecificationNearest) ToSQL() (string, map[string]any) {
+	queryTemplate := `SELECT count(*)` + nearestCondition + ";"
+	args := map[string]any{
+		"distance":  b.distanceMeters,
+		"latitude":  b.latitude,
+		"longitude": b.longitude,
+	}
+	return queryTemplate, args
+}
+
+func NearestCountSpecIsEqual(
+	distanceMeters int,
+	latitude,
+	longitude float64,
+) func(s *BuildingCountSpecificationNearest) bool {
+	return func(s *BuildingCountSpecificationNearest) bool {
+		distanceMatch := s.distanceMeters == distanceMeters
+		latMatch := s.latitude == fmt.Sprintf("%.5f", latitude)
+		lonMatch := s.longitude == fmt.Sprintf("%.5f", longitude)
+		return distanceMatch && latMatch && lonMatch
+	}
+}
+
+type BuildingSpecificationByNeighbourhood struct {
+	neighbourhoodID int64
+	limit           int
+	offset          int
+}
+
+func NewBuildingSpecificationByNeighbourhood(
+	neighbourhoodID int64,
+	limit,
+	offset int,
+) Specification {
+	return &BuildingSpecificationByNeighbourhood{neighbourhoodID, limit, offset}
+}
+
+func (b *BuildingSpecificationByNeighbourhood) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM 
+	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
+	JOIN addresses ON buildings.address_id = addresses.id
+	WHERE addresses.neighbourhood_id = @neighbourhood_id
+	ORDER BY lower(street_address), buildings.id LIMIT @limit OFFSET @offset;`
+	queryArgs := map[string]any{
+		"neighbourhood_id": b.neighbourhoodID,
+		"limit":            b.limit,
+		"offset":           b.offset,
+	}
+	return queryTemplate, queryArgs
+}
+
+func BuildingByNeighbourhoodIsEqual(
+	neighbourhoodID int64,
+	limit,
+	offset int,
+) func(s *BuildingSpecificationByNeighbourhood) bool {
+	return func(s *BuildingSpecificationByNeighbourhood) bool {
+		return s.neighbourhoodID == neighbourhoodID &&
+			s.limit == limit &&
+			s.offset == offset
+	}
+}
+
+type BuildingSpecificationByAuthor struct {
+	actorID int64
+	limit   int
+	offset  int
+}
+
+func NewBuildingSpecificationByAuthor(actorID int64, limit, offset int) Specification {
+	return &BuildingSpecificationByAuthor{actorID, limit, offset}
+}
+
+func (b *BuildingSpecificationByAuthor) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM 
+	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
+	JOIN addresses ON buildings.address_id = addresses.id
+	JOIN building_authors ON buildings.id = building_authors.building_id
+	WHERE building_authors.actor_id = @actor_id
+	ORDER BY lower(street_address), buildings.id LIMIT @limit OFFSET @offset;`
+	queryArgs := map[string]any{
+		"actor_id": b.actorID,
+		"limit":    b.limit,
+		"offset":   b.offset,
+	}
+	return queryTemplate, queryArgs
+}
+
+func BuildingByAuthorIsEqual(
+	actorID int64,
+	limit,
+	offset int,
+) func(s *BuildingSpecificationByAuthor) bool {
+	return func(s *BuildingSpecificationByAuthor) bool {
+		return s.actorID == actorID && s.limit == limit && s.offset == offset
+	}
+}
+
+type BuildingSpecificationByIDs struct {
+	ids []int64
+}
+
+func NewBuildingSpecificationByIDs(ids []int64) *BuildingSpecificationByIDs {
+	return &BuildingSpecificationByIDs{ids}
+}
+
+func (b *BuildingSpecificationByIDs) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM 
+	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
+	JOIN addresses ON buildings.address_id = addresses.id 
+	WHERE buildings.id = ANY(@ids) ORDER BY buildings.id;`
+	return queryTemplate, map[string]any{"ids": b.ids}
+}
+
+func BuildingByIDsIsEqual(ids []int64) func(s *BuildingSpecificationByIDs) bool {
+	return func(s *BuildingSpecificationByIDs) bool {
+		return slices.Equal(ids, s.ids)
+	}
+}
+
+type BuildingSpecificationPopular struct {
+	since         time.Time
+	neighbourhood string
+	limit         int
+}
+
+// NewBuildingSpecificationPopular selects the most viewed buildings since a time.
+// An empty neighbourhood name means all neighbourhoods.
+func NewBuildingSpecificationPopular(
+	since time.Time,
+	neighbourhood string,
+	limit int,
+) Specification {
+	return &BuildingSpecificationPopular{since, neighbourhood, limit}
+}
+
+func (b *BuildingSpecificationPopular) ToSQL() (string, map[string]any) {
+	queryTemplate := selectAllBuildingFields + ` FROM 
+	(SELECT * FROM buildings WHERE deleted_at IS NULL) AS buildings
+	JOIN addresses ON buildings.address_id = addresses.id
+	JOIN (SELECT building_id, count(*) AS views FROM popularity_events
+	WHERE event_type = 'view' AND created_at >= @since
+	GROUP BY building_id) AS popularity ON buildings.id = popularity.building_id`
+	queryArgs := map[string]any{"since": b.since, "limit": b.limit}
+	if b.neighbourhood != "" {
+		queryTemplate += `
+	JOIN neighbourhoods ON addresses.neighbourhood_id = neighbourhoods.id
+	WHERE lower(neighbourhoods.name) = lower(@neighbourhood)`
+		queryArgs["neighbourhood"] = b.neighbourhood
+	}
+	queryTemplate += `
+	ORDER BY popularity.views DESC, buildings.id LIMIT @limit;`
+	return queryTemplate, queryArgs
+}
+
+func PopularSpecIsEqual(
+	since time.Time,
+	neighbourhood string,
+	limit int,
+) func(s *BuildingSpecificationPopular) bool {
+	return func(s *BuildingSpecificationPopular) bool {
+		return s.since.Equal(since) &&
+			s.neighbourhood == neighbourhood &&
+			s.limit == limit
+	}
+}
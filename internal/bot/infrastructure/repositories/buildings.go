@@ -22,8 +22,10 @@ func NewBuildingRepo(dbPool *pgxpool.Pool) *BuildingStorage {
 	return &BuildingStorage{dbPool}
 }
 
+// beginTransaction begins a transaction or a savepoint of a transaction
+// of a context.
 func (b *BuildingStorage) beginTransaction(ctx context.Context) (pgx.Tx, func(), error) {
-	transaction, err := b.dbPool.Begin(ctx)
+	transaction, err := getExecutor(ctx, b.dbPool).Begin(ctx)
 	if err != nil {
 		logMsg := "can not begin a transaction"
 		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
@@ -164,7 +166,7 @@ func (b *BuildingStorage) Add(ctx context.Context, building Building) (*Building
 }
 
 func (b *BuildingStorage) Remove(ctx context.Context, building Building) error {
-	_, err := b.dbPool.Exec(ctx, deleteBuilding, time.Now(), building.ID)
+	_, err := getExecutor(ctx, b.dbPool).Exec(ctx, deleteBuilding, time.Now(), building.ID)
 	if err != nil {
 		itemName := fmt.Sprintf("building %v", building.ID)
 		return processPostgresError(ctx, itemName, err)
@@ -316,8 +318,12 @@ func (b *BuildingStorage) Query(
 	spec Specification,
 ) ([]Building, error) {
 	query, queryArgs := spec.ToSQL()
-	slog.DebugContext(ctx, fmt.Sprintf("send the query %v: %v", query, queryArgs))
-	rows, err := b.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
+	rows, err := getExecutor(ctx, b.dbPool).Query(ctx, query, pgx.NamedArgs(queryArgs))
 	if err != nil {
 		logMsg := fmt.Sprintf("a query error: '%v'", query)
 		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
@@ -328,7 +334,7 @@ func (b *BuildingStorage) Query(
 	for rows.Next() {
 		var building Building
 		var address Address
-		if err := rows.Scan(
+		fields := []any{
 			&building.ID,
 			&building.Code,
 			&building.NameFi,
@@ -382,7 +388,12 @@ func (b *BuildingStorage) Query(
 			&address.CreatedAt,
 			&address.UpdatedAt,
 			&address.deletedAt,
-		); err != nil {
+		}
+		// some specifications select a distance to a point of interest
+		if hasField(rows, distanceField) {
+			fields = append(fields, &building.DistanceMeters)
+		}
+		if err := rows.Scan(fields...); err != nil {
 			slog.ErrorContext(
 				ctx,
 				fmt.Sprintf(
@@ -394,78 +405,124 @@ func (b *BuildingStorage) Query(
 			return nil, err
 		}
 		building.Address = address
+		buildings = append(buildings, building)
+	}
+	if err := rows.Err(); err != nil {
+		logMsg := fmt.Sprintf("can not read a query result: '%v'", query)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, err
+	}
+	rows.Close()
+	if err := b.setRelations(ctx, buildings); err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, fmt.Sprintf("found %v buildings", len(buildings)))
+	return buildings, nil
+}
 
-		authorIDs, err := b.getAuthorIds(ctx, building.ID)
-		if err != nil {
-			slog.ErrorContext(
-				ctx,
-				fmt.Sprintf("can not get authors for a building %v", building.ID),
-				slog.Any(logger.ErrorKey, err),
-			)
-			return nil, err
+// hasField reports whether a query result has a column.
+func hasField(rows pgx.Rows, name string) bool {
+	for _, field := range rows.FieldDescriptions() {
+		if field.Name == name {
+			return true
 		}
-		building.AuthorIDs = authorIDs
+	}
+	return false
+}
 
-		uses, err := b.getUses(ctx, initialUsesTable, building.ID)
-		if err != nil {
-			logMsg := fmt.Sprintf(
-				"can not get uses for a building '%v'",
-				building.ID,
-			)
-			slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
-			buildings = append(buildings, building)
-			continue
-		}
-		building.InitialUses = uses
+// Count returns a number of buildings for a specification
+// that selects a count.
+func (b *BuildingStorage) Count(ctx context.Context, spec Specification) (int, error) {
+	query, queryArgs := spec.ToSQL()
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
+	var count int
+	err := getExecutor(ctx, b.dbPool).QueryRow(ctx, query, pgx.NamedArgs(queryArgs)).Scan(&count)
+	if err != nil {
+		logMsg := fmt.Sprintf("a query error: '%v'", query)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return 0, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	return count, nil
+}
+
+// setRelations loads authors and uses of all buildings with one query
+// per relation, so a number of queries does not depend on a page size.
+func (b *BuildingStorage) setRelations(ctx context.Context, buildings []Building) error {
+	if len(buildings) == 0 {
+		return nil
+	}
+	buildingIDs := make([]int64, len(buildings))
+	for i, building := range buildings {
+		buildingIDs[i] = building.ID
+	}
+	authorIDs, err := b.getAuthorIds(ctx, buildingIDs)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not get authors for buildings %v", buildingIDs),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return err
+	}
+	for i := range buildings {
+		buildings[i].AuthorIDs = authorIDs[buildings[i].ID]
+	}
 
-		uses, err = b.getUses(ctx, currentUsesTable, building.ID)
+	for _, table := range []UseTableNames{initialUsesTable, currentUsesTable} {
+		uses, err := b.getUses(ctx, table, buildingIDs)
 		if err != nil {
-			logMsg := fmt.Sprintf(
-				"can not get uses for a building '%v'",
-				building.ID,
-			)
+			logMsg := fmt.Sprintf("can not get %v for buildings %v", table, buildingIDs)
 			slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
-			buildings = append(buildings, building)
-			continue
+			// buildings without uses are still worth showing
+			return nil
+		}
+		for i := range buildings {
+			buildingUses := uses[buildings[i].ID]
+			if table == initialUsesTable {
+				buildings[i].InitialUses = buildingUses
+			} else {
+				buildings[i].CurrentUses = buildingUses
+			}
 		}
-		building.CurrentUses = uses
-		buildings = append(buildings, building)
 	}
-	slog.DebugContext(ctx, fmt.Sprintf("found %v buildings", len(buildings)))
-	return buildings, nil
+	return nil
 }
 
 func (b *BuildingStorage) getAuthorIds(
 	ctx context.Context,
-	buildingID int64,
-) ([]int64, error) {
-	authorQuery := `SELECT actor_id FROM building_authors 
-	WHERE building_id = $1;`
-	rows, err := b.dbPool.Query(ctx, authorQuery, buildingID)
+	buildingIDs []int64,
+) (map[int64][]int64, error) {
+	authorQuery := `SELECT building_id, actor_id FROM building_authors 
+	WHERE building_id = ANY($1);`
+	rows, err := getExecutor(ctx, b.dbPool).Query(ctx, authorQuery, buildingIDs)
 	if err != nil {
 		logMsg := fmt.Sprintf(
-			"a query error for a building %v: '%v'",
-			buildingID,
+			"a query error for buildings %v: '%v'",
+			buildingIDs,
 			authorQuery,
 		)
 		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
 		return nil, fmt.Errorf("%v: %w", logMsg, err)
 	}
 	defer rows.Close()
-	var authorIDs []int64
+	authorIDs := make(map[int64][]int64, len(buildingIDs))
 	for rows.Next() {
-		var authorID int64
-		if err := rows.Scan(&authorID); err != nil {
+		var buildingID, authorID int64
+		if err := rows.Scan(&buildingID, &authorID); err != nil {
 			msg := fmt.Sprintf(
-				"can not scan an author ID for a building '%v'",
-				buildingID,
+				"can not scan an author ID for buildings %v",
+				buildingIDs,
 			)
 			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
 			return nil, err
 		}
-		authorIDs = append(authorIDs, authorID)
+		authorIDs[buildingID] = append(authorIDs[buildingID], authorID)
 	}
-	return authorIDs, nil
+	return authorIDs, rows.Err()
 }
 
 type UseTableNames string
@@ -478,22 +535,24 @@ const (
 func (b *BuildingStorage) getUses(
 	ctx context.Context,
 	table_name UseTableNames,
-	buildingID int64,
-) ([]UseType, error) {
-	query := fmt.Sprintf(`SELECT id, name_fi, name_en, name_ru, 
+	buildingIDs []int64,
+) (map[int64][]UseType, error) {
+	query := fmt.Sprintf(`SELECT building_id, id, name_fi, name_en, name_ru, 
 	created_at,updated_at, deleted_at FROM use_types JOIN %v
-	ON id = use_type_id WHERE building_id = $1;`, table_name)
-	rows, err := b.dbPool.Query(ctx, query, buildingID)
+	ON id = use_type_id WHERE building_id = ANY($1);`, table_name)
+	rows, err := getExecutor(ctx, b.dbPool).Query(ctx, query, buildingIDs)
 	if err != nil {
 		logMsg := fmt.Sprintf("a query error: '%v'", query)
 		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
 		return nil, fmt.Errorf("%v: %w", logMsg, err)
 	}
 	defer rows.Close()
-	var uses []UseType
+	uses := make(map[int64][]UseType, len(buildingIDs))
 	for rows.Next() {
+		var buildingID int64
 		var use UseType
 		if err := rows.Scan(
+			&buildingID,
 			&use.ID,
 			&use.NameFi,
 			&use.NameEn,
@@ -503,16 +562,16 @@ func (b *BuildingStorage) getUses(
 			&use.deletedAt,
 		); err != nil {
 			msg := fmt.Sprintf(
-				"can not scan %v for a building '%v'",
+				"can not scan %v for buildings %v",
 				table_name,
-				buildingID,
+				buildingIDs,
 			)
 			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
 			return nil, err
 		}
-		uses = append(uses, use)
+		uses[buildingID] = append(uses[buildingID], use)
 	}
-	return uses, nil
+	return uses, rows.Err()
 }
 
 func (b *BuildingStorage) getAddress(
@@ -0,0 +1,232 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/cache"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const buildingCacheName = "buildings"
+
+type buildingQueryResult struct {
+	buildings []Building
+	count     int
+}
+
+// CachedBuildingStorage is a read-through cache of query and count results.
+// Any change of buildings flushes the whole cache because one building
+// can be a part of many cached results.
+type CachedBuildingStorage struct {
+	BuildingRepository
+	results *cache.LRU[string, buildingQueryResult]
+	mu      sync.Mutex
+	// generation prevents caching a result which was read before a flush
+	generation int64
+	metrics    *metrics.Metrics
+}
+
+func NewCachedBuildingRepo(
+	repository BuildingRepository,
+	size int,
+	ttl time.Duration,
+	metrics *metrics.Metrics,
+) *CachedBuildingStorage {
+	return &CachedBuildingStorage{
+		BuildingRepository: repository,
+		results:            cache.NewLRU[string, buildingQueryResult](size, ttl),
+		metrics:            metrics,
+	}
+}
+
+func getCacheKey(method string, spec Specification) string {
+	query, queryArgs := spec.ToSQL()
+	// fmt sorts map keys, so equal arguments produce equal keys
+	return fmt.Sprintf("%s:%s%v", method, query, queryArgs)
+}
+
+func (c *CachedBuildingStorage) Add(ctx context.Context, building Building) (*Building, error) {
+	defer afterCommit(ctx, c.Flush)
+	return c.BuildingRepository.Add(ctx, building)
+}
+
+func (c *CachedBuildingStorage) Remove(ctx context.Context, building Building) error {
+	defer afterCommit(ctx, c.Flush)
+	return c.BuildingRepository.Remove(ctx, building)
+}
+
+func (c *CachedBuildingStorage) Update(ctx context.Context, building Building) (*Building, error) {
+	defer afterCommit(ctx, c.Flush)
+	return c.BuildingRepository.Update(ctx, building)
+}
+
+// Query skips the cache inside a transaction, because the cache
+// does not know about its uncommitted changes and locks.
+func (c *CachedBuildingStorage) Query(ctx context.Context, spec Specification) ([]Building, error) {
+	if inTransaction(ctx) {
+		return c.BuildingRepository.Query(ctx, spec)
+	}
+	key := getCacheKey("query", spec)
+	if result, ok := c.get(key); ok {
+		return cloneBuildings(result.buildings), nil
+	}
+	generation := c.getGeneration()
+	buildings, err := c.BuildingRepository.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, generation, buildingQueryResult{buildings: cloneBuildings(buildings)})
+	return buildings, nil
+}
+
+func (c *CachedBuildingStorage) Count(ctx context.Context, spec Specification) (int, error) {
+	if inTransaction(ctx) {
+		return c.BuildingRepository.Count(ctx, spec)
+	}
+	key := getCacheKey("count", spec)
+	if result, ok := c.get(key); ok {
+		return result.count, nil
+	}
+	generation := c.getGeneration()
+	count, err := c.BuildingRepository.Count(ctx, spec)
+	if err != nil {
+		return 0, err
+	}
+	c.set(key, generation, buildingQueryResult{count: count})
+	return count, nil
+}
+
+// Flush removes all cached results.
+func (c *CachedBuildingStorage) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.results.Purge()
+	c.observeSize()
+}
+
+func (c *CachedBuildingStorage) getGeneration() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *CachedBuildingStorage) get(key string) (buildingQueryResult, bool) {
+	labels := prometheus.Labels{"cache": buildingCacheName}
+	result, ok := c.results.Get(key)
+	if ok {
+		c.metrics.CacheHits.With(labels).Inc()
+	} else {
+		c.metrics.CacheMisses.With(labels).Inc()
+	}
+	return result, ok
+}
+
+func (c *CachedBuildingStorage) set(key string, generation int64, result buildingQueryResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	c.results.Set(key, result)
+	c.observeSize()
+}
+
+func (c *CachedBuildingStorage) observeSize() {
+	labels := prometheus.Labels{"cache": buildingCacheName}
+	c.metrics.CacheSize.With(labels).Set(float64(c.results.Len()))
+}
+
+// cloneBuildings copies buildings with their pointers and slices,
+// so that callers can not change cached results.
+func cloneBuildings(buildings []Building) []Building {
+	if buildings == nil {
+		return nil
+	}
+	result := make([]Building, len(buildings))
+	for i, building := range buildings {
+		result[i] = cloneBuilding(building)
+	}
+	return result
+}
+
+func cloneBuilding(b Building) Building {
+	b.Code = clonePointer(b.Code)
+	b.NameFi = clonePointer(b.NameFi)
+	b.NameEn = clonePointer(b.NameEn)
+	b.NameRu = clonePointer(b.NameRu)
+	b.ConstructionStartYear = clonePointer(b.ConstructionStartYear)
+	b.CompletionYear = clonePointer(b.CompletionYear)
+	b.ComplexFi = clonePointer(b.ComplexFi)
+	b.ComplexEn = clonePointer(b.ComplexEn)
+	b.ComplexRu = clonePointer(b.ComplexRu)
+	b.HistoryFi = clonePointer(b.HistoryFi)
+	b.HistoryEn = clonePointer(b.HistoryEn)
+	b.HistoryRu = clonePointer(b.HistoryRu)
+	b.ReasoningFi = clonePointer(b.ReasoningFi)
+	b.ReasoningEn = clonePointer(b.ReasoningEn)
+	b.ReasoningRu = clonePointer(b.ReasoningRu)
+	b.ProtectionStatusFi = clonePointer(b.ProtectionStatusFi)
+	b.ProtectionStatusEn = clonePointer(b.ProtectionStatusEn)
+	b.ProtectionStatusRu = clonePointer(b.ProtectionStatusRu)
+	b.InfoSourceFi = clonePointer(b.InfoSourceFi)
+	b.InfoSourceEn = clonePointer(b.InfoSourceEn)
+	b.InfoSourceRu = clonePointer(b.InfoSourceRu)
+	b.SurroundingsFi = clonePointer(b.SurroundingsFi)
+	b.SurroundingsEn = clonePointer(b.SurroundingsEn)
+	b.SurroundingsRu = clonePointer(b.SurroundingsRu)
+	b.FoundationFi = clonePointer(b.FoundationFi)
+	b.FoundationEn = clonePointer(b.FoundationEn)
+	b.FoundationRu = clonePointer(b.FoundationRu)
+	b.FrameFi = clonePointer(b.FrameFi)
+	b.FrameEn = clonePointer(b.FrameEn)
+	b.FrameRu = clonePointer(b.FrameRu)
+	b.FloorDescriptionFi = clonePointer(b.FloorDescriptionFi)
+	b.FloorDescriptionEn = clonePointer(b.FloorDescriptionEn)
+	b.FloorDescriptionRu = clonePointer(b.FloorDescriptionRu)
+	b.FacadesFi = clonePointer(b.FacadesFi)
+	b.FacadesEn = clonePointer(b.FacadesEn)
+	b.FacadesRu = clonePointer(b.FacadesRu)
+	b.SpecialFeaturesFi = clonePointer(b.SpecialFeaturesFi)
+	b.SpecialFeaturesEn = clonePointer(b.SpecialFeaturesEn)
+	b.SpecialFeaturesRu = clonePointer(b.SpecialFeaturesRu)
+	b.Latitude_ETRSGK25 = clonePointer(b.Latitude_ETRSGK25)
+	b.Longitude_ETRSGK25 = clonePointer(b.Longitude_ETRSGK25)
+	b.Latitude_WGS84 = clonePointer(b.Latitude_WGS84)
+	b.Longitude_WGS84 = clonePointer(b.Longitude_WGS84)
+	b.DistanceMeters = clonePointer(b.DistanceMeters)
+	b.Address.NeighbourhoodID = clonePointer(b.Address.NeighbourhoodID)
+	b.Address.Timestamps = cloneTimestamps(b.Address.Timestamps)
+	b.AuthorIDs = slices.Clone(b.AuthorIDs)
+	b.InitialUses = cloneUseTypes(b.InitialUses)
+	b.CurrentUses = cloneUseTypes(b.CurrentUses)
+	b.Timestamps = cloneTimestamps(b.Timestamps)
+	return b
+}
+
+func cloneUseTypes(uses []UseType) []UseType {
+	uses = slices.Clone(uses)
+	for i := range uses {
+		uses[i].Timestamps = cloneTimestamps(uses[i].Timestamps)
+	}
+	return uses
+}
+
+func cloneTimestamps(t Timestamps) Timestamps {
+	t.UpdatedAt = clonePointer(t.UpdatedAt)
+	t.deletedAt = clonePointer(t.deletedAt)
+	return t
+}
+
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	value := *p
+	return &value
+}
@@ -0,0 +1,251 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(
+	t *testing.T,
+) (*CachedBuildingStorage, *BuildingRepository_mock, *metrics.Metrics) {
+	repository := NewBuildingRepository_mock(t)
+	registeredMetrics := metrics.NewMetrics(prometheus.NewRegistry())
+	storage := NewCachedBuildingRepo(repository, 10, time.Minute, registeredMetrics)
+	return storage, repository, registeredMetrics
+}
+
+func TestCachedBuildingStorage_Query(t *testing.T) {
+	storage, repository, registeredMetrics := newTestCache(t)
+	ctx := context.Background()
+	buildings := []Building{{ID: 1}, {ID: 2}}
+	spec := NewBuildingSpecificationAll(2, 0)
+	repository.EXPECT().Query(mock.Anything, spec).Return(buildings, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		got, err := storage.Query(ctx, spec)
+		require.NoError(t, err)
+		require.Equal(t, buildings, got)
+	}
+	labels := prometheus.Labels{"cache": buildingCacheName}
+	require.Equal(t, 2.0, testutil.ToFloat64(registeredMetrics.CacheHits.With(labels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(registeredMetrics.CacheMisses.With(labels)))
+	require.Equal(t, 1.0, testutil.ToFloat64(registeredMetrics.CacheSize.With(labels)))
+
+	other := NewBuildingSpecificationAll(2, 2)
+	repository.EXPECT().Query(mock.Anything, other).Return(nil, nil).Once()
+	got, err := storage.Query(ctx, other)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestCachedBuildingStorage_QueryInTransaction(t *testing.T) {
+	storage, repository, registeredMetrics := newTestCache(t)
+	ctx := context.WithValue(context.Background(), transactionKey{}, &transaction{})
+	buildings := []Building{{ID: 1}}
+	spec := NewBuildingSpecificationByIDForUpdate(1)
+	repository.EXPECT().Query(ctx, spec).Return(buildings, nil).Twice()
+
+	for i := 0; i < 2; i++ {
+		got, err := storage.Query(ctx, spec)
+		require.NoError(t, err)
+		require.Equal(t, buildings, got)
+	}
+	labels := prometheus.Labels{"cache": buildingCacheName}
+	require.Equal(t, 0.0, testutil.ToFloat64(registeredMetrics.CacheSize.With(labels)))
+}
+
+func TestCachedBuildingStorage_QueryError(t *testing.T) {
+	storage, repository, _ := newTestCache(t)
+	ctx := context.Background()
+	spec := NewBuildingSpecificationAll(2, 0)
+	expectedErr := errors.New("test error")
+	repository.EXPECT().Query(mock.Anything, spec).Return(nil, expectedErr).Once()
+	repository.EXPECT().Query(mock.Anything, spec).Return([]Building{{ID: 1}}, nil).Once()
+
+	_, err := storage.Query(ctx, spec)
+	require.ErrorIs(t, err, expectedErr)
+	got, err := storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, []Building{{ID: 1}}, got)
+}
+
+func TestCachedBuildingStorage_Count(t *testing.T) {
+	storage, repository, _ := newTestCache(t)
+	ctx := context.Background()
+	spec := NewBuildingCountSpecificationByAlikeAddress("test")
+	repository.EXPECT().Count(mock.Anything, spec).Return(5, nil).Once()
+	repository.EXPECT().Query(mock.Anything, spec).Return([]Building{{ID: 1}}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		count, err := storage.Count(ctx, spec)
+		require.NoError(t, err)
+		require.Equal(t, 5, count)
+	}
+	got, err := storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, []Building{{ID: 1}}, got)
+}
+
+func TestCachedBuildingStorage_flush(t *testing.T) {
+	building := Building{ID: 1}
+	tests := []struct {
+		name   string
+		change func(context.Context, *CachedBuildingStorage, *BuildingRepository_mock)
+	}{
+		{
+			"add",
+			func(ctx context.Context, s *CachedBuildingStorage, r *BuildingRepository_mock) {
+				r.EXPECT().Add(mock.Anything, building).Return(&building, nil).Once()
+				_, err := s.Add(ctx, building)
+				require.NoError(t, err)
+			},
+		},
+		{
+			"update",
+			func(ctx context.Context, s *CachedBuildingStorage, r *BuildingRepository_mock) {
+				r.EXPECT().Update(mock.Anything, building).Return(&building, nil).Once()
+				_, err := s.Update(ctx, building)
+				require.NoError(t, err)
+			},
+		},
+		{
+			"remove",
+			func(ctx context.Context, s *CachedBuildingStorage, r *BuildingRepository_mock) {
+				r.EXPECT().Remove(mock.Anything, building).Return(nil).Once()
+				require.NoError(t, s.Remove(ctx, building))
+			},
+		},
+		{
+			"flush",
+			func(ctx context.Context, s *CachedBuildingStorage, r *BuildingRepository_mock) {
+				s.Flush()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, repository, _ := newTestCache(t)
+			ctx := context.Background()
+			spec := NewBuildingSpecificationAll(1, 0)
+			repository.EXPECT().Query(mock.Anything, spec).Return([]Building{building}, nil).Twice()
+
+			_, err := storage.Query(ctx, spec)
+			require.NoError(t, err)
+			tt.change(ctx, storage, repository)
+			_, err = storage.Query(ctx, spec)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCachedBuildingStorage_flushDuringQuery(t *testing.T) {
+	storage, repository, _ := newTestCache(t)
+	ctx := context.Background()
+	spec := NewBuildingSpecificationAll(1, 0)
+	repository.EXPECT().
+		Query(mock.Anything, spec).
+		Run(func(context.Context, Specification) { storage.Flush() }).
+		Return([]Building{{ID: 1}}, nil).
+		Once()
+	repository.EXPECT().Query(mock.Anything, spec).Return([]Building{{ID: 2}}, nil).Once()
+
+	got, err := storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, []Building{{ID: 1}}, got)
+	got, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, []Building{{ID: 2}}, got)
+}
+
+// fillValue sets every pointer and slice of a value, so that a copy
+// can be checked for shared memory.
+func fillValue(v reflect.Value) {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(v.Index(0))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// unexported fields are settable through their address
+			field := reflect.NewAt(v.Field(i).Type(), v.Field(i).Addr().UnsafePointer()).Elem()
+			fillValue(field)
+		}
+	}
+}
+
+func requireNoSharedMemory(t *testing.T, original, copied reflect.Value, path string) {
+	// time values are immutable
+	if original.Type() == reflect.TypeOf(time.Time{}) {
+		return
+	}
+	switch original.Kind() {
+	case reflect.Pointer, reflect.Slice:
+		require.NotEqual(t, original.Pointer(), copied.Pointer(), path)
+		if original.Kind() == reflect.Slice {
+			for i := 0; i < original.Len(); i++ {
+				requireNoSharedMemory(t, original.Index(i), copied.Index(i), path)
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < original.NumField(); i++ {
+			name := path + "." + original.Type().Field(i).Name
+			requireNoSharedMemory(t, original.Field(i), copied.Field(i), name)
+		}
+	}
+}
+
+func TestCloneBuildings(t *testing.T) {
+	var building Building
+	fillValue(reflect.ValueOf(&building).Elem())
+	buildings := []Building{building}
+	copied := cloneBuildings(buildings)
+	require.Equal(t, buildings, copied)
+	requireNoSharedMemory(
+		t,
+		reflect.ValueOf(buildings),
+		reflect.ValueOf(copied),
+		"Building",
+	)
+	require.Nil(t, cloneBuildings(nil))
+}
+
+func TestCachedBuildingStorage_Query_copy(t *testing.T) {
+	storage, repository, _ := newTestCache(t)
+	ctx := context.Background()
+	spec := NewBuildingSpecificationAll(1, 0)
+	name := "name"
+	repository.EXPECT().
+		Query(mock.Anything, spec).
+		Return([]Building{{ID: 1, NameEn: &name, AuthorIDs: []int64{2}}}, nil).
+		Once()
+	expected := []Building{{ID: 1, NameEn: &name, AuthorIDs: []int64{2}}}
+
+	got, err := storage.Query(ctx, spec)
+	require.NoError(t, err)
+	*got[0].NameEn = "changed by a caller"
+	got[0].AuthorIDs[0] = 3
+	name = "name"
+	got, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+	*got[0].NameEn = "changed by a caller"
+	got[0].AuthorIDs[0] = 3
+	name = "name"
+	got, err = storage.Query(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+}
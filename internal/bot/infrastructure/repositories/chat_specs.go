@@ -0,0 +1,22 @@
+package repositories
+
+type ChatSpecificationByTelegramID struct {
+	telegramID int64
+}
+
+func NewChatSpecificationByID(telegramID int64) *ChatSpecificationByTelegramID {
+	return &ChatSpecificationByTelegramID{telegramID}
+}
+
+func (c *ChatSpecificationByTelegramID) ToSQL() (string, map[string]any) {
+	query := `SELECT id, telegram_id, language, created_at,
+	updated_at, deleted_at FROM chats
+	WHERE telegram_id = @telegram_id AND deleted_at IS NULL;`
+	return query, map[string]any{"telegram_id": c.telegramID}
+}
+
+func ChatByIDIsEqual(telegramID int64) func(s *ChatSpecificationByTelegramID) bool {
+	return func(s *ChatSpecificationByTelegramID) bool {
+		return telegramID == s.telegramID
+	}
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type chatStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewChatRepo(dbPool *pgxpool.Pool) ChatRepository {
+	return &chatStorage{dbPool}
+}
+
+func (s *chatStorage) AddOrUpdate(ctx context.Context, chat Chat) (*Chat, error) {
+	insertQuery := `INSERT INTO chats (telegram_id, language)
+	VALUES ($1, $2) ON CONFLICT (telegram_id) DO UPDATE
+	SET language = $2, updated_at = now()
+	RETURNING id, created_at, updated_at;`
+	err := s.dbPool.QueryRow(
+		ctx,
+		insertQuery,
+		chat.TelegramID,
+		chat.PreferredLanguage,
+	).Scan(&chat.ID, &chat.CreatedAt, &chat.UpdatedAt)
+	if err != nil {
+		logMsg := fmt.Sprintf(
+			"can not add or update a chat %v: %v",
+			chat.TelegramID,
+			chat.PreferredLanguage,
+		)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func (s *chatStorage) Query(ctx context.Context, spec Specification) ([]Chat, error) {
+	query, queryArgs := spec.ToSQL()
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
+	rows, err := s.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
+	if err != nil {
+		logMsg := fmt.Sprintf("a query error: '%v'", query)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	defer rows.Close()
+	var chats []Chat
+	for rows.Next() {
+		var chat Chat
+		if err := rows.Scan(
+			&chat.ID,
+			&chat.TelegramID,
+			&chat.PreferredLanguage,
+			&chat.CreatedAt,
+			&chat.UpdatedAt,
+			&chat.deletedAt,
+		); err != nil {
+			msg := fmt.Sprintf("can not scan a chat from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
+			)
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	return chats, nil
+}
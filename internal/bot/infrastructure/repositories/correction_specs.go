@@ -0,0 +1,59 @@
+package repositories
+
+const selectCorrectionFields = `SELECT id, building_id, field, language, value,
+	reporter_id, chat_id, status, created_at, updated_at, deleted_at
+	FROM corrections`
+
+type CorrectionSpecificationByID struct {
+	id int64
+}
+
+func NewCorrectionSpecificationByID(id int64) *CorrectionSpecificationByID {
+	return &CorrectionSpecificationByID{id}
+}
+
+func (c *CorrectionSpecificationByID) ToSQL() (string, map[string]any) {
+	query := selectCorrectionFields + ` WHERE id = @id AND deleted_at IS NULL;`
+	return query, map[string]any{"id": c.id}
+}
+
+func CorrectionByIDIsEqual(id int64) func(s *CorrectionSpecificationByID) bool {
+	return func(s *CorrectionSpecificationByID) bool {
+		return id == s.id
+	}
+}
+
+type CorrectionSpecificationByStatus struct {
+	status CorrectionStatus
+	limit  int
+	offset int
+}
+
+func NewCorrectionSpecificationByStatus(
+	status CorrectionStatus,
+	limit,
+	offset int,
+) *CorrectionSpecificationByStatus {
+	return &CorrectionSpecificationByStatus{status, limit, offset}
+}
+
+func (c *CorrectionSpecificationByStatus) ToSQL() (string, map[string]any) {
+	query := selectCorrectionFields + ` WHERE status = @status
+	AND deleted_at IS NULL ORDER BY created_at, id LIMIT @limit OFFSET @offset;`
+	queryArgs := map[string]any{
+		"status": string(c.status),
+		"limit":  c.limit,
+		"offset": c.offset,
+	}
+	return query, queryArgs
+}
+
+func CorrectionByStatusIsEqual(
+	status CorrectionStatus,
+	limit,
+	offset int,
+) func(s *CorrectionSpecificationByStatus) bool {
+	return func(s *CorrectionSpecificationByStatus) bool {
+		return s.status == status && s.limit == limit && s.offset == offset
+	}
+}
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type correctionStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewCorrectionRepo(dbPool *pgxpool.Pool) CorrectionRepository {
+	return &correctionStorage{dbPool}
+}
+
+func (s *correctionStorage) Add(
+	ctx context.Context,
+	correction Correction,
+) (*Correction, error) {
+	insertQuery := `INSERT INTO corrections
+	(building_id, field, language, value, reporter_id, chat_id, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at;`
+	if correction.Status == "" {
+		correction.Status = CorrectionPending
+	}
+	err := s.dbPool.QueryRow(
+		ctx,
+		insertQuery,
+		correction.BuildingID,
+		correction.Field,
+		correction.Language,
+		correction.Value,
+		correction.ReporterID,
+		correction.ChatID,
+		string(correction.Status),
+	).Scan(&correction.ID, &correction.CreatedAt)
+	if err != nil {
+		itemName := fmt.Sprintf(
+			"correction '%v-%v' of the building %v",
+			correction.Field,
+			correction.Language,
+			correction.BuildingID,
+		)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	return &correction, nil
+}
+
+func (s *correctionStorage) Remove(ctx context.Context, correction Correction) error {
+	query := `UPDATE corrections SET deleted_at = $1
+	WHERE id = $2 AND deleted_at IS NULL;`
+	_, err := s.dbPool.Exec(ctx, query, time.Now(), correction.ID)
+	if err != nil {
+		itemName := fmt.Sprintf("correction %v", correction.ID)
+		return processPostgresError(ctx, itemName, err)
+	}
+	return nil
+}
+
+// Update changes a pending correction only, a moderated one is ErrNotExist.
+func (s *correctionStorage) Update(
+	ctx context.Context,
+	correction Correction,
+) (*Correction, error) {
+	query := `UPDATE corrections SET value = $1, status = $2, updated_at = $3
+	WHERE id = $4 AND status = $5 AND deleted_at IS NULL;`
+	updatedAt := time.Now()
+	result, err := getExecutor(ctx, s.dbPool).Exec(
+		ctx,
+		query,
+		correction.Value,
+		string(correction.Status),
+		updatedAt,
+		correction.ID,
+		string(CorrectionPending),
+	)
+	if err != nil {
+		itemName := fmt.Sprintf("correction %v", correction.ID)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrNotExist
+	}
+	correction.UpdatedAt = &updatedAt
+	return &correction, nil
+}
+
+func (s *correctionStorage) Query(
+	ctx context.Context,
+	spec Specification,
+) ([]Correction, error) {
+	query, queryArgs := spec.ToSQL()
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
+	rows, err := getExecutor(ctx, s.dbPool).Query(ctx, query, pgx.NamedArgs(queryArgs))
+	if err != nil {
+		logMsg := fmt.Sprintf("a query error: '%v'", query)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	defer rows.Close()
+	var corrections []Correction
+	for rows.Next() {
+		var correction Correction
+		if err := rows.Scan(
+			&correction.ID,
+			&correction.BuildingID,
+			&correction.Field,
+			&correction.Language,
+			&correction.Value,
+			&correction.ReporterID,
+			&correction.ChatID,
+			&correction.Status,
+			&correction.CreatedAt,
+			&correction.UpdatedAt,
+			&correction.deletedAt,
+		); err != nil {
+			msg := fmt.Sprintf("can not scan a correction from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
+			)
+			return nil, err
+		}
+		corrections = append(corrections, correction)
+	}
+	return corrections, nil
+}
@@ -1,12 +1,16 @@
 package repositories
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type BuildingRepository interface {
 	Add(context.Context, Building) (*Building, error)
 	Remove(context.Context, Building) error
 	Update(context.Context, Building) (*Building, error)
 	Query(context.Context, Specification) ([]Building, error)
+	Count(context.Context, Specification) (int, error)
 }
 
 type NeighbourhoodRepository interface {
@@ -29,6 +33,51 @@ type UserRepository interface {
 	Remove(context.Context, User) error
 	Update(context.Context, User) (*User, error)
 	Query(context.Context, Specification) ([]User, error)
+	// CountPerLanguage returns a number of users per a language code
+	CountPerLanguage(context.Context) (map[string]int, error)
+}
+
+type ChatRepository interface {
+	AddOrUpdate(context.Context, Chat) (*Chat, error)
+	Query(context.Context, Specification) ([]Chat, error)
+}
+
+type UpdateRepository interface {
+	Add(context.Context, ProcessedUpdate) (*ProcessedUpdate, error)
+	Exists(context.Context, int64) (bool, error)
+	GetOffset(context.Context) (int64, error)
+	SetOffset(context.Context, int64) error
+}
+
+type CorrectionRepository interface {
+	Add(context.Context, Correction) (*Correction, error)
+	Remove(context.Context, Correction) error
+	Update(context.Context, Correction) (*Correction, error)
+	Query(context.Context, Specification) ([]Correction, error)
+}
+
+type PhotoRepository interface {
+	Add(context.Context, BuildingPhoto) (*BuildingPhoto, error)
+	Remove(context.Context, BuildingPhoto) error
+	Update(context.Context, BuildingPhoto) (*BuildingPhoto, error)
+	Query(context.Context, Specification) ([]BuildingPhoto, error)
+}
+
+type PopularityRepository interface {
+	Add(context.Context, PopularityEvent) (*PopularityEvent, error)
+	// RemoveBefore removes events created before a time and returns their number
+	RemoveBefore(context.Context, time.Time) (int64, error)
+}
+
+// TransactionRepository runs a function in one transaction of repositories
+// which get a context of the function.
+type TransactionRepository interface {
+	Run(context.Context, func(context.Context) error) error
+}
+
+type MigrationRepository interface {
+	// GetVersion returns a schema version and whether it is dirty
+	GetVersion(context.Context) (int64, bool, error)
 }
 
 type Specification interface {
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type migrationStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewMigrationRepo(dbPool *pgxpool.Pool) MigrationRepository {
+	return &migrationStorage{dbPool}
+}
+
+// GetVersion returns a schema version which golang-migrate has applied.
+// A dirty version means that a migration has failed.
+func (s *migrationStorage) GetVersion(ctx context.Context) (int64, bool, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1;`
+	var version int64
+	var dirty bool
+	err := s.dbPool.QueryRow(ctx, query).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "can not get a schema version", slog.Any(logger.ErrorKey, err))
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
@@ -80,6 +80,63 @@ func (_c *BuildingRepository_mock_Add_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// Count provides a mock function with given fields: _a0, _a1
+func (_m *BuildingRepository_mock) Count(_a0 context.Context, _a1 Specification) (int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Specification) (int, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Specification) int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Specification) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BuildingRepository_mock_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type BuildingRepository_mock_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 Specification
+func (_e *BuildingRepository_mock_Expecter) Count(_a0 interface{}, _a1 interface{}) *BuildingRepository_mock_Count_Call {
+	return &BuildingRepository_mock_Count_Call{Call: _e.mock.On("Count", _a0, _a1)}
+}
+
+func (_c *BuildingRepository_mock_Count_Call) Run(run func(_a0 context.Context, _a1 Specification)) *BuildingRepository_mock_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Specification))
+	})
+	return _c
+}
+
+func (_c *BuildingRepository_mock_Count_Call) Return(_a0 int, _a1 error) *BuildingRepository_mock_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BuildingRepository_mock_Count_Call) RunAndReturn(run func(context.Context, Specification) (int, error)) *BuildingRepository_mock_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Query provides a mock function with given fields: _a0, _a1
 func (_m *BuildingRepository_mock) Query(_a0 context.Context, _a1 Specification) ([]Building, error) {
 	ret := _m.Called(_a0, _a1)
@@ -139,6 +139,64 @@ func (_c *UserRepository_mock_AddOrUpdate_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// CountPerLanguage provides a mock function with given fields: _a0
+func (_m *UserRepository_mock) CountPerLanguage(_a0 context.Context) (map[string]int, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPerLanguage")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepository_mock_CountPerLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPerLanguage'
+type UserRepository_mock_CountPerLanguage_Call struct {
+	*mock.Call
+}
+
+// CountPerLanguage is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *UserRepository_mock_Expecter) CountPerLanguage(_a0 interface{}) *UserRepository_mock_CountPerLanguage_Call {
+	return &UserRepository_mock_CountPerLanguage_Call{Call: _e.mock.On("CountPerLanguage", _a0)}
+}
+
+func (_c *UserRepository_mock_CountPerLanguage_Call) Run(run func(_a0 context.Context)) *UserRepository_mock_CountPerLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserRepository_mock_CountPerLanguage_Call) Return(_a0 map[string]int, _a1 error) *UserRepository_mock_CountPerLanguage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepository_mock_CountPerLanguage_Call) RunAndReturn(run func(context.Context) (map[string]int, error)) *UserRepository_mock_CountPerLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Query provides a mock function with given fields: _a0, _a1
 func (_m *UserRepository_mock) Query(_a0 context.Context, _a1 Specification) ([]User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -118,7 +118,11 @@ func (n *neighbourhoodStorage) Update(ctx context.Context, neighbourhood Neighbo
 
 func (n *neighbourhoodStorage) Query(ctx context.Context, spec Specification) ([]Neighbourhood, error) {
 	query, queryArgs := spec.ToSQL()
-	slog.DebugContext(ctx, fmt.Sprintf("send the query %v: %v", query, queryArgs))
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
 	rows, err := n.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
 	if err != nil {
 		logMsg := fmt.Sprintf("a query error: '%v'", query)
@@ -137,12 +141,13 @@ func (n *neighbourhoodStorage) Query(ctx context.Context, spec Specification) ([
 			&neigbourhood.UpdatedAt,
 			&neigbourhood.deletedAt,
 		); err != nil {
-			msg := fmt.Sprintf(
-				"can not scan an actor from a query result: %v: %v",
-				query,
-				queryArgs,
+			msg := fmt.Sprintf("can not scan a neighbourhood from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
 			)
-			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
 			return nil, err
 		}
 		neigbourhoods = append(neigbourhoods, neigbourhood)
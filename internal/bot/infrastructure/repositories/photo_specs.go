@@ -0,0 +1,95 @@
+package repositories
+
+const selectPhotoFields = `SELECT id, building_id, file_id, file_unique_id,
+	attribution, uploader_id, chat_id, status, created_at, updated_at, deleted_at
+	FROM building_photos`
+
+type PhotoSpecificationByID struct {
+	id int64
+}
+
+func NewPhotoSpecificationByID(id int64) *PhotoSpecificationByID {
+	return &PhotoSpecificationByID{id}
+}
+
+func (p *PhotoSpecificationByID) ToSQL() (string, map[string]any) {
+	query := selectPhotoFields + ` WHERE id = @id AND deleted_at IS NULL;`
+	return query, map[string]any{"id": p.id}
+}
+
+func PhotoByIDIsEqual(id int64) func(s *PhotoSpecificationByID) bool {
+	return func(s *PhotoSpecificationByID) bool {
+		return id == s.id
+	}
+}
+
+type PhotoSpecificationByBuilding struct {
+	buildingID int64
+	status     PhotoStatus
+	limit      int
+}
+
+func NewPhotoSpecificationByBuilding(
+	buildingID int64,
+	status PhotoStatus,
+	limit int,
+) *PhotoSpecificationByBuilding {
+	return &PhotoSpecificationByBuilding{buildingID, status, limit}
+}
+
+func (p *PhotoSpecificationByBuilding) ToSQL() (string, map[string]any) {
+	query := selectPhotoFields + ` WHERE building_id = @building_id
+	AND status = @status AND deleted_at IS NULL
+	ORDER BY created_at, id LIMIT @limit;`
+	queryArgs := map[string]any{
+		"building_id": p.buildingID,
+		"status":      string(p.status),
+		"limit":       p.limit,
+	}
+	return query, queryArgs
+}
+
+func PhotoByBuildingIsEqual(
+	buildingID int64,
+	status PhotoStatus,
+	limit int,
+) func(s *PhotoSpecificationByBuilding) bool {
+	return func(s *PhotoSpecificationByBuilding) bool {
+		return s.buildingID == buildingID && s.status == status && s.limit == limit
+	}
+}
+
+type PhotoSpecificationByStatus struct {
+	status PhotoStatus
+	limit  int
+	offset int
+}
+
+func NewPhotoSpecificationByStatus(
+	status PhotoStatus,
+	limit,
+	offset int,
+) *PhotoSpecificationByStatus {
+	return &PhotoSpecificationByStatus{status, limit, offset}
+}
+
+func (p *PhotoSpecificationByStatus) ToSQL() (string, map[string]any) {
+	query := selectPhotoFields + ` WHERE status = @status
+	AND deleted_at IS NULL ORDER BY created_at, id LIMIT @limit OFFSET @offset;`
+	queryArgs := map[string]any{
+		"status": string(p.status),
+		"limit":  p.limit,
+		"offset": p.offset,
+	}
+	return query, queryArgs
+}
+
+func PhotoByStatusIsEqual(
+	status PhotoStatus,
+	limit,
+	offset int,
+) func(s *PhotoSpecificationByStatus) bool {
+	return func(s *PhotoSpecificationByStatus) bool {
+		return s.status == status && s.limit == limit && s.offset == offset
+	}
+}
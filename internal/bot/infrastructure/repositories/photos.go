@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type photoStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewPhotoRepo(dbPool *pgxpool.Pool) PhotoRepository {
+	return &photoStorage{dbPool}
+}
+
+func (s *photoStorage) Add(
+	ctx context.Context,
+	photo BuildingPhoto,
+) (*BuildingPhoto, error) {
+	insertQuery := `INSERT INTO building_photos
+	(building_id, file_id, file_unique_id, attribution, uploader_id, chat_id, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at;`
+	if photo.Status == "" {
+		photo.Status = PhotoPending
+	}
+	err := s.dbPool.QueryRow(
+		ctx,
+		insertQuery,
+		photo.BuildingID,
+		photo.FileID,
+		photo.FileUniqueID,
+		photo.Attribution,
+		photo.UploaderID,
+		photo.ChatID,
+		string(photo.Status),
+	).Scan(&photo.ID, &photo.CreatedAt)
+	if err != nil {
+		itemName := fmt.Sprintf(
+			"photo '%v' of the building %v",
+			photo.FileUniqueID,
+			photo.BuildingID,
+		)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	return &photo, nil
+}
+
+func (s *photoStorage) Remove(ctx context.Context, photo BuildingPhoto) error {
+	query := `UPDATE building_photos SET deleted_at = $1
+	WHERE id = $2 AND deleted_at IS NULL;`
+	_, err := s.dbPool.Exec(ctx, query, time.Now(), photo.ID)
+	if err != nil {
+		itemName := fmt.Sprintf("photo %v", photo.ID)
+		return processPostgresError(ctx, itemName, err)
+	}
+	return nil
+}
+
+// Update changes a pending photo only, a moderated one is ErrNotExist.
+func (s *photoStorage) Update(
+	ctx context.Context,
+	photo BuildingPhoto,
+) (*BuildingPhoto, error) {
+	query := `UPDATE building_photos SET attribution = $1, status = $2,
+	updated_at = $3 WHERE id = $4 AND status = $5 AND deleted_at IS NULL
+	RETURNING updated_at;`
+	err := s.dbPool.QueryRow(
+		ctx,
+		query,
+		photo.Attribution,
+		string(photo.Status),
+		time.Now(),
+		photo.ID,
+		string(PhotoPending),
+	).Scan(&photo.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		itemName := fmt.Sprintf("photo %v", photo.ID)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	return &photo, nil
+}
+
+func (s *photoStorage) Query(
+	ctx context.Context,
+	spec Specification,
+) ([]BuildingPhoto, error) {
+	query, queryArgs := spec.ToSQL()
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
+	rows, err := s.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
+	if err != nil {
+		logMsg := fmt.Sprintf("a query error: '%v'", query)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	defer rows.Close()
+	var photos []BuildingPhoto
+	for rows.Next() {
+		var photo BuildingPhoto
+		if err := rows.Scan(
+			&photo.ID,
+			&photo.BuildingID,
+			&photo.FileID,
+			&photo.FileUniqueID,
+			&photo.Attribution,
+			&photo.UploaderID,
+			&photo.ChatID,
+			&photo.Status,
+			&photo.CreatedAt,
+			&photo.UpdatedAt,
+			&photo.deletedAt,
+		); err != nil {
+			msg := fmt.Sprintf("can not scan a photo from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
+			)
+			return nil, err
+		}
+		photos = append(photos, photo)
+	}
+	return photos, nil
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type popularityStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewPopularityRepo(dbPool *pgxpool.Pool) PopularityRepository {
+	return &popularityStorage{dbPool}
+}
+
+func (s *popularityStorage) Add(
+	ctx context.Context,
+	event PopularityEvent,
+) (*PopularityEvent, error) {
+	insertQuery := `INSERT INTO popularity_events
+	(event_type, user_hash, building_id, search_type, search_query)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at;`
+	err := s.dbPool.QueryRow(
+		ctx,
+		insertQuery,
+		event.EventType,
+		event.UserHash,
+		event.BuildingID,
+		event.SearchType,
+		event.SearchQuery,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		itemName := fmt.Sprintf("popularity event '%v'", event.EventType)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	return &event, nil
+}
+
+func (s *popularityStorage) RemoveBefore(ctx context.Context, before time.Time) (int64, error) {
+	deleteQuery := `DELETE FROM popularity_events WHERE created_at < $1;`
+	tag, err := s.dbPool.Exec(ctx, deleteQuery, before)
+	if err != nil {
+		logMsg := fmt.Sprintf("can not remove popularity events before %v", before)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type transactionKey struct{}
+
+type transaction struct {
+	pgx.Tx
+	// onCommit runs after a successful commit
+	onCommit []func()
+}
+
+// executor is a pool or a transaction which a repository sends queries to.
+type executor interface {
+	Begin(context.Context) (pgx.Tx, error)
+	Exec(context.Context, string, ...any) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...any) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...any) pgx.Row
+}
+
+// getExecutor returns a transaction of a context if there is one,
+// so that repositories which share a pool join it.
+func getExecutor(ctx context.Context, dbPool *pgxpool.Pool) executor {
+	if tx, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		return tx
+	}
+	return dbPool
+}
+
+// inTransaction reports whether a context has a transaction.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(transactionKey{}).(*transaction)
+	return ok
+}
+
+// afterCommit runs a function after a transaction of a context is committed
+// or at once outside a transaction.
+func afterCommit(ctx context.Context, f func()) {
+	if tx, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		tx.onCommit = append(tx.onCommit, f)
+		return
+	}
+	f()
+}
+
+type TransactionStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTransactionRepo(dbPool *pgxpool.Pool) *TransactionStorage {
+	return &TransactionStorage{dbPool}
+}
+
+// Run calls a function in a transaction and commits it if the function
+// succeeds. Repositories use the transaction if they get the context
+// of the function.
+func (s *TransactionStorage) Run(ctx context.Context, f func(context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*transaction); ok {
+		return f(ctx)
+	}
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "can not begin a transaction", slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "can not roll back a transaction", slog.Any(logger.ErrorKey, err))
+		}
+	}()
+	current := &transaction{Tx: tx}
+	if err := f(context.WithValue(ctx, transactionKey{}, current)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		slog.ErrorContext(ctx, "can not commit a transaction", slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	for _, f := range current.onCommit {
+		f()
+	}
+	return nil
+}
@@ -88,6 +88,8 @@ type Building struct {
 	AuthorIDs             []int64
 	InitialUses           []UseType
 	CurrentUses           []UseType
+	// DistanceMeters is set only by specifications that select a distance
+	DistanceMeters *float64
 	Timestamps
 }
 
@@ -97,3 +99,74 @@ type User struct {
 	PreferredLanguage string
 	Timestamps
 }
+
+type Chat struct {
+	ID                int64
+	TelegramID        int64
+	PreferredLanguage string
+	Timestamps
+}
+
+type CorrectionStatus string
+
+const (
+	CorrectionPending  = CorrectionStatus("pending")
+	CorrectionAccepted = CorrectionStatus("accepted")
+	CorrectionRejected = CorrectionStatus("rejected")
+)
+
+type Correction struct {
+	ID         int64
+	BuildingID int64
+	Field      string
+	Language   string
+	Value      string
+	ReporterID int64
+	ChatID     int64
+	Status     CorrectionStatus
+	Timestamps
+}
+
+type PhotoStatus string
+
+const (
+	PhotoPending  = PhotoStatus("pending")
+	PhotoAccepted = PhotoStatus("accepted")
+	PhotoRejected = PhotoStatus("rejected")
+)
+
+type BuildingPhoto struct {
+	ID           int64
+	BuildingID   int64
+	FileID       string
+	FileUniqueID string
+	Attribution  string
+	UploaderID   int64
+	ChatID       int64
+	Status       PhotoStatus
+	Timestamps
+}
+
+type ProcessedUpdate struct {
+	UpdateID  int64
+	CreatedAt time.Time
+}
+
+type PopularityEventType string
+
+const (
+	ViewEvent   PopularityEventType = "view"
+	SearchEvent PopularityEventType = "search"
+)
+
+// PopularityEvent is a building view or a search query. It contains
+// a hash of a user ID instead of the ID.
+type PopularityEvent struct {
+	ID          int64
+	EventType   PopularityEventType
+	UserHash    string
+	BuildingID  *int64
+	SearchType  *string
+	SearchQuery *string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type updateStorage struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewUpdateRepo(dbPool *pgxpool.Pool) UpdateRepository {
+	return &updateStorage{dbPool}
+}
+
+// Add records an update in the ledger of processed updates.
+// It returns ErrDuplicate if the update has already been recorded.
+func (s *updateStorage) Add(
+	ctx context.Context,
+	update ProcessedUpdate,
+) (*ProcessedUpdate, error) {
+	insertQuery := `INSERT INTO processed_updates (update_id)
+	VALUES ($1) RETURNING created_at;`
+	err := s.dbPool.QueryRow(ctx, insertQuery, update.UpdateID).
+		Scan(&update.CreatedAt)
+	if err != nil {
+		itemName := fmt.Sprintf("update %v", update.UpdateID)
+		return nil, processPostgresError(ctx, itemName, err)
+	}
+	return &update, nil
+}
+
+// Exists returns true if an update is recorded as processed.
+func (s *updateStorage) Exists(ctx context.Context, updateID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM processed_updates WHERE update_id = $1);`
+	var exists bool
+	if err := s.dbPool.QueryRow(ctx, query, updateID).Scan(&exists); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not check the update %v", updateID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetOffset returns the saved update offset or zero if there is no offset.
+func (s *updateStorage) GetOffset(ctx context.Context) (int64, error) {
+	query := `SELECT update_offset FROM update_offsets WHERE id = 1;`
+	var offset int64
+	err := s.dbPool.QueryRow(ctx, query).Scan(&offset)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "can not get an update offset", slog.Any(logger.ErrorKey, err))
+		return 0, err
+	}
+	return offset, nil
+}
+
+// SetOffset saves an offset if it is greater than the saved one and
+// removes ledger records that Telegram will not send again.
+func (s *updateStorage) SetOffset(ctx context.Context, offset int64) error {
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		logMsg := "can not begin a transaction"
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	defer func() {
+		err := tx.Rollback(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			logMsg := "can not rollback a transaction"
+			slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		}
+	}()
+	upsertQuery := `INSERT INTO update_offsets (id, update_offset)
+	VALUES (1, $1) ON CONFLICT (id) DO UPDATE
+	SET update_offset = GREATEST(update_offsets.update_offset, EXCLUDED.update_offset),
+	updated_at = now();`
+	if _, err := tx.Exec(ctx, upsertQuery, offset); err != nil {
+		logMsg := fmt.Sprintf("can not save an update offset %v", offset)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	deleteQuery := `DELETE FROM processed_updates WHERE update_id < $1;`
+	if _, err := tx.Exec(ctx, deleteQuery, offset); err != nil {
+		logMsg := fmt.Sprintf("can not remove updates before the offset %v", offset)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		logMsg := fmt.Sprintf("can not commit the update offset %v", offset)
+		slog.ErrorContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return err
+	}
+	return nil
+}
@@ -55,7 +55,11 @@ func (s *userStorage) Update(ctx context.Context, user User) (*User, error) {
 
 func (s *userStorage) Query(ctx context.Context, spec Specification) ([]User, error) {
 	query, queryArgs := spec.ToSQL()
-	slog.DebugContext(ctx, fmt.Sprintf("send the query %v: %v", query, queryArgs))
+	slog.DebugContext(
+		ctx,
+		fmt.Sprintf("send the query %v", query),
+		logger.Map("args", queryArgs),
+	)
 	rows, err := s.dbPool.Query(ctx, query, pgx.NamedArgs(queryArgs))
 	if err != nil {
 		logMsg := fmt.Sprintf("a query error: '%v'", query)
@@ -74,15 +78,41 @@ func (s *userStorage) Query(ctx context.Context, spec Specification) ([]User, er
 			&user.UpdatedAt,
 			&user.deletedAt,
 		); err != nil {
-			msg := fmt.Sprintf(
-				"can not scan an actor from a query result: %v: %v",
-				query,
-				queryArgs,
+			msg := fmt.Sprintf("can not scan a user from a query result: %v", query)
+			slog.ErrorContext(
+				ctx,
+				msg,
+				logger.Map("args", queryArgs),
+				slog.Any(logger.ErrorKey, err),
 			)
-			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
 			return nil, err
 		}
 		users = append(users, user)
 	}
 	return users, nil
 }
+
+// CountPerLanguage returns a number of users per a preferred language code.
+func (s *userStorage) CountPerLanguage(ctx context.Context) (map[string]int, error) {
+	query := `SELECT language::text, count(*) FROM users
+	WHERE language IS NOT NULL AND deleted_at IS NULL GROUP BY language;`
+	rows, err := s.dbPool.Query(ctx, query)
+	if err != nil {
+		logMsg := fmt.Sprintf("a query error: '%v'", query)
+		slog.WarnContext(ctx, logMsg, slog.Any(logger.ErrorKey, err))
+		return nil, fmt.Errorf("%v: %w", logMsg, err)
+	}
+	defer rows.Close()
+	counts := map[string]int{}
+	for rows.Next() {
+		var language string
+		var count int
+		if err := rows.Scan(&language, &count); err != nil {
+			msg := fmt.Sprintf("can not scan a user count from a query result: %v", query)
+			slog.ErrorContext(ctx, msg, slog.Any(logger.ErrorKey, err))
+			return nil, err
+		}
+		counts[language] = count
+	}
+	return counts, rows.Err()
+}
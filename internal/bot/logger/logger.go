@@ -1,3 +1,116 @@
 package logger
 
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+)
+
 var ErrorKey = "error"
+
+const (
+	UpdateIDKey = "update_id"
+	ChatIDKey   = "chat_id"
+	UserIDKey   = "user_id"
+	CommandKey  = "command"
+	redacted    = "[redacted]"
+)
+
+// coordinateKeys are rounded to about a kilometre.
+var coordinateKeys = []string{
+	"latitude",
+	"longitude",
+	"lat",
+	"lon",
+	"min_latitude",
+	"max_latitude",
+	"min_longitude",
+	"max_longitude",
+}
+
+// textKeys contain what users type.
+var textKeys = []string{"text", "search_pattern"}
+
+type contextKey struct{}
+
+// WithAttrs returns a context which adds attributes to every log record
+// produced under this context.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	combined := append(slices.Clip(existing), attrs...)
+	return context.WithValue(ctx, contextKey{}, combined)
+}
+
+// ContextHandler adds attributes of a context to log records.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) ContextHandler {
+	return ContextHandler{handler}
+}
+
+func (h ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(contextKey{}).([]slog.Attr); ok {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ContextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h ContextHandler) WithGroup(name string) slog.Handler {
+	return ContextHandler{h.Handler.WithGroup(name)}
+}
+
+// Redact hides message texts and rounds coordinates so that logs do not
+// reveal where users are. It suits slog.HandlerOptions.ReplaceAttr.
+func Redact(_ []string, attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	switch {
+	case slices.Contains(coordinateKeys, attr.Key) && value.Kind() != slog.KindGroup:
+		return roundCoordinate(attr.Key, value)
+	case slices.Contains(textKeys, attr.Key) && value.Kind() != slog.KindGroup:
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
+
+// roundCoordinate keeps a type of a coordinate. A coordinate which is neither
+// a number nor a number in a string is hidden.
+func roundCoordinate(key string, value slog.Value) slog.Attr {
+	round := func(coordinate float64) float64 {
+		return math.Round(coordinate*100) / 100
+	}
+	switch value.Kind() {
+	case slog.KindFloat64:
+		return slog.Float64(key, round(value.Float64()))
+	case slog.KindInt64, slog.KindUint64:
+		return slog.Attr{Key: key, Value: value}
+	case slog.KindString:
+		coordinate, err := strconv.ParseFloat(value.String(), 64)
+		if err == nil {
+			return slog.String(key, strconv.FormatFloat(round(coordinate), 'f', -1, 64))
+		}
+	}
+	return slog.String(key, redacted)
+}
+
+// Map turns a map into a group so that Redact can check every value.
+func Map(key string, values map[string]any) slog.Attr {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	attrs := make([]any, 0, len(values))
+	for _, name := range names {
+		attrs = append(attrs, slog.Any(name, values[name]))
+	}
+	return slog.Group(key, attrs...)
+}
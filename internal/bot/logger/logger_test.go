@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getTestLogger(buffer *bytes.Buffer, redact bool) *slog.Logger {
+	options := slog.HandlerOptions{}
+	if redact {
+		options.ReplaceAttr = Redact
+	}
+	return slog.New(NewContextHandler(slog.NewJSONHandler(buffer, &options)))
+}
+
+func getRecord(t *testing.T, buffer *bytes.Buffer) map[string]any {
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	delete(record, slog.TimeKey)
+	return record
+}
+
+func TestContextHandler(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := getTestLogger(buffer, false)
+	ctx := WithAttrs(
+		context.Background(),
+		slog.Int(UpdateIDKey, 10),
+		slog.Int64(ChatIDKey, 99),
+	)
+	ctx = WithAttrs(ctx, slog.String(CommandKey, "start"))
+	logger.InfoContext(ctx, "test message", slog.String("key", "value"))
+	expected := map[string]any{
+		slog.LevelKey:   "INFO",
+		slog.MessageKey: "test message",
+		"key":           "value",
+		UpdateIDKey:     float64(10),
+		ChatIDKey:       float64(99),
+		CommandKey:      "start",
+	}
+	require.Equal(t, expected, getRecord(t, buffer))
+}
+
+func TestContextHandler_siblingContexts(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := getTestLogger(buffer, false)
+	parent := WithAttrs(context.Background(), slog.Int(UpdateIDKey, 10))
+	first := WithAttrs(parent, slog.String(CommandKey, "first"))
+	WithAttrs(parent, slog.String(CommandKey, "second"))
+	logger.InfoContext(first, "test message")
+	require.Equal(t, "first", getRecord(t, buffer)[CommandKey])
+}
+
+func TestContextHandler_noAttrs(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := getTestLogger(buffer, false).With(slog.String("key", "value"))
+	logger.WarnContext(context.Background(), "test message")
+	expected := map[string]any{
+		slog.LevelKey:   "WARN",
+		slog.MessageKey: "test message",
+		"key":           "value",
+	}
+	require.Equal(t, expected, getRecord(t, buffer))
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		redact   bool
+		attr     slog.Attr
+		expected map[string]any
+	}{
+		{
+			"a latitude",
+			true,
+			slog.Float64("latitude", 60.123456),
+			map[string]any{"latitude": 60.12},
+		},
+		{
+			"a text",
+			true,
+			slog.String("text", "Mannerheimintie 1"),
+			map[string]any{"text": redacted},
+		},
+		{
+			"query arguments",
+			true,
+			Map("args", map[string]any{
+				"lat":            60.123456,
+				"lon":            24.987654,
+				"search_pattern": "Manner%",
+				"limit":          10,
+			}),
+			map[string]any{"args": map[string]any{
+				"lat":            60.12,
+				"lon":            24.99,
+				"search_pattern": redacted,
+				"limit":          float64(10),
+			}},
+		},
+		{
+			"specification arguments",
+			true,
+			Map("args", map[string]any{
+				"latitude":      "60.12346",
+				"longitude":     "not a number",
+				"min_latitude":  60.123456,
+				"max_longitude": 24.987654,
+				"lon":           []float64{24.987654},
+			}),
+			map[string]any{"args": map[string]any{
+				"latitude":      "60.12",
+				"longitude":     redacted,
+				"min_latitude":  60.12,
+				"max_longitude": 24.99,
+				"lon":           redacted,
+			}},
+		},
+		{
+			"an unrelated attribute",
+			true,
+			slog.Float64("distance", 123.456),
+			map[string]any{"distance": 123.456},
+		},
+		{
+			"no redaction",
+			false,
+			slog.Float64("longitude", 24.987654),
+			map[string]any{"longitude": 24.987654},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			logger := getTestLogger(buffer, tt.redact)
+			logger.InfoContext(context.Background(), "test message", tt.attr)
+			record := getRecord(t, buffer)
+			delete(record, slog.LevelKey)
+			delete(record, slog.MessageKey)
+			require.Equal(t, tt.expected, record)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// activeUsersSize limits the number of remembered users between scrapes
+const activeUsersSize = 100000
+
+var activeUserWindows = []struct {
+	label    string
+	duration time.Duration
+}{
+	{"1h", time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// ActiveUsers counts distinct users who sent updates within sliding windows.
+// It keeps only user IDs and the time of their last update.
+type ActiveUsers struct {
+	mu          sync.Mutex
+	lastSeen    map[int64]time.Time
+	now         func() time.Time
+	description *prometheus.Desc
+}
+
+func NewActiveUsers(now func() time.Time) *ActiveUsers {
+	return &ActiveUsers{
+		lastSeen: map[int64]time.Time{},
+		now:      now,
+		description: prometheus.NewDesc(
+			"helsinki_guide_active_users",
+			"number of users who sent updates within a window",
+			[]string{"window"},
+			nil,
+		),
+	}
+}
+
+func (a *ActiveUsers) Observe(userID int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.lastSeen) >= activeUsersSize {
+		a.removeInactive()
+	}
+	a.lastSeen[userID] = a.now()
+}
+
+func (a *ActiveUsers) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- a.description
+}
+
+func (a *ActiveUsers) Collect(metrics chan<- prometheus.Metric) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.removeInactive()
+	now := a.now()
+	for _, window := range activeUserWindows {
+		count := 0
+		for _, lastSeen := range a.lastSeen {
+			if now.Sub(lastSeen) <= window.duration {
+				count++
+			}
+		}
+		metrics <- prometheus.MustNewConstMetric(
+			a.description,
+			prometheus.GaugeValue,
+			float64(count),
+			window.label,
+		)
+	}
+}
+
+// removeInactive forgets users who are out of the longest window
+func (a *ActiveUsers) removeInactive() {
+	maxWindow := activeUserWindows[len(activeUserWindows)-1].duration
+	now := a.now()
+	for userID, lastSeen := range a.lastSeen {
+		if now.Sub(lastSeen) > maxWindow {
+			delete(a.lastSeen, userID)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveUsers(t *testing.T) {
+	now := time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)
+	activeUsers := NewActiveUsers(func() time.Time { return now })
+	activeUsers.Observe(1)
+	now = now.Add(2 * time.Hour)
+	activeUsers.Observe(2)
+	activeUsers.Observe(2)
+	now = now.Add(30 * time.Minute)
+	activeUsers.Observe(3)
+
+	expected := `
+# HELP helsinki_guide_active_users number of users who sent updates within a window
+# TYPE helsinki_guide_active_users gauge
+helsinki_guide_active_users{window="1d"} 3
+helsinki_guide_active_users{window="1h"} 2
+`
+	err := testutil.CollectAndCompare(activeUsers, strings.NewReader(expected))
+	require.NoError(t, err)
+
+	now = now.Add(23*time.Hour + 45*time.Minute)
+	expected = `
+# HELP helsinki_guide_active_users number of users who sent updates within a window
+# TYPE helsinki_guide_active_users gauge
+helsinki_guide_active_users{window="1d"} 1
+helsinki_guide_active_users{window="1h"} 0
+`
+	err = testutil.CollectAndCompare(activeUsers, strings.NewReader(expected))
+	require.NoError(t, err)
+	require.Len(t, activeUsers.lastSeen, 1)
+}
@@ -1,6 +1,17 @@
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	AddressSearch       = "address"
+	NearestSearch       = "nearest"
+	AuthorSearch        = "author"
+	NeighbourhoodSearch = "neighbourhood"
+)
 
 type Metrics struct {
 	ChatUpdates       prometheus.Counter
@@ -9,6 +20,15 @@ type Metrics struct {
 	ButtonDuration    *prometheus.HistogramVec
 	RequestDuration   *prometheus.HistogramVec
 	HandlerErrors     *prometheus.CounterVec
+	EmptySearches     *prometheus.CounterVec
+	BuildingViews     prometheus.Counter
+	Languages         *prometheus.GaugeVec
+	SettingsChanges   *prometheus.CounterVec
+	ActiveUsers       *ActiveUsers
+	CacheHits         *prometheus.CounterVec
+	CacheMisses       *prometheus.CounterVec
+	CacheSize         *prometheus.GaugeVec
+	APIRequests       *prometheus.CounterVec
 }
 
 func NewMetrics(registerer prometheus.Registerer) *Metrics {
@@ -46,6 +66,47 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 			Name:      "handler_errors",
 			Help:      "number of handler errors",
 		}, []string{"handler_name"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "empty_searches",
+			Help:      "number of searches without results",
+		}, []string{"search_type"}),
+		prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "building_views",
+			Help:      "number of sent building cards",
+		}),
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "helsinki_guide",
+			Name:      "preferred_languages",
+			Help:      "number of users per preferred language",
+		}, []string{"language"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "settings_changes",
+			Help:      "number of settings changes",
+		}, []string{"setting", "value"}),
+		NewActiveUsers(time.Now),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "cache_hits",
+			Help:      "number of cache hits",
+		}, []string{"cache"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "cache_misses",
+			Help:      "number of cache misses",
+		}, []string{"cache"}),
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "helsinki_guide",
+			Name:      "cache_size",
+			Help:      "number of cached items",
+		}, []string{"cache"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "helsinki_guide",
+			Name:      "api_requests",
+			Help:      "number of public API requests",
+		}, []string{"endpoint", "code"}),
 	}
 	registerer.MustRegister(
 		metrics.ChatUpdates,
@@ -54,6 +115,15 @@ func NewMetrics(registerer prometheus.Registerer) *Metrics {
 		metrics.ButtonDuration,
 		metrics.RequestDuration,
 		metrics.HandlerErrors,
+		metrics.EmptySearches,
+		metrics.BuildingViews,
+		metrics.Languages,
+		metrics.SettingsChanges,
+		metrics.ActiveUsers,
+		metrics.CacheHits,
+		metrics.CacheMisses,
+		metrics.CacheSize,
+		metrics.APIRequests,
 	)
 	return &metrics
 }
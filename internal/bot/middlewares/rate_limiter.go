@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiterSize bounds the memory of a rate limiter
+const rateLimiterSize = 10000
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// RateLimiter is a token bucket per key. A limit is a size of a bucket,
+// it is passed on every request because it can change at runtime.
+type RateLimiter[K comparable] struct {
+	mu      sync.Mutex
+	period  time.Duration
+	buckets map[K]*tokenBucket
+	now     func() time.Time
+}
+
+func NewRateLimiter[K comparable](period time.Duration, now func() time.Time) *RateLimiter[K] {
+	return &RateLimiter[K]{
+		period:  period,
+		buckets: map[K]*tokenBucket{},
+		now:     now,
+	}
+}
+
+func (l *RateLimiter[K]) Allow(key K, limit int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if len(l.buckets) >= rateLimiterSize {
+		l.removeFullBuckets(now)
+	}
+	size := float64(limit)
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{size, now}
+		l.buckets[key] = bucket
+	}
+	elapsed := now.Sub(bucket.updatedAt)
+	bucket.tokens = min(size, bucket.tokens+size*elapsed.Seconds()/l.period.Seconds())
+	bucket.updatedAt = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// removeFullBuckets forgets keys which have not sent requests for a period.
+func (l *RateLimiter[K]) removeFullBuckets(now time.Time) {
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.updatedAt) >= l.period {
+			delete(l.buckets, key)
+		}
+	}
+}
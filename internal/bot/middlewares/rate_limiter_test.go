@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter[int64](time.Minute, func() time.Time { return now })
+	require.True(t, limiter.Allow(5, 2))
+	require.True(t, limiter.Allow(5, 2))
+	require.False(t, limiter.Allow(5, 2))
+	now = now.Add(30 * time.Second)
+	require.True(t, limiter.Allow(5, 2))
+	require.False(t, limiter.Allow(5, 2))
+	now = now.Add(time.Hour)
+	require.True(t, limiter.Allow(5, 2))
+	require.True(t, limiter.Allow(5, 2))
+	require.False(t, limiter.Allow(5, 2))
+}
+
+func TestRateLimiter_removeFullBuckets(t *testing.T) {
+	now := time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter[int64](time.Minute, func() time.Time { return now })
+	for userID := int64(0); userID < rateLimiterSize; userID++ {
+		limiter.Allow(userID, 2)
+	}
+	now = now.Add(time.Minute)
+	require.True(t, limiter.Allow(rateLimiterSize, 2))
+	require.Len(t, limiter.buckets, 1)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
 	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
 )
 
 type BuildingService struct {
@@ -58,6 +60,9 @@ func NewBuildingDTO(b r.Building, authors []r.Actor) BuildingDTO {
 		SurroundingsFi:    b.SurroundingsFi,
 		SurroundingsEn:    b.SurroundingsEn,
 		SurroundingsRu:    b.SurroundingsRu,
+		Latitude:          b.Latitude_WGS84,
+		Longitude:         b.Longitude_WGS84,
+		DistanceMeters:    b.DistanceMeters,
 	}
 }
 
@@ -67,6 +72,8 @@ func (bs BuildingService) GetBuildings(
 	limit,
 	offset int,
 ) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildings")
+	defer span.End()
 	addressPrefix = strings.TrimLeft(addressPrefix, " ")
 	spec := r.NewBuildingSpecificationByAlikeAddress(addressPrefix, limit, offset)
 	buildings, err := bs.buildingCollection.Query(ctx, spec)
@@ -86,24 +93,36 @@ func (bs BuildingService) GetBuildings(
 	return previews, nil
 }
 
+func (bs BuildingService) CountBuildings(
+	ctx context.Context,
+	addressPrefix string,
+) (int, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.CountBuildings")
+	defer span.End()
+	addressPrefix = strings.TrimLeft(addressPrefix, " ")
+	spec := r.NewBuildingCountSpecificationByAlikeAddress(addressPrefix)
+	return bs.count(ctx, spec)
+}
+
 func (bs BuildingService) GetBuildingsByAddress(
 	ctx context.Context,
 	address string,
 ) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingsByAddress")
+	defer span.End()
 	address = strings.TrimSpace(address)
 	spec := r.NewBuildingSpecificationByAddress(address)
 	buildings, err := bs.buildingCollection.Query(ctx, spec)
 	if err != nil {
 		return nil, err
 	}
+	authorPerID, err := bs.getAuthors(ctx, buildings)
+	if err != nil {
+		return nil, err
+	}
 	buildingsDto := make([]BuildingDTO, len(buildings))
 	for i, building := range buildings {
-		spec := r.NewAuthorSpecificationByBuilding(building.ID)
-		authors, err := bs.actorCollection.Query(ctx, spec)
-		if err != nil {
-			return nil, err
-		}
-		buildingsDto[i] = NewBuildingDTO(building, authors)
+		buildingsDto[i] = NewBuildingDTO(building, getBuildingAuthors(building, authorPerID))
 	}
 
 	return buildingsDto, nil
@@ -113,6 +132,8 @@ func (bs BuildingService) GetBuildingByID(
 	ctx context.Context,
 	buildingID int64,
 ) (*BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingByID")
+	defer span.End()
 	spec := r.NewBuildingSpecificationByID(buildingID)
 	buildings, err := bs.buildingCollection.Query(ctx, spec)
 	if err != nil {
@@ -130,6 +151,79 @@ func (bs BuildingService) GetBuildingByID(
 	return &buildingDTO, nil
 }
 
+// GetBuildingsByIDs returns buildings with authors in the order of IDs
+// and skips unknown IDs.
+func (bs BuildingService) GetBuildingsByIDs(
+	ctx context.Context,
+	buildingIDs []int64,
+) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingsByIDs")
+	defer span.End()
+	spec := r.NewBuildingSpecificationByIDs(buildingIDs)
+	buildings, err := bs.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not get buildings %v", buildingIDs),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	authorPerID, err := bs.getAuthors(ctx, buildings)
+	if err != nil {
+		return nil, err
+	}
+	buildingPerID := make(map[int64]r.Building, len(buildings))
+	for _, building := range buildings {
+		buildingPerID[building.ID] = building
+	}
+	var buildingsDto []BuildingDTO
+	for _, buildingID := range buildingIDs {
+		building, ok := buildingPerID[buildingID]
+		if !ok {
+			continue
+		}
+		authors := getBuildingAuthors(building, authorPerID)
+		buildingsDto = append(buildingsDto, NewBuildingDTO(building, authors))
+	}
+	return buildingsDto, nil
+}
+
+// getAuthors returns authors of all buildings per author ID with one query.
+func (bs BuildingService) getAuthors(
+	ctx context.Context,
+	buildings []r.Building,
+) (map[int64]r.Actor, error) {
+	var authorIDs []int64
+	for _, building := range buildings {
+		authorIDs = append(authorIDs, building.AuthorIDs...)
+	}
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+	slices.Sort(authorIDs)
+	authorIDs = slices.Compact(authorIDs)
+	authors, err := bs.actorCollection.Query(ctx, r.NewActorSpecificationByIDs(authorIDs))
+	if err != nil {
+		return nil, err
+	}
+	authorPerID := make(map[int64]r.Actor, len(authors))
+	for _, author := range authors {
+		authorPerID[author.ID] = author
+	}
+	return authorPerID, nil
+}
+
+func getBuildingAuthors(building r.Building, authorPerID map[int64]r.Actor) []r.Actor {
+	var authors []r.Actor
+	for _, authorID := range building.AuthorIDs {
+		if author, ok := authorPerID[authorID]; ok {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
 func (bs BuildingService) GetNearestBuildings(
 	ctx context.Context,
 	distanceMeters int,
@@ -138,6 +232,8 @@ func (bs BuildingService) GetNearestBuildings(
 	limit,
 	offset int,
 ) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetNearestBuildings")
+	defer span.End()
 	spec := r.NewBuildingSpecificationNearest(
 		distanceMeters,
 		latitude,
@@ -165,3 +261,116 @@ func (bs BuildingService) GetNearestBuildings(
 	}
 	return previews, nil
 }
+
+func (bs BuildingService) CountNearestBuildings(
+	ctx context.Context,
+	distanceMeters int,
+	latitude,
+	longitude float64,
+) (int, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.CountNearestBuildings")
+	defer span.End()
+	spec := r.NewBuildingCountSpecificationNearest(distanceMeters, latitude, longitude)
+	return bs.count(ctx, spec)
+}
+
+func (bs BuildingService) GetBuildingsByNeighbourhood(
+	ctx context.Context,
+	neighbourhoodID int64,
+	limit,
+	offset int,
+) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingsByNeighbourhood")
+	defer span.End()
+	spec := r.NewBuildingSpecificationByNeighbourhood(neighbourhoodID, limit, offset)
+	return bs.getPreviews(ctx, spec)
+}
+
+func (bs BuildingService) GetBuildingsByAuthor(
+	ctx context.Context,
+	actorID int64,
+	limit,
+	offset int,
+) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingsByAuthor")
+	defer span.End()
+	spec := r.NewBuildingSpecificationByAuthor(actorID, limit, offset)
+	return bs.getPreviews(ctx, spec)
+}
+
+// GetBuildingsInBBox returns buildings with authors inside a box
+// between south-west and north-east corners. A zero limit returns
+// all buildings of a box.
+func (bs BuildingService) GetBuildingsInBBox(
+	ctx context.Context,
+	southWest,
+	northEast Coordinates,
+	limit int,
+) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "BuildingService.GetBuildingsInBBox")
+	defer span.End()
+	spec := r.NewBuildingSpecificationByBBox(
+		southWest.Latitude,
+		southWest.Longitude,
+		northEast.Latitude,
+		northEast.Longitude,
+		limit,
+	)
+	buildings, err := bs.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not get buildings between %v and %v", southWest, northEast),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	authorPerID, err := bs.getAuthors(ctx, buildings)
+	if err != nil {
+		return nil, err
+	}
+	buildingsDto := make([]BuildingDTO, len(buildings))
+	for i, building := range buildings {
+		buildingsDto[i] = NewBuildingDTO(building, getBuildingAuthors(building, authorPerID))
+	}
+	return buildingsDto, nil
+}
+
+func (bs BuildingService) getPreviews(
+	ctx context.Context,
+	spec r.Specification,
+) ([]BuildingDTO, error) {
+	buildings, err := bs.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		query, args := spec.ToSQL()
+		slog.ErrorContext(
+			ctx,
+			"can not get buildings",
+			slog.String("query", query),
+			logger.Map("args", args),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	previews := make([]BuildingDTO, len(buildings))
+	for i, building := range buildings {
+		previews[i] = NewBuildingDTO(building, nil)
+	}
+	return previews, nil
+}
+
+func (bs BuildingService) count(ctx context.Context, spec r.Specification) (int, error) {
+	count, err := bs.buildingCollection.Count(ctx, spec)
+	if err != nil {
+		query, args := spec.ToSQL()
+		slog.ErrorContext(
+			ctx,
+			"can not count buildings",
+			slog.String("query", query),
+			logger.Map("args", args),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildingService_GetBuildingsByNeighbourhood(t *testing.T) {
+	ctx := context.Background()
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByNeighbourhoodIsEqual(3, 10, 0))).
+		Return(
+			[]r.Building{{
+				ID:      1,
+				NameEn:  utils.GetPointer("test building"),
+				Address: r.Address{StreetAddress: "test street 1"},
+			}},
+			nil,
+		)
+	s := NewBuildingService(buildingRepo, r.NewActorRepository_mock(t))
+	got, err := s.GetBuildingsByNeighbourhood(ctx, 3, 10, 0)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]BuildingDTO{{
+			ID:      1,
+			NameEn:  utils.GetPointer("test building"),
+			Address: "test street 1",
+		}},
+		got,
+	)
+}
+
+func TestBuildingService_GetBuildingsByAuthor(t *testing.T) {
+	ctx := context.Background()
+	repositoryError := errors.New("test error")
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByAuthorIsEqual(4, 10, 5))).
+		Return(nil, repositoryError)
+	s := NewBuildingService(buildingRepo, r.NewActorRepository_mock(t))
+	got, err := s.GetBuildingsByAuthor(ctx, 4, 10, 5)
+	require.ErrorIs(t, err, repositoryError)
+	require.Nil(t, got)
+}
+
+func TestBuildingService_GetBuildingsByIDs(t *testing.T) {
+	ctx := context.Background()
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByIDsIsEqual([]int64{2, 5, 1}))).
+		Return(
+			[]r.Building{
+				{
+					ID:              1,
+					Address:         r.Address{StreetAddress: "test street 1"},
+					Latitude_WGS84:  utils.GetPointer(60.1),
+					Longitude_WGS84: utils.GetPointer(24.9),
+				},
+				{
+					ID:        2,
+					Address:   r.Address{StreetAddress: "test street 2"},
+					AuthorIDs: []int64{7},
+				},
+			},
+			nil,
+		)
+	actorRepo := r.NewActorRepository_mock(t)
+	actorRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.ActorByIDsIsEqual([]int64{7}))).
+		Return([]r.Actor{{ID: 7, Name: "test author"}}, nil).
+		Once()
+	s := NewBuildingService(buildingRepo, actorRepo)
+	got, err := s.GetBuildingsByIDs(ctx, []int64{2, 5, 1})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]BuildingDTO{
+			{
+				ID:      2,
+				Address: "test street 2",
+				Authors: &[]string{"test author"},
+			},
+			{
+				ID:        1,
+				Address:   "test street 1",
+				Latitude:  utils.GetPointer(60.1),
+				Longitude: utils.GetPointer(24.9),
+			},
+		},
+		got,
+	)
+}
+
+func TestBuildingService_GetBuildingsInBBox(t *testing.T) {
+	southWest := Coordinates{60.15, 24.9}
+	northEast := Coordinates{60.2, 25}
+	isExpectedSpec := r.BuildingByBBoxIsEqual(60.15, 24.9, 60.2, 25, 100)
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name      string
+		buildings []r.Building
+		repoErr   error
+		expected  []BuildingDTO
+	}{
+		{
+			"buildings with authors",
+			[]r.Building{{
+				ID:              1,
+				Address:         r.Address{StreetAddress: "test street 1"},
+				AuthorIDs:       []int64{7},
+				Latitude_WGS84:  utils.GetPointer(60.17),
+				Longitude_WGS84: utils.GetPointer(24.95),
+			}},
+			nil,
+			[]BuildingDTO{{
+				ID:        1,
+				Address:   "test street 1",
+				Authors:   &[]string{"test author"},
+				Latitude:  utils.GetPointer(60.17),
+				Longitude: utils.GetPointer(24.95),
+			}},
+		},
+		{"a repository error", nil, repositoryError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildingRepo := r.NewBuildingRepository_mock(t)
+			buildingRepo.EXPECT().
+				Query(mock.Anything, mock.MatchedBy(isExpectedSpec)).
+				Return(tt.buildings, tt.repoErr)
+			actorRepo := r.NewActorRepository_mock(t)
+			if tt.repoErr == nil {
+				actorRepo.EXPECT().
+					Query(mock.Anything, mock.MatchedBy(r.ActorByIDsIsEqual([]int64{7}))).
+					Return([]r.Actor{{ID: 7, Name: "test author"}}, nil)
+			}
+			s := NewBuildingService(buildingRepo, actorRepo)
+			got, err := s.GetBuildingsInBBox(context.Background(), southWest, northEast, 100)
+			require.ErrorIs(t, err, tt.repoErr)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -38,7 +38,7 @@ func TestBuildingService_GetNearestPreviews(t *testing.T) {
 				repositories.NewBuildingRepository_mock(t),
 				repositories.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]repositories.Building{},
 			nil,
 			[]BuildingDTO{},
@@ -60,7 +60,7 @@ func TestBuildingService_GetNearestPreviews(t *testing.T) {
 				repositories.NewBuildingRepository_mock(t),
 				repositories.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]repositories.Building{},
 			errors.New("test error"),
 			[]BuildingDTO{},
@@ -90,18 +90,28 @@ func TestBuildingService_GetNearestPreviews(t *testing.T) {
 			args{context.Background(), 100, 0.0, 0.0, 5, 10},
 			[]repositories.Building{
 				{
-					NameFi:  utils.GetPointer("test name 1"),
-					Address: repositories.Address{StreetAddress: "test address 1"},
+					NameFi:         utils.GetPointer("test name 1"),
+					Address:        repositories.Address{StreetAddress: "test address 1"},
+					DistanceMeters: utils.GetPointer(12.5),
 				},
 				{
-					NameFi:  utils.GetPointer("test name 2"),
-					Address: repositories.Address{StreetAddress: "test address 2"},
+					NameFi:         utils.GetPointer("test name 2"),
+					Address:        repositories.Address{StreetAddress: "test address 2"},
+					DistanceMeters: utils.GetPointer(80.0),
 				},
 			},
 			nil,
 			[]BuildingDTO{
-				{Address: "test address 1", NameFi: utils.GetPointer("test name 1")},
-				{Address: "test address 2", NameFi: utils.GetPointer("test name 2")},
+				{
+					Address:        "test address 1",
+					NameFi:         utils.GetPointer("test name 1"),
+					DistanceMeters: utils.GetPointer(12.5),
+				},
+				{
+					Address:        "test address 2",
+					NameFi:         utils.GetPointer("test name 2"),
+					DistanceMeters: utils.GetPointer(80.0),
+				},
 			},
 		},
 	}
@@ -115,7 +125,7 @@ func TestBuildingService_GetNearestPreviews(t *testing.T) {
 				tt.args.offset,
 			)
 			tt.fields.buildingCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(matchSpecFunc),
 			).Return(tt.foundBuildings, tt.repositoryError)
 			bs := BuildingService{
@@ -140,3 +150,15 @@ func TestBuildingService_GetNearestPreviews(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildingService_CountNearestBuildings(t *testing.T) {
+	ctx := context.Background()
+	buildingRepo := repositories.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Count(mock.Anything, mock.MatchedBy(repositories.NearestCountSpecIsEqual(100, 60.17, 24.94))).
+		Return(23, nil)
+	s := NewBuildingService(buildingRepo, repositories.NewActorRepository_mock(t))
+	got, err := s.CountNearestBuildings(ctx, 100, 60.17, 24.94)
+	require.NoError(t, err)
+	require.Equal(t, 23, got)
+}
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing/tracingtest"
 	"github.com/AndreyAD1/helsinki-guide/internal/utils"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestBuildingService_GetBuildings(t *testing.T) {
@@ -36,7 +38,7 @@ func TestBuildingService_GetBuildings(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]r.Building{},
 			nil,
 			[]BuildingDTO{},
@@ -58,7 +60,7 @@ func TestBuildingService_GetBuildings(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]r.Building{},
 			errors.New("test error"),
 			[]BuildingDTO{},
@@ -113,7 +115,7 @@ func TestBuildingService_GetBuildings(t *testing.T) {
 				tt.args.offset,
 			)
 			tt.fields.buildingCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(matchSpec),
 			).Return(tt.foundBuildings, tt.repositoryError)
 			bs := BuildingService{
@@ -162,7 +164,7 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]r.Building{},
 			[]r.Actor{},
 			nil,
@@ -175,7 +177,7 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]r.Building{},
 			[]r.Actor{},
 			errors.New("building error"),
@@ -188,8 +190,8 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
-			[]r.Building{{ID: 1}},
+			args{ctx: context.Background()},
+			[]r.Building{{ID: 1, AuthorIDs: []int64{1}}},
 			[]r.Actor{},
 			nil,
 			errors.New("actor error"),
@@ -215,8 +217,10 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 				r.NewActorRepository_mock(t),
 			},
 			args{context.Background(), "test address"},
-			[]r.Building{{ID: 1, Address: r.Address{StreetAddress: "test address"}}},
-			[]r.Actor{{Name: "author 1"}},
+			[]r.Building{
+				{ID: 1, Address: r.Address{StreetAddress: "test address"}, AuthorIDs: []int64{1}},
+			},
+			[]r.Actor{{ID: 1, Name: "author 1"}},
 			nil,
 			nil,
 			[]BuildingDTO{{ID: 1, Address: "test address", Authors: &[]string{"author 1"}}},
@@ -228,8 +232,10 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 				r.NewActorRepository_mock(t),
 			},
 			args{context.Background(), "test address"},
-			[]r.Building{{ID: 1, Address: r.Address{StreetAddress: "test address"}}},
-			[]r.Actor{{Name: "author 1"}, {Name: "author 2"}},
+			[]r.Building{
+				{ID: 1, Address: r.Address{StreetAddress: "test address"}, AuthorIDs: []int64{1, 2}},
+			},
+			[]r.Actor{{ID: 1, Name: "author 1"}, {ID: 2, Name: "author 2"}},
 			nil,
 			nil,
 			[]BuildingDTO{
@@ -241,15 +247,15 @@ func TestBuildingService_GetBuildingsByAddress(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buildingSpecFunc := r.BuildingByAddressIsEqual(tt.args.address)
 			tt.fields.buildingCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(buildingSpecFunc),
 			).Return(tt.foundBuildings, tt.repositoryBuildingError)
 
-			if len(tt.foundBuildings) > 0 {
-				authorSpec := r.ActorByBuildingIsEqual(tt.foundBuildings[0].ID)
+			if len(tt.foundBuildings) > 0 && tt.foundBuildings[0].AuthorIDs != nil {
+				authorSpec := r.ActorByIDsIsEqual(tt.foundBuildings[0].AuthorIDs)
 				tt.fields.actorCollection.On(
 					"Query",
-					tt.args.ctx,
+					mock.Anything,
 					mock.MatchedBy(authorSpec),
 				).Return(tt.foundAuthors, tt.repositoryActorError)
 			}
@@ -290,27 +296,32 @@ func TestBuildingService_GetBuildingsByAddress_ManyBuildings(t *testing.T) {
 		want           []BuildingDTO
 	}{
 		{
-			"two buildings - same authors",
+			"two buildings - shared authors",
 			fields{
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
 			args{context.Background(), "test address"},
 			[]r.Building{
-				{ID: 1, NameFi: utils.GetPointer("test 1")},
-				{ID: 2, NameFi: utils.GetPointer("test 2")},
+				{ID: 1, NameFi: utils.GetPointer("test 1"), AuthorIDs: []int64{2, 1}},
+				{ID: 2, NameFi: utils.GetPointer("test 2"), AuthorIDs: []int64{2}},
+				{ID: 3, NameFi: utils.GetPointer("test 3")},
 			},
-			[]r.Actor{{Name: "author 1"}, {Name: "author 2"}},
+			[]r.Actor{{ID: 1, Name: "author 1"}, {ID: 2, Name: "author 2"}},
 			[]BuildingDTO{
 				{
 					ID:      1,
 					NameFi:  utils.GetPointer("test 1"),
-					Authors: &[]string{"author 1", "author 2"},
+					Authors: &[]string{"author 2", "author 1"},
 				},
 				{
 					ID:      2,
 					NameFi:  utils.GetPointer("test 2"),
-					Authors: &[]string{"author 1", "author 2"},
+					Authors: &[]string{"author 2"},
+				},
+				{
+					ID:     3,
+					NameFi: utils.GetPointer("test 3"),
 				},
 			},
 		},
@@ -318,18 +329,14 @@ func TestBuildingService_GetBuildingsByAddress_ManyBuildings(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.fields.buildingCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(r.BuildingByAddressIsEqual(tt.args.address)),
 			).Return(tt.foundBuildings, nil)
 
-			authorSpec0 := r.ActorByBuildingIsEqual(tt.foundBuildings[0].ID)
-			authorSpec1 := r.ActorByBuildingIsEqual(tt.foundBuildings[1].ID)
 			tt.fields.actorCollection.EXPECT().Query(
-				tt.args.ctx,
-				mock.MatchedBy(authorSpec0),
-			).Return(tt.foundAuthors, nil).
-				On("Query", tt.args.ctx, mock.MatchedBy(authorSpec1)).
-				Return(tt.foundAuthors, nil)
+				mock.Anything,
+				mock.MatchedBy(r.ActorByIDsIsEqual([]int64{1, 2})),
+			).Return(tt.foundAuthors, nil).Once()
 
 			bs := BuildingService{
 				buildingCollection: tt.fields.buildingCollection,
@@ -380,7 +387,7 @@ func TestBuildingService_GetBuildingByID(t *testing.T) {
 				r.NewBuildingRepository_mock(t),
 				r.NewActorRepository_mock(t),
 			},
-			args{},
+			args{ctx: context.Background()},
 			[]r.Building{},
 			[]r.Actor{},
 			errors.New("building error"),
@@ -418,7 +425,7 @@ func TestBuildingService_GetBuildingByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buildingSpecFunc := r.BuildingByIDIsEqual(tt.args.buildingID)
 			tt.fields.buildingCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(buildingSpecFunc),
 			).Return(tt.foundBuildings, tt.repositoryBuildingError)
 
@@ -426,7 +433,7 @@ func TestBuildingService_GetBuildingByID(t *testing.T) {
 				authorSpec := r.ActorByBuildingIsEqual(tt.foundBuildings[0].ID)
 				tt.fields.actorCollection.On(
 					"Query",
-					tt.args.ctx,
+					mock.Anything,
 					mock.MatchedBy(authorSpec),
 				).Return(tt.foundAuthors, tt.repositoryActorError)
 			}
@@ -448,3 +455,45 @@ func TestBuildingService_GetBuildingByID(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildingService_CountBuildings(t *testing.T) {
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name          string
+		addressPrefix string
+		count         int
+		err           error
+	}{
+		{"count", "  test", 12, nil},
+		{"repository error", "test", 0, repositoryError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			buildingRepo := r.NewBuildingRepository_mock(t)
+			buildingRepo.EXPECT().
+				Count(mock.Anything, mock.MatchedBy(r.AlikeAddressCountSpecIsEqual("test"))).
+				Return(tt.count, tt.err)
+			s := NewBuildingService(buildingRepo, r.NewActorRepository_mock(t))
+			got, err := s.CountBuildings(ctx, tt.addressPrefix)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.count, got)
+		})
+	}
+}
+
+func TestBuildingService_GetBuildings_span(t *testing.T) {
+	exporter := tracingtest.SetExporter(t)
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	var queryCtx context.Context
+	buildingRepo.EXPECT().Query(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ r.Specification) { queryCtx = ctx }).
+		Return(nil, nil)
+	s := NewBuildingService(buildingRepo, r.NewActorRepository_mock(t))
+
+	_, err := s.GetBuildings(context.Background(), "test", 5, 0)
+	require.NoError(t, err)
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"BuildingService.GetBuildings"}, tracingtest.GetSpanNames(exporter))
+	require.Equal(t, spans[0].SpanContext, trace.SpanContextFromContext(queryCtx))
+}
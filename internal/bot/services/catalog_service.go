@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+// CatalogService lists reference data of the dataset: neighbourhoods
+// and building authors.
+type CatalogService struct {
+	neighbourhoodCollection r.NeighbourhoodRepository
+	actorCollection         r.ActorRepository
+}
+
+func NewCatalogService(
+	neighbourhoodCollection r.NeighbourhoodRepository,
+	actorCollection r.ActorRepository,
+) CatalogService {
+	return CatalogService{neighbourhoodCollection, actorCollection}
+}
+
+func (s CatalogService) GetNeighbourhoods(
+	ctx context.Context,
+	limit,
+	offset int,
+) ([]NeighbourhoodDTO, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.GetNeighbourhoods")
+	defer span.End()
+	spec := r.NewNeighbourhoodSpecificationAll(limit, offset)
+	neighbourhoods, err := s.neighbourhoodCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"can not get neighbourhoods",
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	result := make([]NeighbourhoodDTO, len(neighbourhoods))
+	for i, n := range neighbourhoods {
+		result[i] = NeighbourhoodDTO{n.ID, n.Name, n.Municipality}
+	}
+	return result, nil
+}
+
+func (s CatalogService) GetArchitects(
+	ctx context.Context,
+	limit,
+	offset int,
+) ([]ArchitectDTO, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.GetArchitects")
+	defer span.End()
+	spec := r.NewActorSpecificationAll(limit, offset)
+	actors, err := s.actorCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"can not get architects",
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	result := make([]ArchitectDTO, len(actors))
+	for i, a := range actors {
+		result[i] = ArchitectDTO{a.ID, a.Name, a.TitleFi, a.TitleEn, a.TitleRu}
+	}
+	return result, nil
+}
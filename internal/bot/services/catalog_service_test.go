@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogService_GetNeighbourhoods(t *testing.T) {
+	municipality := "Helsinki"
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name           string
+		neighbourhoods []r.Neighbourhood
+		repoErr        error
+		expected       []NeighbourhoodDTO
+	}{
+		{
+			"neighbourhoods",
+			[]r.Neighbourhood{
+				{ID: 1, Name: "Kallio", Municipality: &municipality},
+				{ID: 2, Name: "Lauttasaari"},
+			},
+			nil,
+			[]NeighbourhoodDTO{
+				{1, "Kallio", &municipality},
+				{2, "Lauttasaari", nil},
+			},
+		},
+		{"no neighbourhoods", nil, nil, []NeighbourhoodDTO{}},
+		{"a repository error", nil, repositoryError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := r.NewNeighbourhoodRepository_mock(t)
+			spec := r.NewNeighbourhoodSpecificationAll(10, 20)
+			repo.EXPECT().Query(mock.Anything, spec).Return(tt.neighbourhoods, tt.repoErr)
+			s := NewCatalogService(repo, nil)
+			result, err := s.GetNeighbourhoods(context.Background(), 10, 20)
+			require.ErrorIs(t, err, tt.repoErr)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCatalogService_GetArchitects(t *testing.T) {
+	title := "arkkitehti"
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name     string
+		actors   []r.Actor
+		repoErr  error
+		expected []ArchitectDTO
+	}{
+		{
+			"architects",
+			[]r.Actor{{ID: 3, Name: "Alvar Aalto", TitleFi: &title}},
+			nil,
+			[]ArchitectDTO{{3, "Alvar Aalto", &title, nil, nil}},
+		},
+		{"a repository error", nil, repositoryError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := r.NewActorRepository_mock(t)
+			spec := r.NewActorSpecificationAll(10, 0)
+			repo.EXPECT().Query(mock.Anything, spec).Return(tt.actors, tt.repoErr)
+			s := NewCatalogService(nil, repo)
+			result, err := s.GetArchitects(context.Background(), 10, 0)
+			require.ErrorIs(t, err, tt.repoErr)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
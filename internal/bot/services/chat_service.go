@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+// ChatService keeps settings shared by all members of a group chat.
+type ChatService struct {
+	chatCollection repositories.ChatRepository
+}
+
+func NewChatService(chatCollection repositories.ChatRepository) ChatService {
+	return ChatService{chatCollection}
+}
+
+func (s ChatService) GetPreferredLanguage(ctx context.Context, chatID int64) (*Language, error) {
+	ctx, span := tracing.Start(ctx, "ChatService.GetPreferredLanguage")
+	defer span.End()
+	spec := repositories.NewChatSpecificationByID(chatID)
+	chats, err := s.chatCollection.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(chats) == 0 {
+		return nil, nil
+	}
+	language, ok := GetLanguagePerCode(chats[0].PreferredLanguage)
+	if !ok {
+		return nil, nil
+	}
+	return &language, nil
+}
+
+func (s ChatService) SetLanguage(ctx context.Context, chatID int64, language Language) error {
+	ctx, span := tracing.Start(ctx, "ChatService.SetLanguage")
+	defer span.End()
+	chat := repositories.Chat{TelegramID: chatID, PreferredLanguage: string(language)}
+	_, err := s.chatCollection.AddOrUpdate(ctx, chat)
+	return err
+}
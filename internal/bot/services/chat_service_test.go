@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatService_SetLanguage(t *testing.T) {
+	tests := []struct {
+		name            string
+		repositoryError error
+	}{
+		{"success", nil},
+		{"error", errors.New("some DB error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			chatCollection := repositories.NewChatRepository_mock(t)
+			expectedChat := repositories.Chat{TelegramID: -100, PreferredLanguage: "ru"}
+			chatCollection.EXPECT().AddOrUpdate(mock.Anything, expectedChat).
+				Return(nil, tt.repositoryError)
+			s := NewChatService(chatCollection)
+			err := s.SetLanguage(ctx, -100, Russian)
+			require.ErrorIs(t, err, tt.repositoryError)
+		})
+	}
+}
+
+func TestChatService_GetPreferredLanguage(t *testing.T) {
+	dbError := errors.New("some DB error")
+	tests := []struct {
+		name            string
+		foundChats      []repositories.Chat
+		repositoryError error
+		want            *Language
+		expectedError   error
+	}{
+		{"DB error", nil, dbError, nil, dbError},
+		{"no chats", nil, nil, nil, nil},
+		{
+			"unexpected preferred language",
+			[]repositories.Chat{{PreferredLanguage: "unexpected"}},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"a valid preferred language",
+			[]repositories.Chat{{PreferredLanguage: "fi"}},
+			nil,
+			&Finnish,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			chatCollection := repositories.NewChatRepository_mock(t)
+			chatCollection.EXPECT().
+				Query(mock.Anything, mock.MatchedBy(repositories.ChatByIDIsEqual(-100))).
+				Return(tt.foundChats, tt.repositoryError)
+			s := NewChatService(chatCollection)
+			got, err := s.GetPreferredLanguage(ctx, -100)
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
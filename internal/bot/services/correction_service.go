@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+type CorrectionService struct {
+	correctionCollection r.CorrectionRepository
+	buildingCollection   r.BuildingRepository
+	transactions         r.TransactionRepository
+}
+
+func NewCorrectionService(
+	correctionCollection r.CorrectionRepository,
+	buildingCollection r.BuildingRepository,
+	transactions r.TransactionRepository,
+) CorrectionService {
+	return CorrectionService{correctionCollection, buildingCollection, transactions}
+}
+
+func (s CorrectionService) Report(ctx context.Context, correction CorrectionDTO) error {
+	ctx, span := tracing.Start(ctx, "CorrectionService.Report")
+	defer span.End()
+	value := strings.TrimSpace(correction.Value)
+	if value == "" {
+		return ErrInvalidCorrection
+	}
+	if !slices.Contains(CorrectionFields, correction.Field) {
+		return fmt.Errorf("'%v': %w", correction.Field, ErrUnknownCorrectionField)
+	}
+	if correction.Field == CompletionYearField {
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("'%v': %w", value, ErrInvalidCorrection)
+		}
+	}
+	item := r.Correction{
+		BuildingID: correction.BuildingID,
+		Field:      string(correction.Field),
+		Language:   string(correction.Language),
+		Value:      value,
+		ReporterID: correction.ReporterID,
+		ChatID:     correction.ChatID,
+		Status:     r.CorrectionPending,
+	}
+	if _, err := s.correctionCollection.Add(ctx, item); err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf(
+				"can not save a correction of the building %v",
+				correction.BuildingID,
+			),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s CorrectionService) GetPendingCorrections(
+	ctx context.Context,
+	limit,
+	offset int,
+) ([]CorrectionDTO, error) {
+	ctx, span := tracing.Start(ctx, "CorrectionService.GetPendingCorrections")
+	defer span.End()
+	spec := r.NewCorrectionSpecificationByStatus(r.CorrectionPending, limit, offset)
+	corrections, err := s.correctionCollection.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	buildings, err := s.getBuildings(ctx, corrections)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CorrectionDTO, len(corrections))
+	for i, correction := range corrections {
+		result[i] = newCorrectionDTO(correction)
+		building, ok := buildings[correction.BuildingID]
+		if !ok {
+			return nil, fmt.Errorf("%v: %w", correction.BuildingID, ErrNoBuilding)
+		}
+		result[i].BuildingAddress = building.Address.StreetAddress
+		if correction.Field == string(CompletionYearField) {
+			if building.CompletionYear != nil {
+				year := strconv.Itoa(*building.CompletionYear)
+				result[i].CurrentValue = &year
+			}
+			continue
+		}
+		fieldPtr, err := getTextField(building, result[i].Field, result[i].Language)
+		if err != nil {
+			return nil, err
+		}
+		result[i].CurrentValue = *fieldPtr
+	}
+	return result, nil
+}
+
+// Accept applies a pending correction to a building. The status and
+// the building change together, so one of concurrent moderators wins.
+func (s CorrectionService) Accept(
+	ctx context.Context,
+	correctionID int64,
+) (*CorrectionDTO, error) {
+	ctx, span := tracing.Start(ctx, "CorrectionService.Accept")
+	defer span.End()
+	var result *CorrectionDTO
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		correction, err := s.getPendingCorrection(ctx, correctionID)
+		if err != nil {
+			return err
+		}
+		building, err := s.lockBuilding(ctx, correction.BuildingID)
+		if err != nil {
+			return err
+		}
+		if err := applyCorrection(building, *correction); err != nil {
+			return err
+		}
+		result, err = s.setStatus(ctx, *correction, r.CorrectionAccepted)
+		if err != nil {
+			return err
+		}
+		if _, err := s.buildingCollection.Update(ctx, *building); err != nil {
+			slog.ErrorContext(
+				ctx,
+				fmt.Sprintf(
+					"can not apply the correction %v to the building %v",
+					correction.ID,
+					building.ID,
+				),
+				slog.Any(logger.ErrorKey, err),
+			)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s CorrectionService) Reject(
+	ctx context.Context,
+	correctionID int64,
+) (*CorrectionDTO, error) {
+	ctx, span := tracing.Start(ctx, "CorrectionService.Reject")
+	defer span.End()
+	correction, err := s.getPendingCorrection(ctx, correctionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.setStatus(ctx, *correction, r.CorrectionRejected)
+}
+
+func applyCorrection(building *r.Building, correction r.Correction) error {
+	field := CorrectionField(correction.Field)
+	if field == CompletionYearField {
+		year, err := strconv.Atoi(correction.Value)
+		if err != nil {
+			return fmt.Errorf("'%v': %w", correction.Value, ErrInvalidCorrection)
+		}
+		building.CompletionYear = &year
+		return nil
+	}
+	fieldPtr, err := getTextField(building, field, Language(correction.Language))
+	if err != nil {
+		return err
+	}
+	value := correction.Value
+	*fieldPtr = &value
+	return nil
+}
+
+func (s CorrectionService) getPendingCorrection(
+	ctx context.Context,
+	correctionID int64,
+) (*r.Correction, error) {
+	spec := r.NewCorrectionSpecificationByID(correctionID)
+	corrections, err := s.correctionCollection.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(corrections) == 0 {
+		return nil, fmt.Errorf("%v: %w", correctionID, ErrNoCorrection)
+	}
+	if corrections[0].Status != r.CorrectionPending {
+		return nil, fmt.Errorf(
+			"%v is %v: %w",
+			correctionID,
+			corrections[0].Status,
+			ErrCorrectionModerated,
+		)
+	}
+	return &corrections[0], nil
+}
+
+// lockBuilding reads a building in a transaction of a context
+// and locks it until the transaction ends.
+func (s CorrectionService) lockBuilding(
+	ctx context.Context,
+	buildingID int64,
+) (*r.Building, error) {
+	spec := r.NewBuildingSpecificationByIDForUpdate(buildingID)
+	buildings, err := s.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(buildings) == 0 {
+		return nil, fmt.Errorf("%v: %w", buildingID, ErrNoBuilding)
+	}
+	return &buildings[0], nil
+}
+
+// getBuildings loads buildings of all corrections with one query.
+func (s CorrectionService) getBuildings(
+	ctx context.Context,
+	corrections []r.Correction,
+) (map[int64]*r.Building, error) {
+	if len(corrections) == 0 {
+		return nil, nil
+	}
+	ids := []int64{}
+	for _, correction := range corrections {
+		if !slices.Contains(ids, correction.BuildingID) {
+			ids = append(ids, correction.BuildingID)
+		}
+	}
+	slices.Sort(ids)
+	buildings, err := s.buildingCollection.Query(ctx, r.NewBuildingSpecificationByIDs(ids))
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*r.Building, len(buildings))
+	for i := range buildings {
+		result[buildings[i].ID] = &buildings[i]
+	}
+	return result, nil
+}
+
+func (s CorrectionService) setStatus(
+	ctx context.Context,
+	correction r.Correction,
+	status r.CorrectionStatus,
+) (*CorrectionDTO, error) {
+	correction.Status = status
+	updated, err := s.correctionCollection.Update(ctx, correction)
+	if errors.Is(err, r.ErrNotExist) {
+		// another moderator has been faster
+		return nil, fmt.Errorf("%v: %w", correction.ID, ErrCorrectionModerated)
+	}
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not set the status '%v' to the correction %v", status, correction.ID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	result := newCorrectionDTO(*updated)
+	return &result, nil
+}
+
+func newCorrectionDTO(c r.Correction) CorrectionDTO {
+	return CorrectionDTO{
+		ID:         c.ID,
+		BuildingID: c.BuildingID,
+		Field:      CorrectionField(c.Field),
+		Language:   Language(c.Language),
+		Value:      c.Value,
+		ReporterID: c.ReporterID,
+		ChatID:     c.ChatID,
+	}
+}
+
+func getTextField(
+	b *r.Building,
+	field CorrectionField,
+	language Language,
+) (**string, error) {
+	var fields [3]**string
+	switch field {
+	case NameField:
+		fields = [3]**string{&b.NameFi, &b.NameEn, &b.NameRu}
+	case DescriptionField:
+		fields = [3]**string{
+			&b.FloorDescriptionFi,
+			&b.FloorDescriptionEn,
+			&b.FloorDescriptionRu,
+		}
+	case FacadesField:
+		fields = [3]**string{&b.FacadesFi, &b.FacadesEn, &b.FacadesRu}
+	case DetailsField:
+		fields = [3]**string{
+			&b.SpecialFeaturesFi,
+			&b.SpecialFeaturesEn,
+			&b.SpecialFeaturesRu,
+		}
+	case NotableFeaturesField:
+		fields = [3]**string{&b.ReasoningFi, &b.ReasoningEn, &b.ReasoningRu}
+	case SurroundingsField:
+		fields = [3]**string{&b.SurroundingsFi, &b.SurroundingsEn, &b.SurroundingsRu}
+	case HistoryField:
+		fields = [3]**string{&b.HistoryFi, &b.HistoryEn, &b.HistoryRu}
+	default:
+		return nil, fmt.Errorf("'%v': %w", field, ErrUnknownCorrectionField)
+	}
+	switch language {
+	case Finnish:
+		return fields[0], nil
+	case Russian:
+		return fields[2], nil
+	default:
+		return fields[1], nil
+	}
+}
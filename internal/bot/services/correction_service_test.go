@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrectionService_Report(t *testing.T) {
+	tests := []struct {
+		name            string
+		correction      CorrectionDTO
+		expected        *r.Correction
+		repositoryError error
+		expectedError   error
+	}{
+		{
+			"success",
+			CorrectionDTO{
+				BuildingID: 1,
+				Field:      HistoryField,
+				Language:   English,
+				Value:      "  new history ",
+				ReporterID: 2,
+				ChatID:     3,
+			},
+			&r.Correction{
+				BuildingID: 1,
+				Field:      "history",
+				Language:   "en",
+				Value:      "new history",
+				ReporterID: 2,
+				ChatID:     3,
+				Status:     r.CorrectionPending,
+			},
+			nil,
+			nil,
+		},
+		{
+			"repository error",
+			CorrectionDTO{
+				BuildingID: 1,
+				Field:      CompletionYearField,
+				Language:   Finnish,
+				Value:      "1930",
+			},
+			&r.Correction{
+				BuildingID: 1,
+				Field:      "year",
+				Language:   "fi",
+				Value:      "1930",
+				Status:     r.CorrectionPending,
+			},
+			r.ErrNoDependency,
+			r.ErrNoDependency,
+		},
+		{
+			"empty value",
+			CorrectionDTO{BuildingID: 1, Field: HistoryField, Value: "  "},
+			nil,
+			nil,
+			ErrInvalidCorrection,
+		},
+		{
+			"invalid year",
+			CorrectionDTO{BuildingID: 1, Field: CompletionYearField, Value: "193O"},
+			nil,
+			nil,
+			ErrInvalidCorrection,
+		},
+		{
+			"unknown field",
+			CorrectionDTO{BuildingID: 1, Field: "address", Value: "test"},
+			nil,
+			nil,
+			ErrUnknownCorrectionField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			correctionRepo := r.NewCorrectionRepository_mock(t)
+			if tt.expected != nil {
+				correctionRepo.EXPECT().Add(mock.Anything, *tt.expected).
+					Return(nil, tt.repositoryError)
+			}
+			s := NewCorrectionService(correctionRepo, r.NewBuildingRepository_mock(t), nil)
+			err := s.Report(ctx, tt.correction)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestCorrectionService_Accept(t *testing.T) {
+	tests := []struct {
+		name             string
+		correction       r.Correction
+		building         r.Building
+		expectedBuilding r.Building
+	}{
+		{
+			"text field",
+			r.Correction{
+				ID:         7,
+				BuildingID: 1,
+				Field:      "history",
+				Language:   "ru",
+				Value:      "новая история",
+				ChatID:     3,
+				Status:     r.CorrectionPending,
+			},
+			r.Building{ID: 1, HistoryEn: utils.GetPointer("history")},
+			r.Building{
+				ID:        1,
+				HistoryEn: utils.GetPointer("history"),
+				HistoryRu: utils.GetPointer("новая история"),
+			},
+		},
+		{
+			"completion year",
+			r.Correction{
+				ID:         7,
+				BuildingID: 1,
+				Field:      "year",
+				Language:   "fi",
+				Value:      "1930",
+				Status:     r.CorrectionPending,
+			},
+			r.Building{ID: 1, CompletionYear: utils.GetPointer(1931)},
+			r.Building{ID: 1, CompletionYear: utils.GetPointer(1930)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			correctionRepo := r.NewCorrectionRepository_mock(t)
+			buildingRepo := r.NewBuildingRepository_mock(t)
+			correctionRepo.EXPECT().
+				Query(mock.Anything, mock.MatchedBy(r.CorrectionByIDIsEqual(tt.correction.ID))).
+				Return([]r.Correction{tt.correction}, nil)
+			buildingRepo.EXPECT().
+				Query(mock.Anything, mock.MatchedBy(r.BuildingByIDForUpdateIsEqual(tt.building.ID))).
+				Return([]r.Building{tt.building}, nil)
+			buildingRepo.EXPECT().Update(mock.Anything, tt.expectedBuilding).
+				Return(&tt.expectedBuilding, nil)
+			accepted := tt.correction
+			accepted.Status = r.CorrectionAccepted
+			correctionRepo.EXPECT().Update(mock.Anything, accepted).Return(&accepted, nil)
+
+			s := NewCorrectionService(correctionRepo, buildingRepo, newTransactions(t))
+			got, err := s.Accept(ctx, tt.correction.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.correction.ChatID, got.ChatID)
+			require.Equal(t, CorrectionField(tt.correction.Field), got.Field)
+		})
+	}
+}
+
+func TestCorrectionService_moderatedCorrection(t *testing.T) {
+	ctx := context.Background()
+	correctionRepo := r.NewCorrectionRepository_mock(t)
+	correctionRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.CorrectionByIDIsEqual(7))).
+		Return([]r.Correction{{ID: 7, Status: r.CorrectionRejected}}, nil).
+		Once().
+		On("Query", mock.Anything, mock.MatchedBy(r.CorrectionByIDIsEqual(8))).
+		Return(nil, nil).
+		Once()
+	s := NewCorrectionService(correctionRepo, r.NewBuildingRepository_mock(t), newTransactions(t))
+
+	_, err := s.Accept(ctx, 7)
+	require.ErrorIs(t, err, ErrCorrectionModerated)
+	_, err = s.Reject(ctx, 8)
+	require.ErrorIs(t, err, ErrNoCorrection)
+}
+
+func TestCorrectionService_Reject(t *testing.T) {
+	ctx := context.Background()
+	correction := r.Correction{ID: 7, Field: "name", Status: r.CorrectionPending}
+	rejected := correction
+	rejected.Status = r.CorrectionRejected
+	updateErr := errors.New("test error")
+	correctionRepo := r.NewCorrectionRepository_mock(t)
+	correctionRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.CorrectionByIDIsEqual(7))).
+		Return([]r.Correction{correction}, nil)
+	correctionRepo.EXPECT().Update(mock.Anything, rejected).Return(&rejected, nil).Once()
+	correctionRepo.EXPECT().Update(mock.Anything, rejected).Return(nil, updateErr).Once()
+	s := NewCorrectionService(correctionRepo, r.NewBuildingRepository_mock(t), nil)
+
+	got, err := s.Reject(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), got.ID)
+	_, err = s.Reject(ctx, 7)
+	require.ErrorIs(t, err, updateErr)
+}
+
+// newTransactions runs functions of a service without a database.
+func newTransactions(t *testing.T) *r.TransactionRepository_mock {
+	transactions := r.NewTransactionRepository_mock(t)
+	transactions.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		},
+	)
+	return transactions
+}
+
+func TestCorrectionService_concurrentModeration(t *testing.T) {
+	ctx := context.Background()
+	correction := r.Correction{ID: 7, BuildingID: 1, Field: "name", Status: r.CorrectionPending}
+	correctionRepo := r.NewCorrectionRepository_mock(t)
+	correctionRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.CorrectionByIDIsEqual(7))).
+		Return([]r.Correction{correction}, nil)
+	// another moderator has changed the status after the query
+	correctionRepo.EXPECT().Update(mock.Anything, mock.Anything).Return(nil, r.ErrNotExist)
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByIDForUpdateIsEqual(1))).
+		Return([]r.Building{{ID: 1}}, nil)
+	s := NewCorrectionService(correctionRepo, buildingRepo, newTransactions(t))
+
+	_, err := s.Accept(ctx, 7)
+	require.ErrorIs(t, err, ErrCorrectionModerated)
+	_, err = s.Reject(ctx, 7)
+	require.ErrorIs(t, err, ErrCorrectionModerated)
+}
+
+func TestCorrectionService_GetPendingCorrections(t *testing.T) {
+	ctx := context.Background()
+	correctionRepo := r.NewCorrectionRepository_mock(t)
+	correctionRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.CorrectionByStatusIsEqual(r.CorrectionPending, 3, 0))).
+		Return(
+			[]r.Correction{
+				{ID: 1, BuildingID: 2, Field: "name", Language: "en", Value: "new"},
+				{ID: 2, BuildingID: 1, Field: "year", Value: "1930"},
+				{ID: 3, BuildingID: 2, Field: "history", Language: "en", Value: "old"},
+			},
+			nil,
+		)
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByIDsIsEqual([]int64{1, 2}))).
+		Return(
+			[]r.Building{
+				{
+					ID:             1,
+					Address:        r.Address{StreetAddress: "first"},
+					CompletionYear: utils.GetPointer(1931),
+				},
+				{
+					ID:      2,
+					Address: r.Address{StreetAddress: "second"},
+					NameEn:  utils.GetPointer("name"),
+				},
+			},
+			nil,
+		).
+		Once()
+	s := NewCorrectionService(correctionRepo, buildingRepo, nil)
+
+	got, err := s.GetPendingCorrections(ctx, 3, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, "second", got[0].BuildingAddress)
+	require.Equal(t, utils.GetPointer("name"), got[0].CurrentValue)
+	require.Equal(t, "first", got[1].BuildingAddress)
+	require.Equal(t, utils.GetPointer("1931"), got[1].CurrentValue)
+	require.Nil(t, got[2].CurrentValue)
+}
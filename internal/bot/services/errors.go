@@ -0,0 +1,16 @@
+package services
+
+import "errors"
+
+var (
+	ErrUnknownCorrectionField = errors.New("an unknown correction field")
+	ErrInvalidCorrection      = errors.New("an invalid correction value")
+	ErrNoBuilding             = errors.New("a building does not exist")
+	ErrNoCorrection           = errors.New("a correction does not exist")
+	ErrCorrectionModerated    = errors.New("a correction is already moderated")
+	ErrNoPhoto                = errors.New("a photo does not exist")
+	ErrPhotoModerated         = errors.New("a photo is already moderated")
+	ErrDuplicatePhoto         = errors.New("a photo is already attached to a building")
+	ErrNoRoute                = errors.New("no buildings with coordinates for a route")
+	ErrTooManyRouteBuildings  = errors.New("too many buildings for a route")
+)
@@ -9,7 +9,9 @@ type Buildings interface {
 		limit,
 		offset int,
 	) ([]BuildingDTO, error)
+	CountBuildings(ctx context.Context, addressPrefix string) (int, error)
 	GetBuildingsByAddress(c context.Context, address string) ([]BuildingDTO, error)
+	GetBuildingsByIDs(ctx context.Context, buildingIDs []int64) ([]BuildingDTO, error)
 	GetNearestBuildings(
 		ctx context.Context,
 		distance int,
@@ -18,9 +20,80 @@ type Buildings interface {
 		limit,
 		offset int,
 	) ([]BuildingDTO, error)
+	CountNearestBuildings(
+		ctx context.Context,
+		distance int,
+		latitude,
+		longitude float64,
+	) (int, error)
 	GetBuildingByID(c context.Context, ID int64) (*BuildingDTO, error)
+	GetBuildingsByNeighbourhood(
+		ctx context.Context,
+		neighbourhoodID int64,
+		limit,
+		offset int,
+	) ([]BuildingDTO, error)
+	GetBuildingsByAuthor(
+		ctx context.Context,
+		actorID int64,
+		limit,
+		offset int,
+	) ([]BuildingDTO, error)
+	GetBuildingsInBBox(
+		ctx context.Context,
+		southWest,
+		northEast Coordinates,
+		limit int,
+	) ([]BuildingDTO, error)
 }
 type Users interface {
 	GetPreferredLanguage(ctx context.Context, userID int64) (*Language, error)
 	SetLanguage(ctx context.Context, userID int64, language Language) error
 }
+type Chats interface {
+	GetPreferredLanguage(ctx context.Context, chatID int64) (*Language, error)
+	SetLanguage(ctx context.Context, chatID int64, language Language) error
+}
+type Corrections interface {
+	Report(ctx context.Context, correction CorrectionDTO) error
+	GetPendingCorrections(
+		ctx context.Context,
+		limit,
+		offset int,
+	) ([]CorrectionDTO, error)
+	Accept(ctx context.Context, correctionID int64) (*CorrectionDTO, error)
+	Reject(ctx context.Context, correctionID int64) (*CorrectionDTO, error)
+}
+type Photos interface {
+	AddPhoto(ctx context.Context, photo PhotoDTO, approved bool) (*PhotoDTO, error)
+	GetBuildingPhotos(ctx context.Context, buildingID int64) ([]PhotoDTO, error)
+	GetPendingPhotos(ctx context.Context, limit, offset int) ([]PhotoDTO, error)
+	AcceptPhoto(ctx context.Context, photoID int64) (*PhotoDTO, error)
+	RejectPhoto(ctx context.Context, photoID int64) (*PhotoDTO, error)
+}
+type Routes interface {
+	PlanRoute(
+		ctx context.Context,
+		buildingIDs []int64,
+		start *Coordinates,
+	) (*RouteDTO, error)
+}
+type Updates interface {
+	GetOffset(ctx context.Context) (int, error)
+	Receive(updateID int)
+	IsHandled(ctx context.Context, updateID int) (bool, error)
+	Finish(ctx context.Context, updateID int) error
+}
+type Popularity interface {
+	RecordView(ctx context.Context, userID, buildingID int64) error
+	RecordSearch(ctx context.Context, userID int64, searchType, query string) error
+	GetPopularBuildings(
+		ctx context.Context,
+		neighbourhood string,
+		limit int,
+	) ([]BuildingDTO, error)
+}
+type Catalog interface {
+	GetNeighbourhoods(ctx context.Context, limit, offset int) ([]NeighbourhoodDTO, error)
+	GetArchitects(ctx context.Context, limit, offset int) ([]ArchitectDTO, error)
+}
@@ -21,6 +21,122 @@ func (_m *Buildings_mock) EXPECT() *Buildings_mock_Expecter {
 	return &Buildings_mock_Expecter{mock: &_m.Mock}
 }
 
+// CountBuildings provides a mock function with given fields: ctx, addressPrefix
+func (_m *Buildings_mock) CountBuildings(ctx context.Context, addressPrefix string) (int, error) {
+	ret := _m.Called(ctx, addressPrefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountBuildings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, addressPrefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, addressPrefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, addressPrefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_CountBuildings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountBuildings'
+type Buildings_mock_CountBuildings_Call struct {
+	*mock.Call
+}
+
+// CountBuildings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - addressPrefix string
+func (_e *Buildings_mock_Expecter) CountBuildings(ctx interface{}, addressPrefix interface{}) *Buildings_mock_CountBuildings_Call {
+	return &Buildings_mock_CountBuildings_Call{Call: _e.mock.On("CountBuildings", ctx, addressPrefix)}
+}
+
+func (_c *Buildings_mock_CountBuildings_Call) Run(run func(ctx context.Context, addressPrefix string)) *Buildings_mock_CountBuildings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_CountBuildings_Call) Return(_a0 int, _a1 error) *Buildings_mock_CountBuildings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_CountBuildings_Call) RunAndReturn(run func(context.Context, string) (int, error)) *Buildings_mock_CountBuildings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountNearestBuildings provides a mock function with given fields: ctx, distance, latitude, longitude
+func (_m *Buildings_mock) CountNearestBuildings(ctx context.Context, distance int, latitude float64, longitude float64) (int, error) {
+	ret := _m.Called(ctx, distance, latitude, longitude)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountNearestBuildings")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64, float64) (int, error)); ok {
+		return rf(ctx, distance, latitude, longitude)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, float64, float64) int); ok {
+		r0 = rf(ctx, distance, latitude, longitude)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, float64, float64) error); ok {
+		r1 = rf(ctx, distance, latitude, longitude)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_CountNearestBuildings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountNearestBuildings'
+type Buildings_mock_CountNearestBuildings_Call struct {
+	*mock.Call
+}
+
+// CountNearestBuildings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - distance int
+//   - latitude float64
+//   - longitude float64
+func (_e *Buildings_mock_Expecter) CountNearestBuildings(ctx interface{}, distance interface{}, latitude interface{}, longitude interface{}) *Buildings_mock_CountNearestBuildings_Call {
+	return &Buildings_mock_CountNearestBuildings_Call{Call: _e.mock.On("CountNearestBuildings", ctx, distance, latitude, longitude)}
+}
+
+func (_c *Buildings_mock_CountNearestBuildings_Call) Run(run func(ctx context.Context, distance int, latitude float64, longitude float64)) *Buildings_mock_CountNearestBuildings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(float64), args[3].(float64))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_CountNearestBuildings_Call) Return(_a0 int, _a1 error) *Buildings_mock_CountNearestBuildings_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_CountNearestBuildings_Call) RunAndReturn(run func(context.Context, int, float64, float64) (int, error)) *Buildings_mock_CountNearestBuildings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBuildingByID provides a mock function with given fields: c, ID
 func (_m *Buildings_mock) GetBuildingByID(c context.Context, ID int64) (*BuildingDTO, error) {
 	ret := _m.Called(c, ID)
@@ -200,6 +316,248 @@ func (_c *Buildings_mock_GetBuildingsByAddress_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetBuildingsByAuthor provides a mock function with given fields: ctx, actorID, limit, offset
+func (_m *Buildings_mock) GetBuildingsByAuthor(ctx context.Context, actorID int64, limit int, offset int) ([]BuildingDTO, error) {
+	ret := _m.Called(ctx, actorID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildingsByAuthor")
+	}
+
+	var r0 []BuildingDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]BuildingDTO, error)); ok {
+		return rf(ctx, actorID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []BuildingDTO); ok {
+		r0 = rf(ctx, actorID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BuildingDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, actorID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_GetBuildingsByAuthor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildingsByAuthor'
+type Buildings_mock_GetBuildingsByAuthor_Call struct {
+	*mock.Call
+}
+
+// GetBuildingsByAuthor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID int64
+//   - limit int
+//   - offset int
+func (_e *Buildings_mock_Expecter) GetBuildingsByAuthor(ctx interface{}, actorID interface{}, limit interface{}, offset interface{}) *Buildings_mock_GetBuildingsByAuthor_Call {
+	return &Buildings_mock_GetBuildingsByAuthor_Call{Call: _e.mock.On("GetBuildingsByAuthor", ctx, actorID, limit, offset)}
+}
+
+func (_c *Buildings_mock_GetBuildingsByAuthor_Call) Run(run func(ctx context.Context, actorID int64, limit int, offset int)) *Buildings_mock_GetBuildingsByAuthor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByAuthor_Call) Return(_a0 []BuildingDTO, _a1 error) *Buildings_mock_GetBuildingsByAuthor_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByAuthor_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]BuildingDTO, error)) *Buildings_mock_GetBuildingsByAuthor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBuildingsByIDs provides a mock function with given fields: ctx, buildingIDs
+func (_m *Buildings_mock) GetBuildingsByIDs(ctx context.Context, buildingIDs []int64) ([]BuildingDTO, error) {
+	ret := _m.Called(ctx, buildingIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildingsByIDs")
+	}
+
+	var r0 []BuildingDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]BuildingDTO, error)); ok {
+		return rf(ctx, buildingIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []BuildingDTO); ok {
+		r0 = rf(ctx, buildingIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BuildingDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, buildingIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_GetBuildingsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildingsByIDs'
+type Buildings_mock_GetBuildingsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetBuildingsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - buildingIDs []int64
+func (_e *Buildings_mock_Expecter) GetBuildingsByIDs(ctx interface{}, buildingIDs interface{}) *Buildings_mock_GetBuildingsByIDs_Call {
+	return &Buildings_mock_GetBuildingsByIDs_Call{Call: _e.mock.On("GetBuildingsByIDs", ctx, buildingIDs)}
+}
+
+func (_c *Buildings_mock_GetBuildingsByIDs_Call) Run(run func(ctx context.Context, buildingIDs []int64)) *Buildings_mock_GetBuildingsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByIDs_Call) Return(_a0 []BuildingDTO, _a1 error) *Buildings_mock_GetBuildingsByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByIDs_Call) RunAndReturn(run func(context.Context, []int64) ([]BuildingDTO, error)) *Buildings_mock_GetBuildingsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBuildingsByNeighbourhood provides a mock function with given fields: ctx, neighbourhoodID, limit, offset
+func (_m *Buildings_mock) GetBuildingsByNeighbourhood(ctx context.Context, neighbourhoodID int64, limit int, offset int) ([]BuildingDTO, error) {
+	ret := _m.Called(ctx, neighbourhoodID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildingsByNeighbourhood")
+	}
+
+	var r0 []BuildingDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) ([]BuildingDTO, error)); ok {
+		return rf(ctx, neighbourhoodID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) []BuildingDTO); ok {
+		r0 = rf(ctx, neighbourhoodID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BuildingDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, neighbourhoodID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_GetBuildingsByNeighbourhood_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildingsByNeighbourhood'
+type Buildings_mock_GetBuildingsByNeighbourhood_Call struct {
+	*mock.Call
+}
+
+// GetBuildingsByNeighbourhood is a helper method to define mock.On call
+//   - ctx context.Context
+//   - neighbourhoodID int64
+//   - limit int
+//   - offset int
+func (_e *Buildings_mock_Expecter) GetBuildingsByNeighbourhood(ctx interface{}, neighbourhoodID interface{}, limit interface{}, offset interface{}) *Buildings_mock_GetBuildingsByNeighbourhood_Call {
+	return &Buildings_mock_GetBuildingsByNeighbourhood_Call{Call: _e.mock.On("GetBuildingsByNeighbourhood", ctx, neighbourhoodID, limit, offset)}
+}
+
+func (_c *Buildings_mock_GetBuildingsByNeighbourhood_Call) Run(run func(ctx context.Context, neighbourhoodID int64, limit int, offset int)) *Buildings_mock_GetBuildingsByNeighbourhood_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByNeighbourhood_Call) Return(_a0 []BuildingDTO, _a1 error) *Buildings_mock_GetBuildingsByNeighbourhood_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsByNeighbourhood_Call) RunAndReturn(run func(context.Context, int64, int, int) ([]BuildingDTO, error)) *Buildings_mock_GetBuildingsByNeighbourhood_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBuildingsInBBox provides a mock function with given fields: ctx, southWest, northEast, limit
+func (_m *Buildings_mock) GetBuildingsInBBox(ctx context.Context, southWest Coordinates, northEast Coordinates, limit int) ([]BuildingDTO, error) {
+	ret := _m.Called(ctx, southWest, northEast, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBuildingsInBBox")
+	}
+
+	var r0 []BuildingDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Coordinates, Coordinates, int) ([]BuildingDTO, error)); ok {
+		return rf(ctx, southWest, northEast, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Coordinates, Coordinates, int) []BuildingDTO); ok {
+		r0 = rf(ctx, southWest, northEast, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BuildingDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Coordinates, Coordinates, int) error); ok {
+		r1 = rf(ctx, southWest, northEast, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Buildings_mock_GetBuildingsInBBox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBuildingsInBBox'
+type Buildings_mock_GetBuildingsInBBox_Call struct {
+	*mock.Call
+}
+
+// GetBuildingsInBBox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - southWest Coordinates
+//   - northEast Coordinates
+//   - limit int
+func (_e *Buildings_mock_Expecter) GetBuildingsInBBox(ctx interface{}, southWest interface{}, northEast interface{}, limit interface{}) *Buildings_mock_GetBuildingsInBBox_Call {
+	return &Buildings_mock_GetBuildingsInBBox_Call{Call: _e.mock.On("GetBuildingsInBBox", ctx, southWest, northEast, limit)}
+}
+
+func (_c *Buildings_mock_GetBuildingsInBBox_Call) Run(run func(ctx context.Context, southWest Coordinates, northEast Coordinates, limit int)) *Buildings_mock_GetBuildingsInBBox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(Coordinates), args[2].(Coordinates), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsInBBox_Call) Return(_a0 []BuildingDTO, _a1 error) *Buildings_mock_GetBuildingsInBBox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Buildings_mock_GetBuildingsInBBox_Call) RunAndReturn(run func(context.Context, Coordinates, Coordinates, int) ([]BuildingDTO, error)) *Buildings_mock_GetBuildingsInBBox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetNearestBuildings provides a mock function with given fields: ctx, distance, latitude, longitude, limit, offset
 func (_m *Buildings_mock) GetNearestBuildings(ctx context.Context, distance int, latitude float64, longitude float64, limit int, offset int) ([]BuildingDTO, error) {
 	ret := _m.Called(ctx, distance, latitude, longitude, limit, offset)
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+// Telegram accepts from 2 to 10 items in a media group.
+const MAX_BUILDING_PHOTOS = 10
+
+type PhotoService struct {
+	photoCollection r.PhotoRepository
+}
+
+func NewPhotoService(photoCollection r.PhotoRepository) PhotoService {
+	return PhotoService{photoCollection}
+}
+
+func (s PhotoService) AddPhoto(
+	ctx context.Context,
+	photo PhotoDTO,
+	approved bool,
+) (*PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.AddPhoto")
+	defer span.End()
+	status := r.PhotoPending
+	if approved {
+		status = r.PhotoAccepted
+	}
+	item := r.BuildingPhoto{
+		BuildingID:   photo.BuildingID,
+		FileID:       photo.FileID,
+		FileUniqueID: photo.FileUniqueID,
+		Attribution:  photo.Attribution,
+		UploaderID:   photo.UploaderID,
+		ChatID:       photo.ChatID,
+		Status:       status,
+	}
+	saved, err := s.photoCollection.Add(ctx, item)
+	if errors.Is(err, r.ErrDuplicate) {
+		return nil, fmt.Errorf("%v: %w", photo.FileUniqueID, ErrDuplicatePhoto)
+	}
+	if errors.Is(err, r.ErrNoDependency) {
+		return nil, fmt.Errorf("%v: %w", photo.BuildingID, ErrNoBuilding)
+	}
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not save a photo of the building %v", photo.BuildingID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	result := newPhotoDTO(*saved)
+	return &result, nil
+}
+
+func (s PhotoService) GetBuildingPhotos(
+	ctx context.Context,
+	buildingID int64,
+) ([]PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.GetBuildingPhotos")
+	defer span.End()
+	spec := r.NewPhotoSpecificationByBuilding(
+		buildingID,
+		r.PhotoAccepted,
+		MAX_BUILDING_PHOTOS,
+	)
+	return s.queryPhotos(ctx, spec)
+}
+
+func (s PhotoService) GetPendingPhotos(
+	ctx context.Context,
+	limit,
+	offset int,
+) ([]PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.GetPendingPhotos")
+	defer span.End()
+	spec := r.NewPhotoSpecificationByStatus(r.PhotoPending, limit, offset)
+	return s.queryPhotos(ctx, spec)
+}
+
+func (s PhotoService) AcceptPhoto(ctx context.Context, photoID int64) (*PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.AcceptPhoto")
+	defer span.End()
+	return s.setStatus(ctx, photoID, r.PhotoAccepted)
+}
+
+func (s PhotoService) RejectPhoto(ctx context.Context, photoID int64) (*PhotoDTO, error) {
+	ctx, span := tracing.Start(ctx, "PhotoService.RejectPhoto")
+	defer span.End()
+	return s.setStatus(ctx, photoID, r.PhotoRejected)
+}
+
+func (s PhotoService) queryPhotos(
+	ctx context.Context,
+	spec r.Specification,
+) ([]PhotoDTO, error) {
+	photos, err := s.photoCollection.Query(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PhotoDTO, len(photos))
+	for i, photo := range photos {
+		result[i] = newPhotoDTO(photo)
+	}
+	return result, nil
+}
+
+func (s PhotoService) setStatus(
+	ctx context.Context,
+	photoID int64,
+	status r.PhotoStatus,
+) (*PhotoDTO, error) {
+	photos, err := s.photoCollection.Query(ctx, r.NewPhotoSpecificationByID(photoID))
+	if err != nil {
+		return nil, err
+	}
+	if len(photos) == 0 {
+		return nil, fmt.Errorf("%v: %w", photoID, ErrNoPhoto)
+	}
+	photo := photos[0]
+	if photo.Status != r.PhotoPending {
+		return nil, fmt.Errorf("%v is %v: %w", photoID, photo.Status, ErrPhotoModerated)
+	}
+	photo.Status = status
+	updated, err := s.photoCollection.Update(ctx, photo)
+	if errors.Is(err, r.ErrNotExist) {
+		return nil, fmt.Errorf("%v: %w", photoID, ErrPhotoModerated)
+	}
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not set the status '%v' to the photo %v", status, photoID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	result := newPhotoDTO(*updated)
+	return &result, nil
+}
+
+func newPhotoDTO(p r.BuildingPhoto) PhotoDTO {
+	return PhotoDTO{
+		ID:           p.ID,
+		BuildingID:   p.BuildingID,
+		FileID:       p.FileID,
+		FileUniqueID: p.FileUniqueID,
+		Attribution:  p.Attribution,
+		UploaderID:   p.UploaderID,
+		ChatID:       p.ChatID,
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhotoService_AddPhoto(t *testing.T) {
+	photo := PhotoDTO{
+		BuildingID:   1,
+		FileID:       "file",
+		FileUniqueID: "unique",
+		Attribution:  "author",
+		UploaderID:   2,
+		ChatID:       3,
+	}
+	tests := []struct {
+		name            string
+		approved        bool
+		expectedStatus  r.PhotoStatus
+		repositoryError error
+		expectedError   error
+	}{
+		{"pending", false, r.PhotoPending, nil, nil},
+		{"approved", true, r.PhotoAccepted, nil, nil},
+		{"duplicate", false, r.PhotoPending, r.ErrDuplicate, ErrDuplicatePhoto},
+		{"no building", false, r.PhotoPending, r.ErrNoDependency, ErrNoBuilding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			expected := r.BuildingPhoto{
+				BuildingID:   1,
+				FileID:       "file",
+				FileUniqueID: "unique",
+				Attribution:  "author",
+				UploaderID:   2,
+				ChatID:       3,
+				Status:       tt.expectedStatus,
+			}
+			saved := expected
+			saved.ID = 5
+			photoRepo := r.NewPhotoRepository_mock(t)
+			if tt.repositoryError != nil {
+				photoRepo.EXPECT().Add(mock.Anything, expected).Return(nil, tt.repositoryError)
+			} else {
+				photoRepo.EXPECT().Add(mock.Anything, expected).Return(&saved, nil)
+			}
+			s := NewPhotoService(photoRepo)
+			got, err := s.AddPhoto(ctx, photo, tt.approved)
+			require.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError == nil {
+				require.Equal(t, int64(5), got.ID)
+			}
+		})
+	}
+}
+
+func TestPhotoService_GetBuildingPhotos(t *testing.T) {
+	ctx := context.Background()
+	photoRepo := r.NewPhotoRepository_mock(t)
+	spec := r.PhotoByBuildingIsEqual(1, r.PhotoAccepted, MAX_BUILDING_PHOTOS)
+	photoRepo.EXPECT().Query(mock.Anything, mock.MatchedBy(spec)).Return(
+		[]r.BuildingPhoto{{ID: 5, BuildingID: 1, FileID: "file", Status: r.PhotoAccepted}},
+		nil,
+	)
+	s := NewPhotoService(photoRepo)
+	got, err := s.GetBuildingPhotos(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []PhotoDTO{{ID: 5, BuildingID: 1, FileID: "file"}}, got)
+}
+
+func TestPhotoService_moderation(t *testing.T) {
+	ctx := context.Background()
+	photo := r.BuildingPhoto{ID: 5, ChatID: 3, Status: r.PhotoPending}
+	accepted := photo
+	accepted.Status = r.PhotoAccepted
+	photoRepo := r.NewPhotoRepository_mock(t)
+	photoRepo.EXPECT().Query(mock.Anything, mock.MatchedBy(r.PhotoByIDIsEqual(5))).
+		Return([]r.BuildingPhoto{photo}, nil).
+		Once().
+		On("Query", mock.Anything, mock.MatchedBy(r.PhotoByIDIsEqual(6))).
+		Return([]r.BuildingPhoto{{ID: 6, Status: r.PhotoRejected}}, nil).
+		Once().
+		On("Query", mock.Anything, mock.MatchedBy(r.PhotoByIDIsEqual(7))).
+		Return(nil, nil).
+		Once()
+	photoRepo.EXPECT().Update(mock.Anything, accepted).Return(&accepted, nil)
+	s := NewPhotoService(photoRepo)
+
+	got, err := s.AcceptPhoto(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), got.ChatID)
+	_, err = s.RejectPhoto(ctx, 6)
+	require.ErrorIs(t, err, ErrPhotoModerated)
+	_, err = s.AcceptPhoto(ctx, 7)
+	require.ErrorIs(t, err, ErrNoPhoto)
+}
+
+func TestPhotoService_moderatedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	photo := r.BuildingPhoto{ID: 5, Status: r.PhotoPending}
+	rejected := photo
+	rejected.Status = r.PhotoRejected
+	photoRepo := r.NewPhotoRepository_mock(t)
+	photoRepo.EXPECT().Query(mock.Anything, mock.MatchedBy(r.PhotoByIDIsEqual(5))).
+		Return([]r.BuildingPhoto{photo}, nil)
+	photoRepo.EXPECT().Update(mock.Anything, rejected).Return(nil, r.ErrNotExist)
+	s := NewPhotoService(photoRepo)
+
+	_, err := s.RejectPhoto(ctx, 5)
+	require.ErrorIs(t, err, ErrPhotoModerated)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+const PopularityPeriod = 7 * 24 * time.Hour
+
+// PopularityService records building views and search queries and
+// ranks buildings by views. Events keep a keyed hash of a user ID,
+// so they can not be linked to a Telegram account without the key.
+type PopularityService struct {
+	popularityCollection r.PopularityRepository
+	buildingCollection   r.BuildingRepository
+	key                  []byte
+	now                  func() time.Time
+}
+
+func NewPopularityService(
+	popularityCollection r.PopularityRepository,
+	buildingCollection r.BuildingRepository,
+	key []byte,
+) PopularityService {
+	return PopularityService{popularityCollection, buildingCollection, key, time.Now}
+}
+
+func (s PopularityService) hashUser(userID int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(strconv.FormatInt(userID, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s PopularityService) RecordView(ctx context.Context, userID, buildingID int64) error {
+	ctx, span := tracing.Start(ctx, "PopularityService.RecordView")
+	defer span.End()
+	event := r.PopularityEvent{
+		EventType:  r.ViewEvent,
+		UserHash:   s.hashUser(userID),
+		BuildingID: &buildingID,
+	}
+	if _, err := s.popularityCollection.Add(ctx, event); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not record a view of the building %v", buildingID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (s PopularityService) RecordSearch(
+	ctx context.Context,
+	userID int64,
+	searchType,
+	query string,
+) error {
+	ctx, span := tracing.Start(ctx, "PopularityService.RecordSearch")
+	defer span.End()
+	event := r.PopularityEvent{
+		EventType:   r.SearchEvent,
+		UserHash:    s.hashUser(userID),
+		SearchType:  &searchType,
+		SearchQuery: &query,
+	}
+	if _, err := s.popularityCollection.Add(ctx, event); err != nil {
+		slog.WarnContext(
+			ctx,
+			fmt.Sprintf("can not record a search '%v'", searchType),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return err
+	}
+	return nil
+}
+
+// GetPopularBuildings returns the most viewed buildings of the last
+// popularity period. An empty neighbourhood means all neighbourhoods.
+func (s PopularityService) GetPopularBuildings(
+	ctx context.Context,
+	neighbourhood string,
+	limit int,
+) ([]BuildingDTO, error) {
+	ctx, span := tracing.Start(ctx, "PopularityService.GetPopularBuildings")
+	defer span.End()
+	// the hour precision makes the query cacheable
+	since := s.now().Add(-PopularityPeriod).Truncate(time.Hour)
+	spec := r.NewBuildingSpecificationPopular(since, neighbourhood, limit)
+	buildings, err := s.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not get popular buildings in '%v'", neighbourhood),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	previews := make([]BuildingDTO, len(buildings))
+	for i, building := range buildings {
+		previews[i] = NewBuildingDTO(building, nil)
+	}
+	return previews, nil
+}
+
+// RemoveExpiredEvents removes events which are older than a retention period.
+func (s PopularityService) RemoveExpiredEvents(
+	ctx context.Context,
+	retention time.Duration,
+) (int64, error) {
+	ctx, span := tracing.Start(ctx, "PopularityService.RemoveExpiredEvents")
+	defer span.End()
+	removed, err := s.popularityCollection.RemoveBefore(ctx, s.now().Add(-retention))
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"can not remove expired popularity events",
+			slog.Any(logger.ErrorKey, err),
+		)
+		return 0, err
+	}
+	return removed, nil
+}
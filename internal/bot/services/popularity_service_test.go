@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopularityService_hashUser(t *testing.T) {
+	s := NewPopularityService(nil, nil, []byte("key"))
+	hash := s.hashUser(5)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, s.hashUser(5))
+	require.NotEqual(t, hash, s.hashUser(6))
+	other := NewPopularityService(nil, nil, []byte("other key"))
+	require.NotEqual(t, hash, other.hashUser(5))
+}
+
+func TestPopularityService_RecordView(t *testing.T) {
+	repositoryError := errors.New("test error")
+	for _, expectedErr := range []error{nil, repositoryError} {
+		repo := r.NewPopularityRepository_mock(t)
+		s := NewPopularityService(repo, nil, []byte("key"))
+		buildingID := int64(12)
+		expectedEvent := r.PopularityEvent{
+			EventType:  r.ViewEvent,
+			UserHash:   s.hashUser(5),
+			BuildingID: &buildingID,
+		}
+		repo.EXPECT().Add(mock.Anything, expectedEvent).Return(nil, expectedErr)
+		err := s.RecordView(context.Background(), 5, buildingID)
+		require.ErrorIs(t, err, expectedErr)
+	}
+}
+
+func TestPopularityService_RecordSearch(t *testing.T) {
+	repo := r.NewPopularityRepository_mock(t)
+	s := NewPopularityService(repo, nil, []byte("key"))
+	searchType, query := "address", "mannerheimintie"
+	expectedEvent := r.PopularityEvent{
+		EventType:   r.SearchEvent,
+		UserHash:    s.hashUser(5),
+		SearchType:  &searchType,
+		SearchQuery: &query,
+	}
+	repo.EXPECT().Add(mock.Anything, expectedEvent).Return(&expectedEvent, nil)
+	err := s.RecordSearch(context.Background(), 5, searchType, query)
+	require.NoError(t, err)
+}
+
+func TestPopularityService_GetPopularBuildings(t *testing.T) {
+	now := time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name            string
+		neighbourhood   string
+		buildings       []r.Building
+		repositoryError error
+		expected        []BuildingDTO
+	}{
+		{
+			"buildings",
+			"Lauttasaari",
+			[]r.Building{{ID: 1, Address: r.Address{StreetAddress: "test street"}}},
+			nil,
+			[]BuildingDTO{{ID: 1, Address: "test street"}},
+		},
+		{"no buildings", "", []r.Building{}, nil, []BuildingDTO{}},
+		{"an error", "", nil, repositoryError, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := r.NewBuildingRepository_mock(t)
+			s := NewPopularityService(nil, repo, []byte("key"))
+			s.now = func() time.Time { return now }
+			matchSpec := r.PopularSpecIsEqual(
+				now.Add(-7*24*time.Hour),
+				tt.neighbourhood,
+				10,
+			)
+			repo.EXPECT().Query(mock.Anything, mock.MatchedBy(matchSpec)).
+				Return(tt.buildings, tt.repositoryError)
+			got, err := s.GetPopularBuildings(context.Background(), tt.neighbourhood, 10)
+			require.ErrorIs(t, err, tt.repositoryError)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestPopularityService_RemoveExpiredEvents(t *testing.T) {
+	now := time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)
+	repo := r.NewPopularityRepository_mock(t)
+	s := NewPopularityService(repo, nil, []byte("key"))
+	s.now = func() time.Time { return now }
+	repo.EXPECT().RemoveBefore(mock.Anything, now.Add(-90*24*time.Hour)).Return(3, nil)
+	removed, err := s.RemoveExpiredEvents(context.Background(), 90*24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), removed)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+const (
+	EARTH_RADIUS_METERS = 6371000
+	// an average walking speed is about 5 km/h
+	WALKING_SPEED_METERS_PER_SECOND = 5000.0 / 3600
+	MAX_ROUTE_BUILDINGS             = 50
+)
+
+type RouteService struct {
+	buildingCollection r.BuildingRepository
+}
+
+func NewRouteService(buildingCollection r.BuildingRepository) RouteService {
+	return RouteService{buildingCollection}
+}
+
+// PlanRoute sorts buildings in an efficient walking order. A route
+// starts at the start point if it is set. Otherwise, a route starts
+// at the first building.
+func (s RouteService) PlanRoute(
+	ctx context.Context,
+	buildingIDs []int64,
+	start *Coordinates,
+) (*RouteDTO, error) {
+	ctx, span := tracing.Start(ctx, "RouteService.PlanRoute")
+	defer span.End()
+	if len(buildingIDs) > MAX_ROUTE_BUILDINGS {
+		return nil, fmt.Errorf("%v: %w", len(buildingIDs), ErrTooManyRouteBuildings)
+	}
+	spec := r.NewBuildingSpecificationByIDs(buildingIDs)
+	buildings, err := s.buildingCollection.Query(ctx, spec)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not get buildings for a route %v", buildingIDs),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return nil, err
+	}
+	buildingPerID := make(map[int64]r.Building, len(buildings))
+	for _, building := range buildings {
+		buildingPerID[building.ID] = building
+	}
+	// keep the requested order because the first building can be a start
+	var located []r.Building
+	var points []Coordinates
+	for _, buildingID := range buildingIDs {
+		building, ok := buildingPerID[buildingID]
+		if !ok {
+			continue
+		}
+		if building.Latitude_WGS84 == nil || building.Longitude_WGS84 == nil {
+			continue
+		}
+		located = append(located, building)
+		points = append(points, Coordinates{
+			*building.Latitude_WGS84,
+			*building.Longitude_WGS84,
+		})
+	}
+	if len(located) == 0 {
+		return nil, ErrNoRoute
+	}
+	order, distance := planWalkingOrder(start, points)
+	route := RouteDTO{
+		Buildings:        make([]BuildingDTO, len(order)),
+		DistanceMeters:   distance,
+		WalkingTime:      GetWalkingTime(distance),
+		SkippedBuildings: len(buildingIDs) - len(located),
+	}
+	for i, pointIndex := range order {
+		route.Buildings[i] = NewBuildingDTO(located[pointIndex], nil)
+	}
+	return &route, nil
+}
+
+// GetDistance returns a great-circle distance between two points in metres.
+func GetDistance(a, b Coordinates) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	deltaLat := lat2 - lat1
+	deltaLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Pow(math.Sin(deltaLat/2), 2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(deltaLon/2), 2)
+	return 2 * EARTH_RADIUS_METERS * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// GetBearing returns an initial compass bearing from a to b in degrees
+// clockwise from the north.
+func GetBearing(a, b Coordinates) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	deltaLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	y := math.Sin(deltaLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLon)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+	return math.Mod(bearing+360, 360)
+}
+
+// GetWalkingTime returns a walking time rounded to minutes.
+func GetWalkingTime(distanceMeters float64) time.Duration {
+	seconds := distanceMeters / WALKING_SPEED_METERS_PER_SECOND
+	return time.Duration(seconds * float64(time.Second)).Round(time.Minute)
+}
+
+// planWalkingOrder builds an open path with the nearest-neighbour heuristic
+// and improves it with 2-opt. The function returns indices of points
+// in a walking order and a path length including a way from a start.
+func planWalkingOrder(start *Coordinates, points []Coordinates) ([]int, float64) {
+	// the first node of a path is fixed
+	nodes := points
+	offset := 0
+	if start != nil {
+		nodes = append([]Coordinates{*start}, points...)
+		offset = 1
+	}
+	path := getNearestNeighbourPath(nodes)
+	path = improveWithTwoOpt(nodes, path)
+	distance := 0.0
+	for i := 1; i < len(path); i++ {
+		distance += GetDistance(nodes[path[i-1]], nodes[path[i]])
+	}
+	order := make([]int, 0, len(points))
+	for _, node := range path[offset:] {
+		order = append(order, node-offset)
+	}
+	return order, distance
+}
+
+func getNearestNeighbourPath(nodes []Coordinates) []int {
+	path := []int{0}
+	visited := make([]bool, len(nodes))
+	visited[0] = true
+	for len(path) < len(nodes) {
+		current := nodes[path[len(path)-1]]
+		nearest, nearestDistance := -1, math.Inf(1)
+		for i, node := range nodes {
+			if visited[i] {
+				continue
+			}
+			if distance := GetDistance(current, node); distance < nearestDistance {
+				nearest, nearestDistance = i, distance
+			}
+		}
+		visited[nearest] = true
+		path = append(path, nearest)
+	}
+	return path
+}
+
+func improveWithTwoOpt(nodes []Coordinates, path []int) []int {
+	// a minimal gain prevents endless loops caused by float rounding
+	const minGain = 1e-6
+	distance := func(i, j int) float64 {
+		return GetDistance(nodes[path[i]], nodes[path[j]])
+	}
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < len(path)-1; i++ {
+			for k := i + 1; k < len(path); k++ {
+				// an open path has no edge after the last node
+				removed := distance(i-1, i)
+				added := distance(i-1, k)
+				if k+1 < len(path) {
+					removed += distance(k, k+1)
+					added += distance(i, k+1)
+				}
+				if added < removed-minGain {
+					reverse(path[i : k+1])
+					improved = true
+				}
+			}
+		}
+	}
+	return path
+}
+
+func reverse(path []int) {
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDistance(t *testing.T) {
+	// the Helsinki Central Station and the Helsinki Cathedral
+	station := Coordinates{60.17099, 24.94136}
+	cathedral := Coordinates{60.17046, 24.95219}
+	require.InDelta(t, 602, GetDistance(station, cathedral), 5)
+	require.Equal(t, 0.0, GetDistance(station, station))
+}
+
+func TestGetBearing(t *testing.T) {
+	origin := Coordinates{60.17, 24.94}
+	tests := []struct {
+		name     string
+		to       Coordinates
+		expected float64
+	}{
+		{"north", Coordinates{60.18, 24.94}, 0},
+		{"east", Coordinates{60.17, 24.96}, 90},
+		{"south", Coordinates{60.16, 24.94}, 180},
+		{"west", Coordinates{60.17, 24.92}, 270},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, GetBearing(origin, tt.to), 0.1)
+		})
+	}
+}
+
+func TestGetWalkingTime(t *testing.T) {
+	require.Equal(t, 12*time.Minute, GetWalkingTime(1000))
+	require.Equal(t, time.Duration(0), GetWalkingTime(20))
+}
+
+func TestPlanWalkingOrder(t *testing.T) {
+	points := []Coordinates{
+		{60.0, 24.00},
+		{60.0, 24.02},
+		{60.0, 24.01},
+		{60.0, 24.03},
+	}
+	tests := []struct {
+		name          string
+		start         *Coordinates
+		expectedOrder []int
+	}{
+		{"the first building is a start", nil, []int{0, 2, 1, 3}},
+		{"a user location is a start", &Coordinates{60.0, 24.035}, []int{3, 1, 2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, distance := planWalkingOrder(tt.start, points)
+			require.Equal(t, tt.expectedOrder, order)
+			expectedDistance := GetDistance(points[0], points[3])
+			if tt.start != nil {
+				expectedDistance += GetDistance(*tt.start, points[3])
+			}
+			require.InDelta(t, expectedDistance, distance, 0.001)
+		})
+	}
+}
+
+func TestImproveWithTwoOpt(t *testing.T) {
+	nodes := []Coordinates{
+		{60.000, 24.000},
+		{60.001, 24.000},
+		{60.000, 24.002},
+		{60.001, 24.002},
+	}
+	// the path crosses itself
+	path := improveWithTwoOpt(nodes, []int{0, 3, 1, 2})
+	require.Equal(t, []int{0, 1, 3, 2}, path)
+}
+
+func TestRouteService_PlanRoute(t *testing.T) {
+	ctx := context.Background()
+	buildingRepo := r.NewBuildingRepository_mock(t)
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByIDsIsEqual([]int64{3, 1, 2, 4}))).
+		Return(
+			[]r.Building{
+				{
+					ID:              1,
+					Address:         r.Address{StreetAddress: "street 1"},
+					Latitude_WGS84:  utils.GetPointer(60.0),
+					Longitude_WGS84: utils.GetPointer(24.02),
+				},
+				{ID: 2, Address: r.Address{StreetAddress: "street 2"}},
+				{
+					ID:              3,
+					Address:         r.Address{StreetAddress: "street 3"},
+					Latitude_WGS84:  utils.GetPointer(60.0),
+					Longitude_WGS84: utils.GetPointer(24.00),
+				},
+				{
+					ID:              4,
+					Address:         r.Address{StreetAddress: "street 4"},
+					Latitude_WGS84:  utils.GetPointer(60.0),
+					Longitude_WGS84: utils.GetPointer(24.01),
+				},
+			},
+			nil,
+		).
+		Once()
+	buildingRepo.EXPECT().
+		Query(mock.Anything, mock.MatchedBy(r.BuildingByIDsIsEqual([]int64{2}))).
+		Return([]r.Building{{ID: 2}}, nil).
+		Once()
+	s := NewRouteService(buildingRepo)
+
+	route, err := s.PlanRoute(ctx, []int64{3, 1, 2, 4}, nil)
+	require.NoError(t, err)
+	var routeIDs []int64
+	for _, building := range route.Buildings {
+		routeIDs = append(routeIDs, building.ID)
+	}
+	require.Equal(t, []int64{3, 4, 1}, routeIDs)
+	require.Equal(t, 1, route.SkippedBuildings)
+	require.InDelta(t, 1115, route.DistanceMeters, 5)
+	require.Equal(t, 13*time.Minute, route.WalkingTime)
+
+	_, err = s.PlanRoute(ctx, []int64{2}, nil)
+	require.ErrorIs(t, err, ErrNoRoute)
+	_, err = s.PlanRoute(ctx, make([]int64, MAX_ROUTE_BUILDINGS+1), nil)
+	require.ErrorIs(t, err, ErrTooManyRouteBuildings)
+}
@@ -1,5 +1,7 @@
 package services
 
+import "time"
+
 type Language string
 
 var (
@@ -45,4 +47,83 @@ type BuildingDTO struct {
 	HistoryFi         *string   `valueLanguage:"fi" nameFi:"Rakennushistoria" nameEn:"Building_history" nameRu:"История_здания"`
 	HistoryEn         *string   `valueLanguage:"en" nameFi:"Rakennushistoria" nameEn:"Building_history" nameRu:"История_здания"`
 	HistoryRu         *string   `valueLanguage:"ru" nameFi:"Rakennushistoria" nameEn:"Building_history" nameRu:"История_здания"`
+	Latitude          *float64
+	Longitude         *float64
+	DistanceMeters    *float64
+}
+
+type CorrectionField string
+
+var (
+	NameField            = CorrectionField("name")
+	CompletionYearField  = CorrectionField("year")
+	DescriptionField     = CorrectionField("description")
+	FacadesField         = CorrectionField("facades")
+	DetailsField         = CorrectionField("details")
+	NotableFeaturesField = CorrectionField("features")
+	SurroundingsField    = CorrectionField("surroundings")
+	HistoryField         = CorrectionField("history")
+)
+
+// CorrectionFields lists building fields a user can correct in the order
+// they appear on a building card.
+var CorrectionFields = []CorrectionField{
+	NameField,
+	DescriptionField,
+	CompletionYearField,
+	FacadesField,
+	DetailsField,
+	NotableFeaturesField,
+	SurroundingsField,
+	HistoryField,
+}
+
+type CorrectionDTO struct {
+	ID              int64
+	BuildingID      int64
+	BuildingAddress string
+	Field           CorrectionField
+	Language        Language
+	CurrentValue    *string
+	Value           string
+	ReporterID      int64
+	ChatID          int64
+}
+
+type PhotoDTO struct {
+	ID           int64
+	BuildingID   int64
+	FileID       string
+	FileUniqueID string
+	Attribution  string
+	UploaderID   int64
+	ChatID       int64
+}
+
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type RouteDTO struct {
+	// Buildings are sorted in a walking order
+	Buildings      []BuildingDTO
+	DistanceMeters float64
+	WalkingTime    time.Duration
+	// SkippedBuildings is a number of buildings without coordinates
+	SkippedBuildings int
+}
+
+type NeighbourhoodDTO struct {
+	ID           int64
+	Name         string
+	Municipality *string
+}
+
+type ArchitectDTO struct {
+	ID      int64
+	Name    string
+	TitleFi *string
+	TitleEn *string
+	TitleRu *string
 }
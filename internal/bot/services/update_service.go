@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/logger"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+)
+
+// updateTracker finds the first update which has not been handled yet.
+// Telegram does not send updates before a confirmed offset again, so
+// the offset must not pass an update which is still being handled.
+type updateTracker struct {
+	mu          sync.Mutex
+	inProgress  map[int]struct{}
+	nextOffset  int
+	savedOffset int
+}
+
+func (t *updateTracker) receive(updateID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inProgress[updateID] = struct{}{}
+	t.nextOffset = max(t.nextOffset, updateID+1)
+}
+
+// finish returns a new offset and true if the offset has increased.
+func (t *updateTracker) finish(updateID int) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.inProgress, updateID)
+	offset := t.nextOffset
+	for inProgressID := range t.inProgress {
+		offset = min(offset, inProgressID)
+	}
+	if offset <= t.savedOffset {
+		return t.savedOffset, false
+	}
+	t.savedOffset = offset
+	return offset, true
+}
+
+// UpdateService makes update handling idempotent and persists
+// an update offset so that the bot resumes after a restart.
+type UpdateService struct {
+	updateCollection r.UpdateRepository
+	tracker          *updateTracker
+}
+
+func NewUpdateService(updateCollection r.UpdateRepository) UpdateService {
+	tracker := updateTracker{inProgress: map[int]struct{}{}}
+	return UpdateService{updateCollection, &tracker}
+}
+
+// GetOffset returns a saved offset to start receiving updates from.
+func (s UpdateService) GetOffset(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "UpdateService.GetOffset")
+	defer span.End()
+	offset, err := s.updateCollection.GetOffset(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "can not get an update offset", slog.Any(logger.ErrorKey, err))
+		return 0, err
+	}
+	s.tracker.mu.Lock()
+	defer s.tracker.mu.Unlock()
+	s.tracker.nextOffset = max(s.tracker.nextOffset, int(offset))
+	s.tracker.savedOffset = int(offset)
+	return int(offset), nil
+}
+
+// Receive registers an update. Updates should be received in the order
+// Telegram sends them.
+func (s UpdateService) Receive(updateID int) {
+	s.tracker.receive(updateID)
+}
+
+// IsHandled returns true if an update has already been handled,
+// for example, before a crash.
+func (s UpdateService) IsHandled(ctx context.Context, updateID int) (bool, error) {
+	ctx, span := tracing.Start(ctx, "UpdateService.IsHandled")
+	defer span.End()
+	handled, err := s.updateCollection.Exists(ctx, int64(updateID))
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not check the update %v", updateID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return false, err
+	}
+	return handled, nil
+}
+
+// Finish records a handled update in the ledger of processed updates and
+// saves an offset after all updates before the offset are handled.
+func (s UpdateService) Finish(ctx context.Context, updateID int) error {
+	ctx, span := tracing.Start(ctx, "UpdateService.Finish")
+	defer span.End()
+	var ledgerErr error
+	_, err := s.updateCollection.Add(ctx, r.ProcessedUpdate{UpdateID: int64(updateID)})
+	if err != nil && !errors.Is(err, r.ErrDuplicate) {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not record the update %v", updateID),
+			slog.Any(logger.ErrorKey, err),
+		)
+		// the offset is saved anyway, the update is handled
+		ledgerErr = err
+	}
+	offset, ok := s.tracker.finish(updateID)
+	if !ok {
+		return ledgerErr
+	}
+	if err := s.updateCollection.SetOffset(ctx, int64(offset)); err != nil {
+		slog.ErrorContext(
+			ctx,
+			fmt.Sprintf("can not save the update offset %v", offset),
+			slog.Any(logger.ErrorKey, err),
+		)
+		return errors.Join(ledgerErr, err)
+	}
+	return ledgerErr
+}
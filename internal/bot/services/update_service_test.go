@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	r "github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateService_IsHandled(t *testing.T) {
+	repositoryError := errors.New("test error")
+	tests := []struct {
+		name            string
+		exists          bool
+		repositoryError error
+		expected        bool
+	}{
+		{"a new update", false, nil, false},
+		{"a processed update", true, nil, true},
+		{"a repository error", false, repositoryError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := r.NewUpdateRepository_mock(t)
+			repo.EXPECT().Exists(mock.Anything, int64(5)).Return(tt.exists, tt.repositoryError)
+			got, err := NewUpdateService(repo).IsHandled(context.Background(), 5)
+			require.ErrorIs(t, err, tt.repositoryError)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestUpdateService_Finish(t *testing.T) {
+	ctx := context.Background()
+	repo := r.NewUpdateRepository_mock(t)
+	repo.EXPECT().Add(mock.Anything, mock.Anything).Return(nil, nil)
+	repo.EXPECT().GetOffset(mock.Anything).Return(10, nil)
+	s := NewUpdateService(repo)
+	offset, err := s.GetOffset(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 10, offset)
+
+	s.Receive(10)
+	s.Receive(11)
+	s.Receive(12)
+	// the update 10 is still being handled
+	require.NoError(t, s.Finish(ctx, 11))
+	require.NoError(t, s.Finish(ctx, 12))
+
+	repo.EXPECT().SetOffset(mock.Anything, int64(13)).Return(nil).Once()
+	require.NoError(t, s.Finish(ctx, 10))
+
+	s.Receive(13)
+	s.Receive(14)
+	repo.EXPECT().SetOffset(mock.Anything, int64(14)).Return(nil).Once()
+	require.NoError(t, s.Finish(ctx, 13))
+}
+
+func TestUpdateService_Finish_error(t *testing.T) {
+	ctx := context.Background()
+	repositoryError := errors.New("test error")
+	repo := r.NewUpdateRepository_mock(t)
+	repo.EXPECT().Add(mock.Anything, mock.Anything).Return(nil, nil)
+	repo.EXPECT().SetOffset(mock.Anything, int64(4)).Return(repositoryError).Once()
+	repo.EXPECT().SetOffset(mock.Anything, int64(5)).Return(nil).Once()
+	s := NewUpdateService(repo)
+	s.Receive(3)
+	s.Receive(4)
+	require.ErrorIs(t, s.Finish(ctx, 3), repositoryError)
+	// the next offset is saved anyway
+	require.NoError(t, s.Finish(ctx, 4))
+}
+
+func TestUpdateService_Finish_ledger(t *testing.T) {
+	ctx := context.Background()
+	repositoryError := errors.New("test error")
+	repo := r.NewUpdateRepository_mock(t)
+	repo.EXPECT().
+		Add(mock.Anything, r.ProcessedUpdate{UpdateID: 3}).
+		Return(nil, r.ErrDuplicate).Once()
+	repo.EXPECT().
+		Add(mock.Anything, r.ProcessedUpdate{UpdateID: 4}).
+		Return(nil, repositoryError).Once()
+	repo.EXPECT().SetOffset(mock.Anything, int64(4)).Return(nil).Once()
+	repo.EXPECT().SetOffset(mock.Anything, int64(5)).Return(nil).Once()
+	s := NewUpdateService(repo)
+	s.Receive(3)
+	s.Receive(4)
+	// a duplicate is an update handled before a restart
+	require.NoError(t, s.Finish(ctx, 3))
+	// the offset is saved even if the ledger fails
+	require.ErrorIs(t, s.Finish(ctx, 4), repositoryError)
+}
@@ -2,36 +2,117 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/cache"
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+const userCacheName = "users"
+
 type UserService struct {
 	userCollection repositories.UserRepository
+	metrics        *metrics.Metrics
+	// languages caches preferred languages per user ID.
+	// An empty language means that a user has no preference.
+	languages *cache.LRU[int64, Language]
 }
 
-func NewUserService(userCollection repositories.UserRepository) UserService {
-	return UserService{userCollection}
+func NewUserService(
+	userCollection repositories.UserRepository,
+	metrics *metrics.Metrics,
+	cacheSize int,
+	cacheTTL time.Duration,
+) UserService {
+	languages := cache.NewLRU[int64, Language](cacheSize, cacheTTL)
+	return UserService{userCollection, metrics, languages}
 }
 
 func (s UserService) GetPreferredLanguage(ctx context.Context, userID int64) (*Language, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetPreferredLanguage")
+	defer span.End()
+	language, ok := s.getCachedLanguage(userID)
+	if !ok {
+		var err error
+		language, err = s.queryLanguage(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheLanguage(userID, language)
+	}
+	if language == "" {
+		return nil, nil
+	}
+	return &language, nil
+}
+
+// queryLanguage returns an empty language if a user has no valid preference.
+func (s UserService) queryLanguage(ctx context.Context, userID int64) (Language, error) {
 	spec := repositories.NewUserSpecificationByID(userID)
 	users, err := s.userCollection.Query(ctx, spec)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	if len(users) == 0 {
-		return nil, nil
+		return "", nil
 	}
 	language, ok := GetLanguagePerCode(users[0].PreferredLanguage)
 	if !ok {
-		return nil, nil
+		return "", nil
 	}
-	return &language, nil
+	return language, nil
 }
 
 func (s UserService) SetLanguage(ctx context.Context, userID int64, language Language) error {
+	ctx, span := tracing.Start(ctx, "UserService.SetLanguage")
+	defer span.End()
 	user := repositories.User{TelegramID: userID, PreferredLanguage: string(language)}
-	_, err := s.userCollection.AddOrUpdate(ctx, user)
-	return err
+	if _, err := s.userCollection.AddOrUpdate(ctx, user); err != nil {
+		// the preference may have been saved before the error
+		s.languages.Remove(userID)
+		s.observeCacheSize()
+		return err
+	}
+	s.cacheLanguage(userID, language)
+	return nil
+}
+
+// ObserveLanguages loads numbers of users per preferred language into
+// a gauge. Only known languages become labels, so the label cardinality
+// stays bounded. A count reads all users, so a server calls it periodically
+// rather than on every change.
+func (s UserService) ObserveLanguages(ctx context.Context) error {
+	counts, err := s.userCollection.CountPerLanguage(ctx)
+	if err != nil {
+		return err
+	}
+	for code, language := range codePerLanguage {
+		labels := prometheus.Labels{"language": string(language)}
+		s.metrics.Languages.With(labels).Set(float64(counts[code]))
+	}
+	return nil
+}
+
+func (s UserService) getCachedLanguage(userID int64) (Language, bool) {
+	labels := prometheus.Labels{"cache": userCacheName}
+	language, ok := s.languages.Get(userID)
+	if ok {
+		s.metrics.CacheHits.With(labels).Inc()
+	} else {
+		s.metrics.CacheMisses.With(labels).Inc()
+	}
+	return language, ok
+}
+
+func (s UserService) cacheLanguage(userID int64, language Language) {
+	s.languages.Set(userID, language)
+	s.observeCacheSize()
+}
+
+func (s UserService) observeCacheSize() {
+	labels := prometheus.Labels{"cache": userCacheName}
+	s.metrics.CacheSize.With(labels).Set(float64(s.languages.Len()))
 }
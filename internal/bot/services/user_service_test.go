@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/AndreyAD1/helsinki-guide/internal/bot/infrastructure/repositories"
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -57,9 +61,10 @@ func TestUserService_SetLanguage(t *testing.T) {
 				PreferredLanguage: string(tt.args.language),
 			}
 			tt.fields.userCollection.EXPECT().
-				AddOrUpdate(tt.args.ctx, expectedUser).
+				AddOrUpdate(mock.Anything, expectedUser).
 				Return(nil, tt.repositoryError)
-			s := UserService{userCollection: tt.fields.userCollection}
+			m := metrics.NewMetrics(prometheus.NewRegistry())
+			s := NewUserService(tt.fields.userCollection, m, 10, time.Minute)
 			err := s.SetLanguage(tt.args.ctx, tt.args.userID, tt.args.language)
 			require.ErrorIs(t, err, tt.repositoryError)
 		})
@@ -142,13 +147,107 @@ func TestUserService_GetPreferredLanguage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			matchSpec := repositories.UserByIDIsEqual(tt.args.userID)
 			tt.fields.userCollection.EXPECT().Query(
-				tt.args.ctx,
+				mock.Anything,
 				mock.MatchedBy(matchSpec),
 			).Return(tt.foundUsers, tt.repositoryError)
-			us := UserService{userCollection: tt.fields.userCollection}
+			m := metrics.NewMetrics(prometheus.NewRegistry())
+			us := NewUserService(tt.fields.userCollection, m, 10, time.Minute)
 			got, err := us.GetPreferredLanguage(tt.args.ctx, tt.args.userID)
 			require.ErrorIs(t, err, tt.expectedError)
 			require.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestUserService_GetPreferredLanguage_cache(t *testing.T) {
+	tests := []struct {
+		name       string
+		foundUsers []repositories.User
+		want       *Language
+	}{
+		{"a preferred language", []repositories.User{{PreferredLanguage: "ru"}}, &Russian},
+		{"no preferred language", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := int64(123)
+			userCollection := repositories.NewUserRepository_mock(t)
+			userCollection.EXPECT().Query(
+				mock.Anything,
+				mock.MatchedBy(repositories.UserByIDIsEqual(userID)),
+			).Return(tt.foundUsers, nil).Once()
+			m := metrics.NewMetrics(prometheus.NewRegistry())
+			us := NewUserService(userCollection, m, 10, time.Minute)
+			for i := 0; i < 3; i++ {
+				got, err := us.GetPreferredLanguage(context.Background(), userID)
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			labels := prometheus.Labels{"cache": userCacheName}
+			require.Equal(t, 2.0, testutil.ToFloat64(m.CacheHits.With(labels)))
+			require.Equal(t, 1.0, testutil.ToFloat64(m.CacheMisses.With(labels)))
+			require.Equal(t, 1.0, testutil.ToFloat64(m.CacheSize.With(labels)))
+		})
+	}
+}
+
+func TestUserService_SetLanguage_cache(t *testing.T) {
+	tests := []struct {
+		name            string
+		repositoryError error
+		want            *Language
+	}{
+		{"success", nil, &Finnish},
+		{"error", errors.New("some DB error"), &English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := int64(123)
+			userCollection := repositories.NewUserRepository_mock(t)
+			userCollection.EXPECT().Query(
+				mock.Anything,
+				mock.MatchedBy(repositories.UserByIDIsEqual(userID)),
+			).Return([]repositories.User{{PreferredLanguage: "en"}}, nil).Once()
+			userCollection.EXPECT().AddOrUpdate(mock.Anything, mock.Anything).
+				Return(nil, tt.repositoryError).Once()
+			us := NewUserService(
+				userCollection,
+				metrics.NewMetrics(prometheus.NewRegistry()),
+				10,
+				time.Minute,
+			)
+			_, err := us.GetPreferredLanguage(context.Background(), userID)
+			require.NoError(t, err)
+			err = us.SetLanguage(context.Background(), userID, Finnish)
+			require.ErrorIs(t, err, tt.repositoryError)
+			if tt.repositoryError != nil {
+				userCollection.EXPECT().Query(
+					mock.Anything,
+					mock.MatchedBy(repositories.UserByIDIsEqual(userID)),
+				).Return([]repositories.User{{PreferredLanguage: "en"}}, nil).Once()
+			}
+			got, err := us.GetPreferredLanguage(context.Background(), userID)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserService_ObserveLanguages(t *testing.T) {
+	userCollection := repositories.NewUserRepository_mock(t)
+	userCollection.EXPECT().CountPerLanguage(mock.Anything).
+		Return(map[string]int{"en": 5, "ru": 2, "unknown": 1}, nil).Once()
+	countErr := errors.New("a count error")
+	userCollection.EXPECT().CountPerLanguage(mock.Anything).Return(nil, countErr).Once()
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	us := NewUserService(userCollection, m, 10, time.Minute)
+
+	require.NoError(t, us.ObserveLanguages(context.Background()))
+	expected := map[Language]float64{English: 5, Russian: 2, Finnish: 0}
+	for language, count := range expected {
+		observed := m.Languages.WithLabelValues(string(language))
+		require.Equal(t, count, testutil.ToFloat64(observed), language)
+	}
+	require.Equal(t, len(expected), testutil.CollectAndCount(m.Languages))
+	require.ErrorIs(t, us.ObserveLanguages(context.Background()), countErr)
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer starts a span for every SQL query of a connection pool.
+// Query arguments are not recorded because they may contain user data.
+type QueryTracer struct{}
+
+func (t QueryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	ctx, _ = Start(
+		ctx,
+		"db.query",
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.statement", data.SQL),
+	)
+	return ctx
+}
+
+func (t QueryTracer) TraceQueryEnd(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryEndData,
+) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	End(span, data.Err)
+}
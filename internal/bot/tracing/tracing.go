@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/AndreyAD1/helsinki-guide"
+	serviceName         = "helsinki-guide"
+)
+
+// Start starts a span. A span is not recorded until
+// a tracer provider is set.
+func Start(
+	ctx context.Context,
+	name string,
+	attributes ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	// a global provider may change after an import of this package
+	tracer := otel.Tracer(instrumentationName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End marks a span as failed if an error occurs and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewProvider returns a tracer provider which sends spans
+// to an OTLP HTTP endpoint, for example, http://localhost:4318.
+func NewProvider(ctx context.Context, endpoint string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("can not create an OTLP exporter %v: %w", endpoint, err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+	)
+	return provider, nil
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/bot/tracing/tracingtest"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestStartEnd(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+		expectedEvents int
+	}{
+		{"success", nil, codes.Unset, 0},
+		{"error", errors.New("test error"), codes.Error, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracingtest.SetExporter(t)
+			ctx, parent := Start(context.Background(), "parent")
+			_, child := Start(ctx, "child", attribute.Int("key", 1))
+			End(child, tt.err)
+			parent.End()
+
+			spans := exporter.GetSpans()
+			require.Equal(t, []string{"child", "parent"}, tracingtest.GetSpanNames(exporter))
+			require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+			require.Equal(t, []attribute.KeyValue{attribute.Int("key", 1)}, spans[0].Attributes)
+			require.Equal(t, tt.expectedStatus, spans[0].Status.Code)
+			require.Len(t, spans[0].Events, tt.expectedEvents)
+		})
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	exporter := tracingtest.SetExporter(t)
+	ctx, parent := Start(context.Background(), "parent")
+	tracer := QueryTracer{}
+	queryErr := errors.New("test error")
+
+	queryCtx := tracer.TraceQueryStart(
+		ctx,
+		nil,
+		pgx.TraceQueryStartData{SQL: "SELECT 1", Args: []any{60.123456}},
+	)
+	tracer.TraceQueryEnd(
+		queryCtx,
+		nil,
+		pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: queryErr},
+	)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"db.query", "parent"}, tracingtest.GetSpanNames(exporter))
+	require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	expectedAttributes := []attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.statement", "SELECT 1"),
+		attribute.Int64("db.rows_affected", 1),
+	}
+	require.Equal(t, expectedAttributes, spans[0].Attributes)
+	require.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(context.Background(), "http://localhost:4318")
+	require.NoError(t, err)
+	require.NoError(t, provider.Shutdown(context.Background()))
+}
@@ -0,0 +1,32 @@
+// Package tracingtest records spans in memory for tests.
+package tracingtest
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// SetExporter sets a global tracer provider which keeps spans in memory
+// until a test ends. Tests which use it should not run in parallel.
+func SetExporter(t testing.TB) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+	return exporter
+}
+
+// GetSpanNames returns names of ended spans in the order of their end.
+func GetSpanNames(exporter *tracetest.InMemoryExporter) []string {
+	names := []string{}
+	for _, span := range exporter.GetSpans() {
+		names = append(names, span.Name)
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package export
+
+import "math"
+
+// cellsPerTile splits a tile of 256 pixels of web maps into cells of 64 pixels
+const cellsPerTile = 4
+
+// Cluster is a group of places which are close to each other on a map
+// of some zoom level.
+type Cluster struct {
+	// a position of a cluster is an average position of its places
+	Latitude  float64
+	Longitude float64
+	Places    []Place
+}
+
+type cell struct {
+	x int
+	y int
+}
+
+// ClusterPlaces groups places by cells of a Web Mercator grid of a zoom
+// level of web maps. Clusters keep an order of the first places of cells.
+func ClusterPlaces(places []Place, zoom int) []Cluster {
+	cellsNumber := math.Exp2(float64(zoom)) * cellsPerTile
+	clusterPerCell := map[cell]int{}
+	var clusters []Cluster
+	for _, place := range places {
+		x, y := project(place.Latitude, place.Longitude)
+		placeCell := cell{int(x * cellsNumber), int(y * cellsNumber)}
+		i, ok := clusterPerCell[placeCell]
+		if !ok {
+			i = len(clusters)
+			clusterPerCell[placeCell] = i
+			clusters = append(clusters, Cluster{})
+		}
+		clusters[i].Latitude += place.Latitude
+		clusters[i].Longitude += place.Longitude
+		clusters[i].Places = append(clusters[i].Places, place)
+	}
+	for i := range clusters {
+		placeNumber := float64(len(clusters[i].Places))
+		clusters[i].Latitude /= placeNumber
+		clusters[i].Longitude /= placeNumber
+	}
+	return clusters
+}
+
+// project converts coordinates into Web Mercator coordinates from 0 to 1,
+// y grows to the south.
+func project(latitude, longitude float64) (float64, float64) {
+	x := (longitude + 180) / 360
+	sin := math.Sin(latitude * math.Pi / 180)
+	y := 0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi)
+	return x, y
+}
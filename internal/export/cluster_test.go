@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterPlaces(t *testing.T) {
+	kauppahalli := Place{ID: 1, Name: "Kauppahalli", Latitude: 60.16717, Longitude: 24.95375}
+	market := Place{ID: 2, Name: "Market", Latitude: 60.16737, Longitude: 24.95395}
+	kallio := Place{ID: 3, Name: "Kallio", Latitude: 60.1888, Longitude: 24.9513}
+	places := []Place{kauppahalli, kallio, market}
+	tests := []struct {
+		name     string
+		zoom     int
+		expected []Cluster
+	}{
+		{
+			"a city zoom",
+			10,
+			[]Cluster{{60.17445, 24.95300, places}},
+		},
+		{
+			"a district zoom",
+			14,
+			[]Cluster{
+				{60.16727, 24.95385, []Place{kauppahalli, market}},
+				{60.1888, 24.9513, []Place{kallio}},
+			},
+		},
+		{
+			"a street zoom",
+			20,
+			[]Cluster{
+				{60.16717, 24.95375, []Place{kauppahalli}},
+				{60.1888, 24.9513, []Place{kallio}},
+				{60.16737, 24.95395, []Place{market}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters := ClusterPlaces(places, tt.zoom)
+			require.Len(t, clusters, len(tt.expected))
+			for i, cluster := range clusters {
+				require.InDelta(t, tt.expected[i].Latitude, cluster.Latitude, 1e-5)
+				require.InDelta(t, tt.expected[i].Longitude, cluster.Longitude, 1e-5)
+				require.Equal(t, tt.expected[i].Places, cluster.Places)
+			}
+		})
+	}
+	require.Empty(t, ClusterPlaces(nil, 10))
+}
+
+func TestNewClusterCollection(t *testing.T) {
+	kauppahalli := Place{
+		ID:        1,
+		Name:      "Kauppahalli",
+		Address:   "Eteläranta 1",
+		Latitude:  60.1,
+		Longitude: 24.9,
+	}
+	clusters := []Cluster{
+		{60.1, 24.9, []Place{kauppahalli}},
+		{60.2, 25, []Place{kauppahalli, kauppahalli}},
+	}
+	var buffer bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buffer).Encode(NewClusterCollection(clusters)))
+	expected := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[24.9,60.1]},
+		"properties":{"name":"Kauppahalli","address":"Eteläranta 1","year":null,"authors":[]}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[25,60.2]},
+		"properties":{"cluster":true,"count":2}}
+	]}`
+	require.JSONEq(t, expected, buffer.String())
+}
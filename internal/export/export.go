@@ -0,0 +1,71 @@
+// Package export serializes places into formats of GIS and hiking apps.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	GPX     = Format("gpx")
+	KML     = Format("kml")
+	GeoJSON = Format("geojson")
+)
+
+var Formats = []Format{GPX, KML, GeoJSON}
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+type Place struct {
+	// ID is an optional identifier of a place, zero means no identifier
+	ID        int64
+	Name      string
+	Address   string
+	Year      *int
+	Authors   []string
+	Latitude  float64
+	Longitude float64
+}
+
+type writer func(io.Writer, []Place) error
+
+var writerPerFormat = map[Format]writer{
+	GPX:     WriteGPX,
+	KML:     WriteKML,
+	GeoJSON: WriteGeoJSON,
+}
+
+// Write serializes places into a format.
+func Write(w io.Writer, format Format, places []Place) error {
+	write, ok := writerPerFormat[format]
+	if !ok {
+		return fmt.Errorf("%v: %w", format, ErrUnknownFormat)
+	}
+	return write(w, places)
+}
+
+// FileName returns a file name with an extension of a format.
+func FileName(name string, format Format) string {
+	return name + "." + string(format)
+}
+
+func formatCoordinate(coordinate float64) string {
+	return strconv.FormatFloat(coordinate, 'f', -1, 64)
+}
+
+// getDescription returns a plain text for formats without custom fields.
+func getDescription(place Place) string {
+	lines := []string{place.Address}
+	if place.Year != nil {
+		lines = append(lines, strconv.Itoa(*place.Year))
+	}
+	if len(place.Authors) > 0 {
+		lines = append(lines, strings.Join(place.Authors, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AndreyAD1/helsinki-guide/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testPlaces = []Place{
+	{
+		Name:      "Kauppahalli",
+		Address:   "Eteläranta 1",
+		Year:      utils.GetPointer(1889),
+		Authors:   []string{"Gustaf Nyström"},
+		Latitude:  60.16717,
+		Longitude: 24.95375,
+	},
+	{
+		Name:      "Asuinkerrostalo <& \"Ilo\">",
+		Address:   "Itäinen Papinkatu 12",
+		Authors:   []string{"Harald Andersin", "Elsi Borg"},
+		Latitude:  60.1888,
+		Longitude: 24.9513,
+	},
+	{
+		Name:      "Здание",
+		Address:   "Mannerheimintie 3",
+		Latitude:  60.17,
+		Longitude: 24.94,
+	},
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		places []Place
+		golden string
+	}{
+		{"gpx", GPX, testPlaces, "places.gpx"},
+		{"kml", KML, testPlaces, "places.kml"},
+		{"geojson", GeoJSON, testPlaces, "places.geojson"},
+		{"empty gpx", GPX, nil, "empty.gpx"},
+		{"empty kml", KML, nil, "empty.kml"},
+		{"empty geojson", GeoJSON, nil, "empty.geojson"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := Write(&buffer, tt.format, tt.places)
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", tt.golden+".golden")
+			if *update {
+				err := os.WriteFile(goldenPath, buffer.Bytes(), 0644)
+				require.NoError(t, err)
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), buffer.String())
+		})
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Write(&buffer, Format("shp"), testPlaces)
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	require.Empty(t, buffer.String())
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "buildings.geojson", FileName("buildings", GeoJSON))
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// FeatureCollection is a GeoJSON object which can be encoded as JSON.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string `json:"type"`
+	ID         int64  `json:"id,omitempty"`
+	Geometry   point  `json:"geometry"`
+	Properties any    `json:"properties"`
+}
+
+type point struct {
+	Type string `json:"type"`
+	// GeoJSON sets a longitude before a latitude
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type featureProperties struct {
+	Name    string   `json:"name"`
+	Address string   `json:"address"`
+	Year    *int     `json:"year"`
+	Authors []string `json:"authors"`
+}
+
+type clusterProperties struct {
+	Cluster bool `json:"cluster"`
+	Count   int  `json:"count"`
+}
+
+func newPoint(latitude, longitude float64) point {
+	return point{"Point", [2]float64{longitude, latitude}}
+}
+
+func newPlaceFeature(place Place) feature {
+	authors := place.Authors
+	if authors == nil {
+		authors = []string{}
+	}
+	return feature{
+		Type:     "Feature",
+		ID:       place.ID,
+		Geometry: newPoint(place.Latitude, place.Longitude),
+		Properties: featureProperties{
+			Name:    place.Name,
+			Address: place.Address,
+			Year:    place.Year,
+			Authors: authors,
+		},
+	}
+}
+
+// NewFeatureCollection returns a FeatureCollection of points.
+func NewFeatureCollection(places []Place) FeatureCollection {
+	collection := FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]feature, len(places)),
+	}
+	for i, place := range places {
+		collection.Features[i] = newPlaceFeature(place)
+	}
+	return collection
+}
+
+// NewClusterCollection returns a FeatureCollection where a cluster of
+// one place is a point of the place and a larger cluster is a point
+// with a number of places.
+func NewClusterCollection(clusters []Cluster) FeatureCollection {
+	collection := FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]feature, len(clusters)),
+	}
+	for i, cluster := range clusters {
+		if len(cluster.Places) == 1 {
+			collection.Features[i] = newPlaceFeature(cluster.Places[0])
+			continue
+		}
+		collection.Features[i] = feature{
+			Type:       "Feature",
+			Geometry:   newPoint(cluster.Latitude, cluster.Longitude),
+			Properties: clusterProperties{true, len(cluster.Places)},
+		}
+	}
+	return collection
+}
+
+// WriteGeoJSON writes places as a GeoJSON FeatureCollection of points.
+func WriteGeoJSON(w io.Writer, places []Place) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewFeatureCollection(places))
+}
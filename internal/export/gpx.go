@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Namespace string        `xml:"xmlns,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+type gpxWaypoint struct {
+	Latitude    string `xml:"lat,attr"`
+	Longitude   string `xml:"lon,attr"`
+	Name        string `xml:"name"`
+	Description string `xml:"desc,omitempty"`
+	Type        string `xml:"type"`
+}
+
+// WriteGPX writes places as GPX 1.1 waypoints.
+func WriteGPX(w io.Writer, places []Place) error {
+	document := gpxDocument{
+		Version:   "1.1",
+		Creator:   "helsinki-guide",
+		Namespace: "http://www.topografix.com/GPX/1/1",
+		Waypoints: make([]gpxWaypoint, len(places)),
+	}
+	for i, place := range places {
+		document.Waypoints[i] = gpxWaypoint{
+			Latitude:    formatCoordinate(place.Latitude),
+			Longitude:   formatCoordinate(place.Longitude),
+			Name:        place.Name,
+			Description: getDescription(place),
+			Type:        "Building",
+		}
+	}
+	return writeXML(w, document)
+}
+
+func writeXML(w io.Writer, document any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
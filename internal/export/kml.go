@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type kmlDocument struct {
+	XMLName   xml.Name `xml:"kml"`
+	Namespace string   `xml:"xmlns,attr"`
+	Document  kmlFolder
+}
+
+type kmlFolder struct {
+	XMLName    xml.Name       `xml:"Document"`
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name         string    `xml:"name"`
+	Address      string    `xml:"address"`
+	Description  string    `xml:"description"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Coordinates  string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// WriteKML writes places as KML 2.2 placemarks.
+func WriteKML(w io.Writer, places []Place) error {
+	document := kmlDocument{
+		Namespace: "http://www.opengis.net/kml/2.2",
+		Document: kmlFolder{
+			Name:       "Helsinki buildings",
+			Placemarks: make([]kmlPlacemark, len(places)),
+		},
+	}
+	for i, place := range places {
+		data := []kmlData{{"address", place.Address}}
+		if place.Year != nil {
+			data = append(data, kmlData{"year", strconv.Itoa(*place.Year)})
+		}
+		if len(place.Authors) > 0 {
+			data = append(data, kmlData{"authors", strings.Join(place.Authors, ", ")})
+		}
+		document.Document.Placemarks[i] = kmlPlacemark{
+			Name:         place.Name,
+			Address:      place.Address,
+			Description:  getDescription(place),
+			ExtendedData: data,
+			// KML sets a longitude before a latitude
+			Coordinates: formatCoordinate(place.Longitude) + "," + formatCoordinate(place.Latitude),
+		}
+	}
+	return writeXML(w, document)
+}
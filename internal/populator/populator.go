@@ -21,6 +21,7 @@ type Populator struct {
 	actorRepo         repositories.ActorRepository
 	neighbourhoodRepo repositories.NeighbourhoodRepository
 	converterClient   clients.CoordinateConverter
+	buildingChanges   *repositories.BuildingChangeStorage
 }
 
 func NewPopulator(ctx context.Context, config configuration.PopulatorConfig) (*Populator, error) {
@@ -50,6 +51,7 @@ func NewPopulator(ctx context.Context, config configuration.PopulatorConfig) (*P
 		repositories.NewActorRepo(dbpool),
 		repositories.NewNeighbourhoodRepo(dbpool),
 		clients.NewEPSGClient(config.ConverterURL, 10),
+		repositories.NewBuildingChangeRepo(dbpool),
 	}
 	return &populator, nil
 }
@@ -62,6 +64,12 @@ func (p *Populator) Run(
 	ruFilename string,
 	firstRowNumber int,
 ) error {
+	// running bots cache buildings, so they should reread even partial changes
+	defer func() {
+		if err := p.buildingChanges.Notify(ctx); err != nil {
+			log.Printf("can not notify bots about building changes: %v", err)
+		}
+	}()
 	var rowSet []*excelize.Rows
 	for _, filename := range []string{fiFilename, enFilename, ruFilename} {
 		source, err := excelize.OpenFile(filename)